@@ -2,23 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 	_ "github.com/kakaovx/cursor-slack-server/docs" // Swagger docs
 	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/forge"
 	"github.com/kakaovx/cursor-slack-server/internal/ngrok"
+	"github.com/kakaovx/cursor-slack-server/internal/process"
+	"github.com/kakaovx/cursor-slack-server/internal/redact"
 	"github.com/kakaovx/cursor-slack-server/internal/server"
 	"github.com/kakaovx/cursor-slack-server/internal/setup"
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
 	"github.com/kakaovx/cursor-slack-server/internal/worker"
 )
 
@@ -51,9 +59,21 @@ import (
 // @name X-Slack-Request-Timestamp
 // @description Slack 요청 타임스탬프 (Unix timestamp)
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description /api 인증: "Bearer <API 키>" (v1.5)
+
+// @securityDefinitions.apikey APIKeyHeader
+// @in header
+// @name X-API-Key
+// @description /api 인증: API 키 (v1.5)
+
 func main() {
 	// CLI 플래그 파싱
 	setupMode := flag.Bool("setup", false, "대화형 설정 마법사 실행")
+	createAPIKey := flag.String("create-api-key", "", "지정한 이름으로 /api 인증용 API 키를 발급하고 종료 (v1.5)")
+	apiKeyScopes := flag.String("api-key-scopes", "run,read,configure", "--create-api-key로 발급할 키의 권한 범위 (run, read, configure)")
 	flag.Parse()
 
 	// 설정 모드인 경우 설정 마법사 실행
@@ -78,7 +98,12 @@ func main() {
 			f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 			if err == nil {
 				// stdout과 파일 둘 다에 로그 출력
-				mw := io.MultiWriter(os.Stdout, f)
+				// v1.5: API 키 발급 모드에서는 stdout에 키만 출력되도록 로그를 stderr로 보냄
+				console := io.Writer(os.Stdout)
+				if *createAPIKey != "" {
+					console = os.Stderr
+				}
+				mw := io.MultiWriter(console, f)
 				log.SetOutput(mw)
 				log.Printf("📝 로그 파일: %s", logFile)
 			}
@@ -111,6 +136,21 @@ func main() {
 		}
 	}
 
+	// v1.5: 비밀 값 가리기 (작업 출력/diff/계획, Slack에 표시하는 프롬프트, 서버 로그)
+	// REDACT_PATTERNS_FILE: 기본 탐지 규칙에 더할 정규식 파일 (한 줄에 하나, #으로 시작하면 주석)
+	// REDACT_HIGH_ENTROPY=off: 엔트로피가 높은 문자열은 가리지 않음 (기본값: on)
+	var redactPatterns []*regexp.Regexp
+	if patternsFile := os.Getenv("REDACT_PATTERNS_FILE"); patternsFile != "" {
+		redactPatterns, err = redact.LoadPatterns(patternsFile)
+		if err != nil {
+			log.Fatalf("REDACT_PATTERNS_FILE 설정 오류: %v", err)
+		}
+		log.Printf("🔒 추가 비밀 값 패턴 %d개를 불러왔습니다: %s", len(redactPatterns), patternsFile)
+	}
+	redactor := redact.New(redactPatterns)
+	redactor.HighEntropy = !strings.EqualFold(os.Getenv("REDACT_HIGH_ENTROPY"), "off")
+	log.SetOutput(redact.NewWriter(log.Writer(), redactor))
+
 	// 환경변수 로드
 	signingSecret := os.Getenv("SLACK_SIGNING_SECRET")
 	if signingSecret == "" {
@@ -174,6 +214,26 @@ func main() {
 		log.Printf("ℹ️  CURSOR_CLI_PATH 사용: %s", cursorCLIPath)
 	}
 
+	// v1.5: 에이전트 백엔드 (cursor-agent 기본 제공)
+	// CURSOR_MODELS: cursor-agent에서 --model로 선택할 수 있는 모델 허용 목록 (쉼표로 구분, 비어 있으면 --model 비활성화)
+	// AGENTS_CONFIG: 다른 코딩 CLI를 명령어 템플릿으로 추가하는 JSON 파일 (default로 기본 백엔드 변경)
+	var cursorModels []string
+	for _, model := range strings.Split(os.Getenv("CURSOR_MODELS"), ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			cursorModels = append(cursorModels, model)
+		}
+	}
+	if len(cursorModels) > 0 {
+		log.Printf("🧠 cursor-agent 모델 허용 목록: %v", cursorModels)
+	}
+	agents := worker.NewAgentRegistry(worker.NewCursorAgent(cursorCLIPath, cursorModels))
+	if agentsConfig := os.Getenv("AGENTS_CONFIG"); agentsConfig != "" {
+		if err := agents.LoadAgents(agentsConfig); err != nil {
+			log.Fatalf("AGENTS_CONFIG 설정 오류: %v", err)
+		}
+		log.Printf("🤖 에이전트 백엔드: %s (기본값: %s)", strings.Join(agents.Names(), ", "), agents.Default())
+	}
+
 	// v1.1: SSRF 방어용 허용 도메인 설정
 	allowedDomains := []string{"hooks.slack.com"}
 	log.Printf("ℹ️  SSRF 방어: 허용 도메인 = %v", allowedDomains)
@@ -211,6 +271,46 @@ func main() {
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Println()
 
+	// v1.5: API 키 발급 모드 (첫 키는 서버에서 직접 발급, 이후에는 configure 권한 키로 POST /api/keys)
+	if *createAPIKey != "" {
+		scopes, err := database.ParseScopes([]string{*apiKeyScopes})
+		if err != nil {
+			log.Fatalf("--api-key-scopes 설정 오류: %v", err)
+		}
+		key, plain, err := database.NewAPIKey(*createAPIKey, scopes, nil, "cli")
+		if err == nil {
+			err = db.CreateAPIKey(key)
+		}
+		if err != nil {
+			log.Fatalf("API 키 발급 실패: %v", err)
+		}
+		log.Printf("🔑 API 키 발급: %s (ID: %s, 권한: %s)", key.Name, key.ID, strings.Join(key.Scopes, ","))
+		// v1.5: 감사 로그 기록
+		if err := db.AppendAuditEvent(&database.AuditEvent{
+			ActorType: database.AuditActorCLI,
+			ActorID:   "cli",
+			Action:    database.AuditAPIKeyCreate,
+			Target:    key.ID,
+			After:     fmt.Sprintf("%s (%s, 권한: %s)", key.Name, key.Prefix, strings.Join(key.Scopes, ",")),
+		}); err != nil {
+			log.Printf("⚠️  감사 이벤트 기록 실패: %v", err)
+		}
+		fmt.Println(plain)
+		log.Println("⚠️  키 원문은 다시 확인할 수 없습니다. 안전한 곳에 보관하세요.")
+		db.Close()
+		return
+	}
+
+	// v1.5: 같은 DB를 사용하는 서버는 하나만 실행 (작업 큐, 실행 중인 작업 취소, 프롬프트 원문은 프로세스 메모리에 있으므로
+	// 두 번째 서버가 시작되면 첫 번째 서버의 실행 중인 작업을 중단된 작업으로 정리하게 됨)
+	unlockDB, err := process.LockFile(dbPath + ".lock")
+	if err != nil {
+		if errors.Is(err, process.ErrLocked) {
+			log.Fatalf("같은 데이터베이스(%s)를 사용하는 서버가 이미 실행 중입니다. 기존 서버를 종료하거나 DB_PATH를 바꿔주세요.", absDbPath)
+		}
+		log.Fatalf("데이터베이스 잠금 실패: %v", err)
+	}
+
 	// v1.4: Worker Pool 설정
 	maxWorkers := 3 // 기본값: 3개의 동시 작업
 	if maxWorkersEnv := os.Getenv("MAX_WORKERS"); maxWorkersEnv != "" {
@@ -219,33 +319,274 @@ func main() {
 		}
 	}
 	
-	// 작업 큐 생성 (버퍼 크기: maxWorkers * 2)
-	jobQueue := make(chan worker.Job, maxWorkers*2)
-	
+	// v1.5: 작업 시간 제한
+	// JOB_TIMEOUT: 작업 시간 제한 기본값 (Go duration 형식, 예: 15m, 1h, 프로젝트/요청별로 변경 가능)
+	// JOB_TIMEOUT_MAX: 프로젝트 설정과 --timeout으로 지정할 수 있는 최대값 (HTTP 서버 타임아웃도 이 값에 맞춤)
+	jobTimeout, maxJobTimeout := worker.DefaultJobTimeout, worker.DefaultMaxJobTimeout
+	for _, setting := range []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"JOB_TIMEOUT", &jobTimeout},
+		{"JOB_TIMEOUT_MAX", &maxJobTimeout},
+	} {
+		if v := os.Getenv(setting.env); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil || parsed < time.Minute {
+				log.Fatalf("%s 설정 오류: %q (1분 이상, 예: 15m, 1h)", setting.env, v)
+			}
+			*setting.value = parsed
+		}
+	}
+	if jobTimeout > maxJobTimeout {
+		log.Fatalf("JOB_TIMEOUT(%s)이 JOB_TIMEOUT_MAX(%s)보다 깁니다", jobTimeout, maxJobTimeout)
+	}
+	// JOB_KILL_GRACE: 시간 초과/취소 시 SIGTERM 후 SIGKILL까지 기다리는 시간 (0이면 바로 SIGKILL, Windows는 항상 바로 종료)
+	killGrace := worker.DefaultKillGrace
+	if v := os.Getenv("JOB_KILL_GRACE"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed < 0 {
+			log.Fatalf("JOB_KILL_GRACE 설정 오류: %q (0 이상, 예: 10s, 1m)", v)
+		}
+		killGrace = parsed
+	}
+	log.Printf("⏱️  작업 시간 제한: 기본 %s, 최대 %s (종료 유예 %s)", worker.FormatDuration(jobTimeout), worker.FormatDuration(maxJobTimeout), killGrace)
+
+	// v1.5: 작업 큐 생성 (job_records 테이블 기반 영속 큐)
+	jobQueue := worker.NewQueue(db)
+	jobQueue.Redactor = redactor // v1.5: job_records.prompt에는 비밀 값을 가린 프롬프트만 저장
+
+	// v1.5: 이전 실행에서 running 상태로 남은 작업 정리
+	// ORPHANED_JOB_POLICY=fail(기본값): 사유와 함께 실패 처리
+	// ORPHANED_JOB_POLICY=requeue: 다시 대기열에 등록 (JOB_MAX_ATTEMPTS회까지, 중단 전 수정한 파일 위에서 다시 실행됨)
+	var requeueOrphans bool
+	switch policy := strings.ToLower(strings.TrimSpace(os.Getenv("ORPHANED_JOB_POLICY"))); policy {
+	case "", "fail":
+	case "requeue":
+		requeueOrphans = true
+	default:
+		log.Fatalf("알 수 없는 ORPHANED_JOB_POLICY: %s (fail, requeue 중 선택)", policy)
+	}
+	maxAttempts := 2 // 기본값: 최초 실행 + 재시작 후 1회 재시도
+	if maxAttemptsEnv := os.Getenv("JOB_MAX_ATTEMPTS"); maxAttemptsEnv != "" {
+		if parsed, err := strconv.Atoi(maxAttemptsEnv); err == nil && parsed > 0 {
+			maxAttempts = parsed
+		}
+	}
+	requeued, failed, err := db.ReconcileOrphanedJobs(requeueOrphans, maxAttempts)
+	if err != nil {
+		log.Fatalf("중단된 작업 정리 실패: %v", err)
+	}
+	if requeued > 0 || failed > 0 {
+		log.Printf("♻️  이전 실행에서 중단된 작업 정리: 재등록 %d개, 실패 처리 %d개", requeued, failed)
+	}
+
 	// TaskExecutor 생성
 	taskExecutor := worker.NewTaskExecutor(allowedDomains)
-	
-	// Dispatcher 생성 및 시작
-	dispatcher := worker.NewDispatcher(jobQueue, maxWorkers)
-	dispatcher.Start(taskExecutor)
-	
-	log.Printf("🔧 Worker Pool 초기화 완료: %d개 작업자, 큐 크기: %d", maxWorkers, maxWorkers*2)
-	log.Println()
+	taskExecutor.Redactor = redactor
+
+	// v1.5: 작업별 git worktree 격리
+	// WORKTREE_MODE=on: 각 작업을 cursor/job-<ID> 브랜치의 별도 worktree에서 실행 (MAX_WORKERS > 1에서 같은 저장소 동시 수정 방지)
+	// WORKTREE_DIR: worktree 생성 위치 (기본값: DB 디렉토리/worktrees)
+	// WORKTREE_CLEANUP: if-clean(기본값, 변경 없을 때만 삭제) / always / keep
+	if os.Getenv("WORKTREE_MODE") == "on" {
+		cleanup, err := worker.ParseWorktreeCleanup(os.Getenv("WORKTREE_CLEANUP"))
+		if err != nil {
+			log.Fatalf("WORKTREE_CLEANUP 설정 오류: %v", err)
+		}
+		worktreeDir := os.Getenv("WORKTREE_DIR")
+		if worktreeDir == "" {
+			worktreeDir = filepath.Join(dbDir, "worktrees")
+		}
+		worktreeDir, _ = filepath.Abs(worktreeDir)
+
+		taskExecutor.Worktree = worker.WorktreeConfig{
+			Enabled: true,
+			Dir:     worktreeDir,
+			Cleanup: cleanup,
+		}
+		log.Printf("🌿 worktree 격리 모드: %s (정리 정책: %s)", worktreeDir, cleanup)
+	} else if maxWorkers > 1 {
+		log.Printf("⚠️  MAX_WORKERS=%d이지만 worktree 격리가 꺼져 있어 같은 저장소의 작업은 하나씩 실행됩니다. (WORKTREE_MODE=on)", maxWorkers)
+	}
+
+	// v1.5: 작업 완료 후 PR 생성 (--pr 옵션 또는 프로젝트 auto_pr 설정)
+	// FORGE_TYPE: github / gitlab / gitea, FORGE_TOKEN: API 토큰
+	// FORGE_API_URL: API 주소 (GitHub Enterprise, 자체 호스팅 GitLab, Gitea)
+	// FORGE_REPO: 저장소 경로 (생략 시 remote URL에서 추출)
+	// PR_REMOTE: push할 원격 저장소 (기본값: origin), PR_BASE_BRANCH: 대상 브랜치 (생략 시 프로젝트의 현재 브랜치)
+	taskExecutor.PullRequest = worker.PullRequestConfig{
+		Remote:     os.Getenv("PR_REMOTE"),
+		BaseBranch: os.Getenv("PR_BASE_BRANCH"),
+		Repo:       os.Getenv("FORGE_REPO"),
+	}
+	if forgeType := os.Getenv("FORGE_TYPE"); forgeType != "" {
+		forgeClient, err := forge.New(forge.Config{
+			Type:    forgeType,
+			BaseURL: os.Getenv("FORGE_API_URL"),
+			Token:   os.Getenv("FORGE_TOKEN"),
+		})
+		if err != nil {
+			log.Fatalf("forge 설정 오류: %v", err)
+		}
+		taskExecutor.PullRequest.Forge = forgeClient
+		log.Printf("🔀 PR 생성 활성화: %s", forgeClient.Name())
+	}
+
+	// v1.5: 실행 계획 승인자 (프로젝트에 승인자가 지정되지 않았을 때 사용)
+	// APPROVAL_REVIEWERS: 쉼표로 구분한 Slack user_id 또는 사용자 그룹 ID 목록 (예: U1234567890,S0123456789)
+	var approvalReviewers []string
+	for _, id := range strings.Split(os.Getenv("APPROVAL_REVIEWERS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			approvalReviewers = append(approvalReviewers, id)
+		}
+	}
+	if len(approvalReviewers) > 0 {
+		log.Printf("📝 기본 승인자: %v", approvalReviewers)
+	}
+
+	// v1.5: Slack Web API (Events API 멘션/DM 요청의 결과를 스레드에 게시)
+	// SLACK_BOT_TOKEN: 봇 토큰 (xoxb-..., 권한: app_mentions:read, im:history, chat:write, v1.5 RBAC 사용자 그룹: usergroups:read)
+	// SLACK_API_URL: Web API 주소 (기본값: https://slack.com/api, 테스트용 가짜 서버 지정 시 사용)
+	var slackClient *slack.Client
+	if botToken := os.Getenv("SLACK_BOT_TOKEN"); botToken != "" {
+		slackClient, err = slack.NewClient(slack.Config{
+			Token:   botToken,
+			BaseURL: os.Getenv("SLACK_API_URL"),
+		})
+		if err != nil {
+			log.Fatalf("Slack 클라이언트 설정 오류: %v", err)
+		}
+		taskExecutor.Slack = slackClient
+		log.Println("💬 Slack 봇 토큰 설정됨: @멘션/DM 요청을 스레드로 처리합니다 (/slack/events)")
+	}
+
+	// v1.5: 긴 출력/diff를 결과 스레드에 파일로 첨부 (봇 토큰 필요, 권한: files:write)
+	// SLACK_FILE_THRESHOLD: 첨부 기준 길이 (바이트, 기본값: 8000, 0이면 첨부하지 않음)
+	if thresholdEnv := os.Getenv("SLACK_FILE_THRESHOLD"); thresholdEnv != "" {
+		parsed, err := strconv.Atoi(thresholdEnv)
+		if err != nil || parsed < 0 {
+			log.Fatalf("SLACK_FILE_THRESHOLD 설정 오류: %q", thresholdEnv)
+		}
+		taskExecutor.FileUploadThreshold = parsed
+	}
+
+	// v1.5: 사용자/채널별 작업 등록 제한 (0 또는 미설정이면 제한 없음, API 요청은 제외)
+	// RATE_LIMIT_USER_HOURLY: 사용자별 시간당 작업 수
+	// RATE_LIMIT_USER_CONCURRENT: 사용자별 동시 작업 수 (대기/실행/승인 대기)
+	// RATE_LIMIT_CHANNEL_DAILY_MINUTES: 채널별 하루 cursor-agent 실행 시간(분)
+	var rateLimits server.RateLimits
+	for _, limit := range []struct {
+		env   string
+		value *int
+	}{
+		{"RATE_LIMIT_USER_HOURLY", &rateLimits.JobsPerUserPerHour},
+		{"RATE_LIMIT_USER_CONCURRENT", &rateLimits.ConcurrentJobsPerUser},
+		{"RATE_LIMIT_CHANNEL_DAILY_MINUTES", &rateLimits.ChannelAgentMinutesPerDay},
+	} {
+		if v := os.Getenv(limit.env); v != "" {
+			parsed, err := strconv.Atoi(v)
+			if err != nil || parsed < 0 {
+				log.Fatalf("%s 설정 오류: %q", limit.env, v)
+			}
+			*limit.value = parsed
+		}
+	}
+	if rateLimits != (server.RateLimits{}) {
+		log.Printf("🚦 요청 한도: 사용자 시간당 %d개, 사용자 동시 %d개, 채널 하루 %d분 (0은 제한 없음)",
+			rateLimits.JobsPerUserPerHour, rateLimits.ConcurrentJobsPerUser, rateLimits.ChannelAgentMinutesPerDay)
+	}
+	// 지난 구간의 사용량 카운터 정리 (일 단위 구간보다 충분히 오래된 것만)
+	if pruned, err := db.PruneUsage(time.Now().AddDate(0, 0, -7)); err != nil {
+		log.Printf("⚠️  사용량 카운터 정리 실패: %v", err)
+	} else if pruned > 0 {
+		log.Printf("🧹 오래된 사용량 카운터 %d개 정리", pruned)
+	}
+
+	// v1.5: 역할 기반 접근 제어 (RBAC_ADMINS를 설정하면 활성화, 미설정이면 모든 사용자가 모든 명령어 사용 가능)
+	// RBAC_ADMINS: 쉼표로 구분한 Slack user_id / user group ID 목록 (항상 admin)
+	// RBAC_DEFAULT_ROLE: 역할이 부여되지 않은 사용자의 역할 (viewer|operator|admin|none, 기본값: none)
+	var rbac server.RBAC
+	for _, id := range strings.Split(os.Getenv("RBAC_ADMINS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			rbac.Admins = append(rbac.Admins, id)
+		}
+	}
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("RBAC_DEFAULT_ROLE"))); v != "" {
+		role, ok := database.ParseRole(v)
+		if !ok && v != "none" {
+			log.Fatalf("RBAC_DEFAULT_ROLE 설정 오류: %q (viewer, operator, admin, none 중 하나)", v)
+		}
+		rbac.DefaultRole = role
+	}
+	if rbac.Enabled() {
+		log.Printf("🔐 역할 기반 접근 제어 활성화: 관리자 %v, 기본 역할 %q", rbac.Admins, rbac.DefaultRole)
+	}
+
+	// v1.5: /api 요청 인증 (API 키, 기본값: on)
+	// API_AUTH=off: 인증하지 않음 (ngrok 등으로 포트가 외부에 노출되면 누구나 작업을 실행할 수 있으므로 로컬 개발용으로만 사용)
+	apiAuthDisabled := strings.EqualFold(os.Getenv("API_AUTH"), "off")
+	if apiAuthDisabled {
+		log.Println("⚠️  API_AUTH=off: /api 요청을 인증하지 않습니다. 외부에 노출된 환경에서는 사용하지 마세요.")
+	} else {
+		log.Println("🔑 /api 요청은 API 키가 필요합니다 (발급: --create-api-key <이름> 또는 POST /api/keys)")
+	}
+
+	// v1.5: 프로젝트 경로로 허용하는 디렉토리 (쉼표로 구분, 미설정이면 제한 없음)
+	// set-path, bind, project add와 API로 지정하는 경로는 심볼릭 링크를 해석한 실제 경로가 이 디렉토리 아래에 있어야 합니다.
+	var projectRoots []string
+	for _, root := range strings.Split(os.Getenv("ALLOWED_PROJECT_ROOTS"), ",") {
+		if root = strings.TrimSpace(root); root != "" {
+			projectRoots = append(projectRoots, root)
+		}
+	}
+	allowedProjectRoots, err := server.ResolveProjectRoots(projectRoots)
+	if err != nil {
+		log.Fatalf("ALLOWED_PROJECT_ROOTS 설정 오류: %v", err)
+	}
+	if len(allowedProjectRoots) > 0 {
+		log.Printf("📂 허용된 프로젝트 디렉토리: %v", allowedProjectRoots)
+	} else {
+		log.Println("⚠️  ALLOWED_PROJECT_ROOTS가 설정되지 않아 모든 디렉토리를 프로젝트 경로로 지정할 수 있습니다.")
+	}
 
 	// 설정 정보를 담은 구조체 (v1.2: 동적 경로 관리, v1.3: DB 추가, v1.4: Worker Pool 추가)
 	config := &server.Config{
 		SigningSecret:          signingSecret,
 		Port:                   port,
-		CursorCLIPath:          cursorCLIPath,
+		Agents:                 agents,
 		AllowedResponseDomains: allowedDomains,
 		DB:                     db,
-		Dispatcher:             dispatcher,
 		JobQueue:               jobQueue,
+		Executor:               taskExecutor,
+		ApprovalReviewers:      approvalReviewers,
+		Slack:                  slackClient,
+		RateLimits:             rateLimits,
+		RBAC:                   rbac,
+		APIAuthDisabled:        apiAuthDisabled,
+		AllowedProjectRoots:    allowedProjectRoots,
+		Redactor:               redactor,
+		JobTimeout:             jobTimeout,
+		MaxJobTimeout:          maxJobTimeout,
+		JobKillGrace:           killGrace,
 	}
 
+	// Dispatcher 생성 및 시작
+	dispatcher := worker.NewDispatcher(jobQueue, maxWorkers, config.ToWorkerConfig)
+	config.Dispatcher = dispatcher
+	dispatcher.Start(taskExecutor)
+	
+	log.Printf("🔧 Worker Pool 초기화 완료: %d개 작업자 (작업 큐: SQLite)", maxWorkers)
+	log.Println()
+
 	// 환경 변수로 초기 프로젝트 경로 설정 (있는 경우)
+	// v1.5: set-path와 같은 기준으로 검증
 	if projectPath != "" {
-		config.SetProjectPath(projectPath)
+		resolved, err := config.ValidateProjectPath(projectPath)
+		if err != nil {
+			log.Fatalf("CURSOR_PROJECT_PATH 설정 오류: %v", err)
+		}
+		config.SetProjectPath(resolved)
 	}
 
 	// v1.4: 포트 사용 가능 여부 확인 및 정리
@@ -288,8 +629,8 @@ func main() {
 	srv := &http.Server{
 		Addr:              ":" + port,
 		Handler:           router,
-		ReadTimeout:       15 * time.Minute, // 작업 타임아웃과 동일
-		WriteTimeout:      15 * time.Minute,
+		ReadTimeout:       maxJobTimeout + 2*server.SyncWaitMargin, // v1.5: 동기 API의 최대 대기시간보다 길게 (JOB_TIMEOUT_MAX)
+		WriteTimeout:      maxJobTimeout + 2*server.SyncWaitMargin,
 		IdleTimeout:       60 * time.Second,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
@@ -319,10 +660,8 @@ func main() {
 		log.Println("✅ HTTP 서버 종료 완료")
 	}
 
-	// 2. JobQueue 닫기 (새 작업 수신 중단)
-	log.Println("2️⃣ 작업 큐 닫는 중...")
-	close(config.JobQueue)
-	log.Println("✅ 작업 큐 닫힘 (새 작업 수신 중단)")
+	// 2. 작업 큐는 DB에 유지됨 (v1.5)
+	log.Println("2️⃣ 대기 중인 작업은 데이터베이스에 보존됩니다 (다음 실행 시 처리)")
 
 	// 3. Worker Pool 종료 (진행 중인 작업 완료 대기)
 	log.Println("3️⃣ 진행 중인 작업 완료 대기 중...")
@@ -360,6 +699,7 @@ func main() {
 	} else {
 		log.Println("✅ 데이터베이스 연결 종료 완료")
 	}
+	unlockDB()
 
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Println("✅ 모든 리소스 정리 완료 - 서버 종료")
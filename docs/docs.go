@@ -24,8 +24,116 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "명령어 실행과 설정 변경 기록을 오래된 순으로 조회합니다. 기록은 추가만 가능하며 수정/삭제할 수 없습니다.\nformat=csv 또는 format=jsonl이면 파일로 내려받습니다 (limit을 지정하지 않으면 조건에 맞는 이벤트 전체).\nCSV에서는 스프레드시트 수식으로 실행되지 않도록 =, +, -, @로 시작하는 값 앞에 '를 붙입니다.\nv1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "감사 로그 조회 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "행위자 ID (Slack user_id 또는 API 키 ID)",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "동작 (예: config.set_path, ` + "`" + `job.` + "`" + `처럼 ` + "`" + `.` + "`" + `으로 끝나면 접두사 검색)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "대상 (작업 ID, @프로젝트 등)",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "시작 시각 (RFC3339 또는 2006-01-02, 포함)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "종료 시각 (RFC3339 또는 2006-01-02, 제외)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최근 이벤트 개수 (기본값: 100, json 형식)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "응답 형식 (json/csv/jsonl, 기본값: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "감사 이벤트 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/database.AuditEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/config/project-path": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
                 "description": "현재 설정된 프로젝트 경로를 조회합니다.",
                 "produces": [
                     "application/json"
@@ -40,11 +148,31 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.ProjectPathResponse"
                         }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "cursor-agent가 실행될 프로젝트 경로를 설정합니다.\n이 경로는 런타임에 동적으로 변경 가능합니다.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "cursor-agent가 실행될 프로젝트 경로를 설정합니다.\n이 경로는 런타임에 동적으로 변경 가능합니다.\nv1.5: 심볼릭 링크와 ` + "`" + `..` + "`" + `를 해석한 실제 경로가 존재하는 git 저장소이고 허용된 디렉토리(ALLOWED_PROJECT_ROOTS) 아래에 있어야 합니다.\nv1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).",
                 "consumes": [
                     "application/json"
                 ],
@@ -78,13 +206,33 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/api/cursor": {
             "post": {
-                "description": "JSON 형식으로 cursor-agent를 실행합니다. Slack 인증이 필요 없습니다.\nv1.1: 자연어 프롬프트 방식 (파일명을 프롬프트에 직접 포함)\nasync=false: 동기 실행 (결과를 즉시 반환)\nasync=true: 비동기 실행 (job_id만 반환, 결과는 별도 조회 필요 - 원 단계에서 구현)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "JSON 형식으로 cursor-agent를 실행합니다. Slack 서명 대신 API 키(v1.5: run 권한)로 인증합니다.\nv1.1: 자연어 프롬프트 방식 (파일명을 프롬프트에 직접 포함)\nasync=false: 동기 실행 (결과를 즉시 반환)\nasync=true: 비동기 실행 (job_id만 반환, 결과는 별도 조회 필요 - 원 단계에서 구현)",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,6 +267,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 한도 초과",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "서버 오류",
                         "schema": {
@@ -130,7 +296,15 @@ const docTemplate = `{
         },
         "/api/jobs": {
             "get": {
-                "description": "작업 목록을 조회합니다. 상태별 필터링과 페이지네이션을 지원합니다.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "작업 목록을 조회합니다. 상태별 필터링과 페이지네이션을 지원합니다.\nv1.5: API 키에 프로젝트 허용 목록이 있으면 허용된 프로젝트의 작업만 반환하며, limit/offset도 반환되는 작업 기준입니다.",
                 "produces": [
                     "application/json"
                 ],
@@ -153,7 +327,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "작업 상태 필터 (pending/running/completed/failed)",
+                        "description": "작업 상태 필터 (pending/running/awaiting_approval/completed/failed/cancelled/rejected)",
                         "name": "status",
                         "in": "query"
                     }
@@ -173,12 +347,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/api/jobs/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
                 "description": "Job ID로 작업 실행 결과를 조회합니다.",
                 "produces": [
                     "application/json"
@@ -203,6 +397,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/database.JobRecord"
                         }
                     },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "작업을 찾을 수 없음",
                         "schema": {
@@ -210,91 +416,111 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "서버가 정상 동작 중인지 확인합니다.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "대기 중인 작업은 큐에서 제외하고, 실행 중인 작업은 프로세스 그룹을 종료합니다.\n취소된 작업은 cancelled 상태와 취소 요청자/시간이 기록됩니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "jobs"
+                ],
+                "summary": "작업 취소 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "서버 상태 확인",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "취소된 작업",
                         "schema": {
-                            "$ref": "#/definitions/server.HealthResponse"
+                            "$ref": "#/definitions/database.JobRecord"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "작업을 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 종료된 작업",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/slack/cursor": {
+        "/api/jobs/{id}/continue": {
             "post": {
                 "security": [
                     {
-                        "SlackSignature": []
+                        "BearerAuth": []
                     },
                     {
-                        "SlackTimestamp": []
+                        "APIKeyHeader": []
                     }
                 ],
-                "description": "Slack의 /cursor 슬래시 커맨드를 받아 cursor-agent를 비동기로 실행합니다.\nv1.1: 자연어 프롬프트 방식 사용 (파일명을 프롬프트에 직접 포함)\nHMAC-SHA256 서명 검증 및 타임스탬프 검증이 필요합니다.",
+                "description": "종료된 작업의 cursor-agent 채팅 세션을 재개(--resume)하여 후속 프롬프트를 실행하는 작업을 큐에 등록합니다.\n새 작업은 kind=continue, parent_job_id=원본 작업으로 기록되며 원본 작업의 프로젝트에서 실행됩니다.",
                 "consumes": [
-                    "application/x-www-form-urlencoded"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "slack"
+                    "jobs"
                 ],
-                "summary": "Slack 슬래시 커맨드 처리 (v1.1)",
+                "summary": "작업 이어서 실행 (v1.5)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "자연어 프롬프트 (예: 'main.go의 버그를 수정해줘')",
-                        "name": "text",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Slack 사용자명",
-                        "name": "user_name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Slack 사용자 ID",
-                        "name": "user_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "지연 응답을 보낼 Slack Webhook URL",
-                        "name": "response_url",
-                        "in": "formData",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Slack 트리거 ID",
-                        "name": "trigger_id",
-                        "in": "formData",
-                        "required": true
+                        "description": "후속 프롬프트",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.ContinueJobRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "즉시 ACK 응답",
+                    "202": {
+                        "description": "이어서 실행 작업 접수",
                         "schema": {
-                            "$ref": "#/definitions/server.SlackImmediateResponse"
+                            "$ref": "#/definitions/server.ContinueJobResponse"
                         }
                     },
                     "400": {
@@ -308,51 +534,1035 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
-                    }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "작업을 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이어서 실행할 수 없는 작업",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 한도 초과",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "작업의 실행 전 스냅샷으로 변경 파일을 복원하는 revert 작업을 큐에 등록합니다.\nrevert 작업은 별도 작업 레코드(kind=revert, parent_job_id)로 기록되며, 이후 작업이 같은 파일을 변경했거나 완료 후 파일이 직접 수정된 경우 실패합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "작업 되돌리기 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "되돌리기 작업 접수",
+                        "schema": {
+                            "$ref": "#/definitions/server.RevertJobResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "작업을 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "되돌릴 수 없는 작업",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 한도 초과",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "Server-Sent Events로 실행 중인 작업의 출력을 실시간으로 전송합니다.\n이벤트 종류: status(상태 변경), output(새로 추가된 출력), reset(출력 재전송), done(작업 종료)\n재연결 시 offset 쿼리(또는 Last-Event-ID 헤더)로 이미 받은 출력 길이를 전달하면 이어서 받을 수 있습니다.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "작업 출력 실시간 스트리밍 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "이미 수신한 출력 길이 (기본값: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SSE 이벤트 스트림",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobStreamEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "작업을 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "발급된 API 키 목록을 조회합니다. 키 원문과 해시는 포함되지 않으며, 폐기된 키도 감사를 위해 표시됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "API 키 목록 조회 (v1.5)",
+                "responses": {
+                    "200": {
+                        "description": "API 키 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/database.APIKey"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "권한 범위(run: 작업 실행/취소/되돌리기/이어서 실행, read: 조회, configure: 경로/프로젝트/API 키 관리)를 지정하여 API 키를 발급합니다.\n요청한 키에 없는 권한 범위나 프로젝트 허용 목록 밖의 프로젝트(허용 목록이 있는 키는 모든 작업 포함)를 가진 키는 발급할 수 없습니다 (403).\n키 원문(key)은 이 응답에서만 확인할 수 있으며 서버에는 SHA-256 해시만 저장됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "API 키 발급 (v1.5)",
+                "parameters": [
+                    {
+                        "description": "키 이름과 권한 범위",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "발급된 API 키",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "API 키를 폐기합니다. 폐기한 키로 보낸 요청은 즉시 401로 거부되며, 키 기록은 감사를 위해 유지됩니다.\n요청한 키보다 넓은 권한 범위나 프로젝트를 가진 키는 폐기할 수 없습니다 (403).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "API 키 폐기 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API 키 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "폐기 성공"
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API 키를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "등록된 이름 있는 프로젝트와 기본 경로를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "프로젝트 목록 조회 (v1.5)",
+                "responses": {
+                    "200": {
+                        "description": "프로젝트 목록",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProjectListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "이름으로 참조할 프로젝트를 등록합니다. 프롬프트 앞에 @이름을 붙여 대상 프로젝트를 지정할 수 있습니다.\n경로는 존재하는 git 저장소여야 하며, ALLOWED_PROJECT_ROOTS를 설정하면 그 아래 디렉토리만 등록할 수 있습니다.\nv1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "프로젝트 등록 (v1.5)",
+                "parameters": [
+                    {
+                        "description": "프로젝트 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.ProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "등록된 프로젝트",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 등록된 이름",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/projects/{name}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyHeader": []
+                    }
+                ],
+                "description": "등록된 프로젝트를 삭제합니다. 이미 대기 중인 해당 프로젝트의 작업은 실행 시 실패합니다.\nv1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "프로젝트 삭제 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "프로젝트 이름",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "삭제 성공"
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "프로젝트를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "서버가 정상 동작 중인지 확인합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "서버 상태 확인",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/cursor": {
+            "post": {
+                "security": [
+                    {
+                        "SlackSignature": []
+                    },
+                    {
+                        "SlackTimestamp": []
+                    }
+                ],
+                "description": "Slack의 /cursor 슬래시 커맨드를 받아 cursor-agent를 비동기로 실행합니다.\nv1.1: 자연어 프롬프트 방식 사용 (파일명을 프롬프트에 직접 포함)\nHMAC-SHA256 서명 검증 및 타임스탬프 검증이 필요합니다.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack 슬래시 커맨드 처리 (v1.1)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "자연어 프롬프트 (예: 'main.go의 버그를 수정해줘')",
+                        "name": "text",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack 사용자명",
+                        "name": "user_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack 사용자 ID",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "지연 응답을 보낼 Slack Webhook URL",
+                        "name": "response_url",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack 트리거 ID",
+                        "name": "trigger_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "명령어를 실행한 채널 ID (채널 바인딩에 사용)",
+                        "name": "channel_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "명령어를 실행한 채널 이름",
+                        "name": "channel_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack 워크스페이스 ID",
+                        "name": "team_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack Enterprise Grid ID",
+                        "name": "enterprise_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "즉시 ACK 응답",
+                        "schema": {
+                            "$ref": "#/definitions/server.SlackImmediateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/events": {
+            "post": {
+                "security": [
+                    {
+                        "SlackSignature": []
+                    },
+                    {
+                        "SlackTimestamp": []
+                    }
+                ],
+                "description": "url_verification 요청에 challenge를 응답하고, app_mention / message.im 이벤트를 작업으로 등록합니다.\n\"@cursor 프롬프트\"처럼 멘션하면 작업 결과가 멘션한 스레드에 게시됩니다 (SLACK_BOT_TOKEN 필요).\n작업이 끝난 스레드에서 다시 멘션하면 마지막 작업의 cursor-agent 세션을 이어서 실행합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack Events API 처리 (v1.5)",
+                "parameters": [
+                    {
+                        "description": "Events API 요청",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SlackEventEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "challenge 응답 또는 빈 응답",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/interactions": {
+            "post": {
+                "security": [
+                    {
+                        "SlackSignature": []
+                    },
+                    {
+                        "SlackTimestamp": []
+                    }
+                ],
+                "description": "작업 메시지의 버튼(block_actions)을 처리합니다: 취소, 다시 실행, 전체 출력, 되돌리기, 승인, 거절.\nSlack은 3초 안에 200 응답을 기대하므로 결과는 인터랙션의 response_url로 전송합니다.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack 인터랙션 (버튼) 처리 (v1.5)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "block_actions payload JSON",
+                        "name": "payload",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "접수됨"
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
+                    }
                 }
             }
         }
     },
     "definitions": {
+        "database.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ci-bot"
+                },
+                "prefix": {
+                    "description": "키를 구분하기 위한 앞부분 (전체 키가 아님)",
+                    "type": "string",
+                    "example": "csk_1a2b3c4d"
+                },
+                "projects": {
+                    "description": "작업을 실행/조회할 수 있는 프로젝트 (비어 있으면 모든 작업)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "run",
+                        "read"
+                    ]
+                }
+            }
+        },
+        "database.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "config.set_path"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "U1234567890"
+                },
+                "actor_name": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "actor_type": {
+                    "description": "slack, api_key, api, cli",
+                    "type": "string",
+                    "example": "slack"
+                },
+                "after": {
+                    "type": "string",
+                    "example": "/srv/repos/new"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "/srv/repos/old"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "source_ip": {
+                    "type": "string",
+                    "example": "10.0.0.5"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
+        },
+        "database.ChangedFile": {
+            "type": "object",
+            "properties": {
+                "old_path": {
+                    "description": "이름 변경 시 이전 경로",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "internal/server/handlers.go"
+                },
+                "status": {
+                    "description": "A(추가), M(수정), D(삭제), R(이름 변경), T(타입 변경)",
+                    "type": "string",
+                    "example": "M"
+                }
+            }
+        },
+        "database.JobKind": {
+            "type": "string",
+            "enum": [
+                "agent",
+                "revert",
+                "continue"
+            ],
+            "x-enum-comments": {
+                "JobKindAgent": "cursor-agent 실행 (기본값)",
+                "JobKindContinue": "원본 작업의 cursor-agent 세션을 이어서 실행",
+                "JobKindRevert": "다른 작업의 변경 사항 되돌리기"
+            },
+            "x-enum-descriptions": [
+                "cursor-agent 실행 (기본값)",
+                "다른 작업의 변경 사항 되돌리기",
+                "원본 작업의 cursor-agent 세션을 이어서 실행"
+            ],
+            "x-enum-varnames": [
+                "JobKindAgent",
+                "JobKindRevert",
+                "JobKindContinue"
+            ]
+        },
         "database.JobRecord": {
             "type": "object",
             "properties": {
+                "agent": {
+                    "description": "v1.5: 작업을 실행하는 에이전트 백엔드 (요청 시점에 결정, revert 작업은 빈 값)",
+                    "type": "string"
+                },
+                "api_key_id": {
+                    "description": "v1.5: 작업을 등록한 API 키 ID (Slack 요청은 빈 값)",
+                    "type": "string"
+                },
+                "attempts": {
+                    "description": "v1.5: 실행 시도 횟수 (재시작 후 재실행 포함)",
+                    "type": "integer"
+                },
+                "base_commit": {
+                    "description": "v1.5: 실행 시작 시점의 HEAD 커밋",
+                    "type": "string"
+                },
+                "branch": {
+                    "description": "v1.5: 작업 브랜치 (worktree 격리 모드)",
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cancelled_by": {
+                    "description": "v1.5: 취소한 사용자 (Slack user_id 또는 \"api\")",
+                    "type": "string"
+                },
+                "changed_files": {
+                    "description": "v1.5: 실행 전후 스냅샷 사이에 변경된 파일",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ChangedFile"
+                    }
+                },
+                "channel_id": {
+                    "description": "v1.5: Slack 채널 ID (API 요청은 빈 값)",
+                    "type": "string"
+                },
+                "channel_name": {
+                    "description": "v1.5: Slack 채널 이름",
+                    "type": "string"
+                },
+                "checkpoint_after": {
+                    "description": "v1.5: 실행 후 작업 디렉토리 스냅샷 커밋",
+                    "type": "string"
+                },
+                "checkpoint_before": {
+                    "description": "v1.5: 실행 전 작업 디렉토리 스냅샷 커밋",
+                    "type": "string"
+                },
                 "completed_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "created_at": {
+                    "type": "string"
+                },
+                "diff": {
+                    "description": "v1.5: 실행 전후 스냅샷의 unified diff",
                     "type": "string"
                 },
                 "duration": {
                     "description": "milliseconds",
                     "type": "integer"
                 },
+                "enterprise_id": {
+                    "description": "v1.5: Slack Enterprise Grid ID",
+                    "type": "string"
+                },
                 "error": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "kind": {
+                    "description": "v1.5: 작업 종류 (agent, revert, continue)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.JobKind"
+                        }
+                    ]
+                },
+                "message_ts": {
+                    "description": "v1.5: 진행 상황을 chat.update로 갱신하는 상태 메시지",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "v1.5: 요청한 에이전트 모델 (--model, 빈 값이면 에이전트 기본 모델)",
+                    "type": "string"
+                },
+                "open_pr": {
+                    "description": "v1.5: 완료 후 PR 생성 요청 여부 (--pr 또는 프로젝트 설정)",
+                    "type": "boolean"
+                },
                 "output": {
                     "type": "string"
                 },
+                "parent_job_id": {
+                    "description": "v1.5: 원본 작업 ID (revert: 되돌린 작업, continue: 이어서 실행한 작업, 재실행: 원본 작업)",
+                    "type": "string"
+                },
+                "plan": {
+                    "description": "v1.5: 승인 요청 시 제시한 실행 계획 (읽기 전용 실행 결과)",
+                    "type": "string"
+                },
+                "pr_url": {
+                    "description": "v1.5: 생성된 PR URL",
+                    "type": "string"
+                },
+                "project_name": {
+                    "description": "v1.5: @이름으로 지정한 프로젝트 (기본 경로 사용 시 빈 값)",
+                    "type": "string"
+                },
                 "project_path": {
                     "type": "string"
                 },
                 "prompt": {
                     "type": "string"
                 },
+                "prompt_redacted": {
+                    "description": "v1.5: 저장된 프롬프트에서 비밀 값을 가림 (원문은 실행 전까지 메모리에만 있음)",
+                    "type": "boolean"
+                },
+                "read_only": {
+                    "description": "v1.5: 파일을 수정하지 않는 읽기 전용 실행 (--readonly)",
+                    "type": "boolean"
+                },
+                "redaction_count": {
+                    "description": "v1.5: 저장/게시 전에 가린 비밀 값 수 (계획, 출력, diff)",
+                    "type": "integer"
+                },
+                "require_approval": {
+                    "description": "v1.5: 파일 수정 전 실행 계획 승인 필요 (프로젝트 설정)",
+                    "type": "boolean"
+                },
+                "reverted_by": {
+                    "description": "v1.5: 이 작업을 되돌린 revert 작업 ID",
+                    "type": "string"
+                },
+                "review_note": {
+                    "description": "v1.5: 거절 사유",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "description": "v1.5: 승인/거절한 사용자",
+                    "type": "string"
+                },
+                "session_id": {
+                    "description": "v1.5: cursor-agent 채팅 세션 ID (continue 작업이 재개)",
+                    "type": "string"
+                },
                 "started_at": {
                     "type": "string"
                 },
                 "status": {
                     "$ref": "#/definitions/database.JobStatus"
                 },
+                "team_id": {
+                    "description": "v1.5: Slack 워크스페이스 ID",
+                    "type": "string"
+                },
+                "thread_ts": {
+                    "description": "v1.5: 작업 결과를 게시하는 Slack 스레드 (멘션/DM 요청 또는 봇 토큰으로 게시한 상태 메시지)",
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "description": "v1.5: 요청한 시간 제한(초) (--timeout, 0이면 기본값)",
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "string"
                 },
                 "user_name": {
                     "type": "string"
+                },
+                "worktree_path": {
+                    "description": "v1.5: 작업이 실행된 git worktree (정리된 뒤에도 유지)",
+                    "type": "string"
                 }
             }
         },
@@ -362,28 +1572,140 @@ const docTemplate = `{
                 "pending",
                 "running",
                 "completed",
-                "failed"
+                "failed",
+                "cancelled",
+                "awaiting_approval",
+                "rejected"
+            ],
+            "x-enum-comments": {
+                "JobStatusAwaitingApproval": "v1.5: 실행 계획 작성 완료, 승인 대기 중",
+                "JobStatusCancelled": "v1.5: 사용자 요청으로 취소됨",
+                "JobStatusRejected": "v1.5: 승인자가 실행 계획을 거절함"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "",
+                "v1.5: 사용자 요청으로 취소됨",
+                "v1.5: 실행 계획 작성 완료, 승인 대기 중",
+                "v1.5: 승인자가 실행 계획을 거절함"
             ],
             "x-enum-varnames": [
                 "JobStatusPending",
                 "JobStatusRunning",
                 "JobStatusCompleted",
-                "JobStatusFailed"
+                "JobStatusFailed",
+                "JobStatusCancelled",
+                "JobStatusAwaitingApproval",
+                "JobStatusRejected"
             ]
         },
+        "database.Project": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "description": "v1.5: 작업을 실행할 에이전트 백엔드 (비어 있으면 기본 백엔드, --agent 옵션이 우선)",
+                    "type": "string",
+                    "example": "cursor"
+                },
+                "allowed_users": {
+                    "description": "v1.5: 작업을 실행할 수 있는 Slack user_id / user group ID (비어 있으면 operator 이상 모두)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "U1234567890",
+                        "S0123456789"
+                    ]
+                },
+                "auto_pr": {
+                    "description": "모든 작업 완료 후 PR 생성 (--pr 없이도)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "U1234567890"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/srv/repos/backend"
+                },
+                "require_approval": {
+                    "description": "v1.5: 파일 수정 전 실행 계획 승인",
+                    "type": "boolean",
+                    "example": false
+                },
+                "reviewers": {
+                    "description": "승인 가능한 Slack user_id 또는 사용자 그룹 ID (비어 있으면 APPROVAL_REVIEWERS)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "U1234567890",
+                        "U0987654321"
+                    ]
+                },
+                "timeout_seconds": {
+                    "description": "v1.5: 작업 시간 제한(초) (0이면 JOB_TIMEOUT, --timeout 옵션이 우선)",
+                    "type": "integer",
+                    "example": 1800
+                }
+            }
+        },
         "server.APICursorRequest": {
             "type": "object",
             "required": [
                 "prompt"
             ],
             "properties": {
+                "agent": {
+                    "description": "v1.5: 실행할 에이전트 백엔드 (프롬프트의 --agent보다 우선)",
+                    "type": "string",
+                    "example": "cursor"
+                },
                 "async": {
                     "type": "boolean",
                     "example": false
                 },
+                "model": {
+                    "description": "v1.5: 에이전트 실행 옵션 (프롬프트의 --model, --timeout, --readonly보다 우선)",
+                    "type": "string",
+                    "example": "gpt-5"
+                },
+                "pr": {
+                    "description": "v1.5: 완료 후 변경 사항으로 PR 생성 (프롬프트의 --pr과 동일)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "project": {
+                    "description": "v1.5: 등록된 프로젝트 이름 (프롬프트의 @이름보다 우선)",
+                    "type": "string",
+                    "example": "backend"
+                },
                 "prompt": {
                     "type": "string",
                     "example": "main.go의 버그를 수정해줘"
+                },
+                "readonly": {
+                    "description": "파일을 수정하지 않고 실행 (pr과 함께 사용할 수 없음)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "timeout": {
+                    "description": "시간 제한 (Go duration 형식, 1분 이상, 서버 최대값 이하)",
+                    "type": "string",
+                    "example": "30m"
                 }
             }
         },
@@ -408,6 +1730,128 @@ const docTemplate = `{
                 }
             }
         },
+        "server.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "ci-bot"
+                },
+                "projects": {
+                    "description": "작업을 실행/조회할 수 있는 등록된 프로젝트 (비어 있으면 모든 작업)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "scopes": {
+                    "description": "run, read, configure",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "run",
+                        "read"
+                    ]
+                }
+            }
+        },
+        "server.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "csk_1a2b3c4d..."
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ci-bot"
+                },
+                "prefix": {
+                    "description": "키를 구분하기 위한 앞부분 (전체 키가 아님)",
+                    "type": "string",
+                    "example": "csk_1a2b3c4d"
+                },
+                "projects": {
+                    "description": "작업을 실행/조회할 수 있는 프로젝트 (비어 있으면 모든 작업)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "run",
+                        "read"
+                    ]
+                }
+            }
+        },
+        "server.ContinueJobRequest": {
+            "type": "object",
+            "required": [
+                "prompt"
+            ],
+            "properties": {
+                "pr": {
+                    "description": "완료 후 변경 사항으로 PR 생성",
+                    "type": "boolean",
+                    "example": false
+                },
+                "prompt": {
+                    "type": "string",
+                    "example": "이제 테스트도 추가해줘"
+                }
+            }
+        },
+        "server.ContinueJobResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "parent_job_id": {
+                    "type": "string",
+                    "example": "3f2c1a9e-0b7d-4c1e-9a55-2d6f0e8b1c44"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
         "server.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -426,6 +1870,49 @@ const docTemplate = `{
                 }
             }
         },
+        "server.JobStreamEvent": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 128
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.JobStatus"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "main.go를 수정하고 있습니다..."
+                }
+            }
+        },
+        "server.ProjectListResponse": {
+            "type": "object",
+            "properties": {
+                "default_path": {
+                    "type": "string",
+                    "example": "/Users/username/projects/my-project"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.Project"
+                    }
+                }
+            }
+        },
         "server.ProjectPathRequest": {
             "type": "object",
             "required": [
@@ -455,6 +1942,70 @@ const docTemplate = `{
                 }
             }
         },
+        "server.ProjectRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "path"
+            ],
+            "properties": {
+                "agent": {
+                    "description": "v1.5: 작업을 실행할 에이전트 백엔드 (비어 있으면 기본 백엔드)",
+                    "type": "string",
+                    "example": "cursor"
+                },
+                "auto_pr": {
+                    "description": "모든 작업 완료 후 PR 생성",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/srv/repos/backend"
+                },
+                "require_approval": {
+                    "description": "v1.5: 파일 수정 전 실행 계획 승인",
+                    "type": "boolean",
+                    "example": false
+                },
+                "reviewers": {
+                    "description": "승인 가능한 Slack user_id 또는 사용자 그룹 ID (비어 있으면 APPROVAL_REVIEWERS)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "U1234567890"
+                    ]
+                },
+                "timeout": {
+                    "description": "v1.5: 작업 시간 제한 (Go duration 형식, 비어 있으면 JOB_TIMEOUT, JOB_TIMEOUT_MAX 이하)",
+                    "type": "string",
+                    "example": "30m"
+                }
+            }
+        },
+        "server.RevertJobResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "parent_job_id": {
+                    "type": "string",
+                    "example": "3f2c1a9e-0b7d-4c1e-9a55-2d6f0e8b1c44"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
         "server.SlackImmediateResponse": {
             "type": "object",
             "properties": {
@@ -467,9 +2018,81 @@ const docTemplate = `{
                     "example": "⏳ 요청을 접수했습니다. 작업을 처리 중입니다..."
                 }
             }
+        },
+        "types.SlackEvent": {
+            "type": "object",
+            "properties": {
+                "bot_id": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "channel_type": {
+                    "description": "message: \"im\", \"channel\" 등",
+                    "type": "string"
+                },
+                "subtype": {
+                    "description": "message_changed, bot_message 등 (사용자가 새로 보낸 메시지는 빈 값)",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "thread_ts": {
+                    "description": "스레드 안의 메시지면 부모 메시지 ts",
+                    "type": "string"
+                },
+                "ts": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.SlackEventEnvelope": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "description": "url_verification: 그대로 응답해야 하는 값",
+                    "type": "string"
+                },
+                "enterprise_id": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/types.SlackEvent"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"url_verification\" 또는 \"event_callback\"",
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "APIKeyHeader": {
+            "description": "/api 인증: API 키 (v1.5)",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "/api 인증: \"Bearer \u003cAPI 키\u003e\" (v1.5)",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
         "SlackSignature": {
             "description": "Slack HMAC-SHA256 서명",
             "type": "apiKey",
@@ -0,0 +1,230 @@
+package database
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefix는 발급하는 API 키 원문의 접두사입니다 (로그/설정 파일에서 키를 식별하기 위함).
+const apiKeyPrefix = "csk_"
+
+// API 키 권한 범위 (v1.5)
+const (
+	ScopeRun       = "run"       // 작업 실행, 취소, 되돌리기, 이어서 실행
+	ScopeRead      = "read"      // 작업/프로젝트/경로 조회
+	ScopeConfigure = "configure" // 기본 경로, 프로젝트, API 키 관리
+)
+
+// ValidScope는 알려진 권한 범위인지 반환합니다.
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeRun, ScopeRead, ScopeConfigure:
+		return true
+	}
+	return false
+}
+
+// ParseScopes는 권한 범위 목록을 검증하고 중복을 제거합니다 ("run,read"처럼 쉼표로 구분한 값도 허용).
+func ParseScopes(values []string) ([]string, error) {
+	var scopes []string
+	for _, v := range values {
+		for _, scope := range strings.Split(v, ",") {
+			scope = strings.ToLower(strings.TrimSpace(scope))
+			if scope == "" {
+				continue
+			}
+			if !ValidScope(scope) {
+				return nil, fmt.Errorf("알 수 없는 권한 범위입니다: %s (run, read, configure 중 하나)", scope)
+			}
+			if !containsScope(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("권한 범위를 하나 이상 지정해주세요 (run, read, configure)")
+	}
+	return scopes, nil
+}
+
+// APIKey는 /api 요청 인증에 사용하는 키입니다 (v1.5)
+// 키 원문은 발급할 때 한 번만 보여주고 SHA-256 해시만 저장합니다.
+type APIKey struct {
+	ID         string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Name       string     `json:"name" example:"ci-bot"`
+	Prefix     string     `json:"prefix" example:"csk_1a2b3c4d"` // 키를 구분하기 위한 앞부분 (전체 키가 아님)
+	Scopes     []string   `json:"scopes" example:"run,read"`
+	Projects   []string   `json:"projects,omitempty" example:"backend"` // 작업을 실행/조회할 수 있는 프로젝트 (비어 있으면 모든 작업)
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	KeyHash    string     `json:"-"`
+}
+
+// HasScope는 키에 권한 범위가 있는지 반환합니다.
+func (k *APIKey) HasScope(scope string) bool {
+	return containsScope(k.Scopes, scope)
+}
+
+// AllowsProject는 키로 projectName 프로젝트의 작업을 실행/조회할 수 있는지 반환합니다.
+// 프로젝트 허용 목록이 있는 키는 목록의 등록된 프로젝트만 사용할 수 있으며, 경로로 지정한 작업(projectName이 빈 값)은 사용할 수 없습니다.
+func (k *APIKey) AllowsProject(projectName string) bool {
+	if len(k.Projects) == 0 {
+		return true
+	}
+	return projectName != "" && containsScope(k.Projects, projectName)
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey는 저장/조회에 사용하는 키 원문의 SHA-256 해시(hex)를 반환합니다.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewAPIKey는 새 API 키를 만들고 저장할 레코드와 키 원문을 반환합니다 (저장은 CreateAPIKey).
+// 키 원문은 24바이트 난수의 hex 문자열에 접두사를 붙인 값입니다.
+func NewAPIKey(name string, scopes []string, projects []string, createdBy string) (*APIKey, string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	plain := apiKeyPrefix + hex.EncodeToString(buf)
+	return &APIKey{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Prefix:    plain[:len(apiKeyPrefix)+8],
+		Scopes:    scopes,
+		Projects:  projects,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		KeyHash:   HashAPIKey(plain),
+	}, plain, nil
+}
+
+// apiKeyColumns는 api_keys 조회 시 사용하는 컬럼 목록입니다.
+const apiKeyColumns = `id, name, prefix, scopes, COALESCE(projects, ''), COALESCE(created_by, ''), created_at, last_used_at, revoked_at, key_hash`
+
+// scanAPIKey는 apiKeyColumns 순서대로 한 행을 APIKey로 읽습니다.
+func scanAPIKey(row rowScanner) (*APIKey, error) {
+	k := &APIKey{}
+	var scopes, projects string
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &projects, &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt, &k.KeyHash); err != nil {
+		return nil, err
+	}
+	if scopes != "" {
+		k.Scopes = strings.Split(scopes, ",")
+	}
+	if projects != "" {
+		k.Projects = strings.Split(projects, ",")
+	}
+	return k, nil
+}
+
+// CreateAPIKey는 API 키를 저장합니다. KeyHash에는 HashAPIKey로 만든 해시를 지정해야 합니다.
+func (db *DB) CreateAPIKey(k *APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, key_hash, prefix, scopes, projects, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := db.conn.Exec(query, k.ID, k.Name, k.KeyHash, k.Prefix, strings.Join(k.Scopes, ","), strings.Join(k.Projects, ","), k.CreatedBy, k.CreatedAt)
+	return err
+}
+
+// GetAPIKeyByHash는 해시로 폐기되지 않은 API 키를 조회합니다. 없으면 nil을 반환합니다.
+func (db *DB) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	row := db.conn.QueryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = ? AND revoked_at IS NULL", hash)
+	k, err := scanAPIKey(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return k, err
+}
+
+// GetAPIKey는 ID로 API 키를 조회합니다 (폐기된 키 포함). 없으면 nil을 반환합니다.
+func (db *DB) GetAPIKey(id string) (*APIKey, error) {
+	row := db.conn.QueryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE id = ?", id)
+	k, err := scanAPIKey(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return k, err
+}
+
+// Covers는 키가 scopes 권한 범위와 projects 프로젝트 허용 목록을 모두 포함하는지 반환합니다.
+// 허용 목록이 없는 키는 모든 프로젝트를 포함하고, 허용 목록이 있는 키는 허용 목록이 없는(모든 작업) 키를 포함하지 않습니다.
+func (k *APIKey) Covers(scopes []string, projects []string) bool {
+	for _, scope := range scopes {
+		if !k.HasScope(scope) {
+			return false
+		}
+	}
+	if len(k.Projects) == 0 {
+		return true
+	}
+	if len(projects) == 0 {
+		return false
+	}
+	for _, project := range projects {
+		if !containsScope(k.Projects, project) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListAPIKeys는 폐기된 키를 포함한 모든 API 키를 생성 순으로 조회합니다.
+func (db *DB) ListAPIKeys() ([]*APIKey, error) {
+	rows, err := db.conn.Query("SELECT " + apiKeyColumns + " FROM api_keys ORDER BY created_at ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey는 API 키를 폐기합니다. 기록은 감사를 위해 남겨 둡니다.
+// 키가 없거나 이미 폐기되었으면 false를 반환합니다.
+func (db *DB) RevokeAPIKey(id string) (bool, error) {
+	res, err := db.conn.Exec("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// TouchAPIKey는 API 키의 마지막 사용 시각을 기록합니다.
+func (db *DB) TouchAPIKey(id string, usedAt time.Time) error {
+	_, err := db.conn.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", usedAt, id)
+	return err
+}
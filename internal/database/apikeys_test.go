@@ -0,0 +1,53 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAPIKeyHashing(t *testing.T) {
+	db := newTestDB(t)
+	key, plain, err := NewAPIKey("ci", []string{ScopeRead, ScopeRun}, []string{"backend"}, "U1")
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if !strings.HasPrefix(plain, apiKeyPrefix) || !strings.HasPrefix(plain, key.Prefix) || len(key.Prefix) >= len(plain) {
+		t.Errorf("plain = %q, prefix = %q", plain, key.Prefix)
+	}
+	if len(key.KeyHash) != 64 || key.KeyHash != HashAPIKey(plain) || strings.Contains(key.KeyHash, plain) {
+		t.Errorf("KeyHash = %q, want SHA-256(plain)", key.KeyHash)
+	}
+	if HashAPIKey(plain+"x") == key.KeyHash {
+		t.Error("다른 키의 해시가 같습니다")
+	}
+	if err := db.CreateAPIKey(key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	// 원문은 저장하지 않고 해시로만 조회
+	var stored int
+	if err := db.conn.QueryRow("SELECT COUNT(*) FROM api_keys WHERE key_hash = ? OR prefix = ? OR name = ?", plain, plain, plain).Scan(&stored); err != nil || stored != 0 {
+		t.Errorf("키 원문이 저장됨: %d, %v", stored, err)
+	}
+	got, err := db.GetAPIKeyByHash(HashAPIKey(plain))
+	if err != nil || got == nil || got.ID != key.ID {
+		t.Fatalf("GetAPIKeyByHash = %+v, %v", got, err)
+	}
+	if !got.HasScope(ScopeRun) || got.HasScope(ScopeConfigure) || !got.AllowsProject("backend") || got.AllowsProject("") {
+		t.Errorf("scopes = %v, projects = %v", got.Scopes, got.Projects)
+	}
+
+	// 폐기된 키는 조회되지 않음 (기록은 남음)
+	if ok, err := db.RevokeAPIKey(key.ID); !ok || err != nil {
+		t.Fatalf("RevokeAPIKey = %v, %v", ok, err)
+	}
+	if ok, _ := db.RevokeAPIKey(key.ID); ok {
+		t.Error("이미 폐기된 키를 다시 폐기했습니다")
+	}
+	if got, err := db.GetAPIKeyByHash(HashAPIKey(plain)); err != nil || got != nil {
+		t.Errorf("폐기 후 GetAPIKeyByHash = %+v, %v; want nil", got, err)
+	}
+	if got, err := db.GetAPIKey(key.ID); err != nil || got == nil || got.RevokedAt == nil {
+		t.Errorf("폐기 후 GetAPIKey = %+v, %v; want 폐기 시각", got, err)
+	}
+}
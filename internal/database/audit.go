@@ -0,0 +1,161 @@
+package database
+
+import (
+	"strings"
+	"time"
+)
+
+// 감사 이벤트 행위자 종류 (v1.5)
+const (
+	AuditActorSlack  = "slack"   // Slack 사용자 (actor_id: user_id)
+	AuditActorAPIKey = "api_key" // API 키 (actor_id: 키 ID, actor_name: 키 이름)
+	AuditActorAPI    = "api"     // API_AUTH=off일 때의 인증되지 않은 API 요청
+	AuditActorCLI    = "cli"     // 서버 명령줄 (--create-api-key)
+)
+
+// 감사 이벤트 동작 이름 (v1.5)
+const (
+	AuditJobCreate     = "job.create"      // 작업 등록 (target: 작업 ID, before: 원본 작업 ID, after: 프롬프트)
+	AuditJobCancel     = "job.cancel"      // 작업 취소
+	AuditJobApprove    = "job.approve"     // 실행 계획 승인
+	AuditJobReject     = "job.reject"      // 실행 계획 거절
+	AuditJobView       = "job.view"        // 작업 결과 조회 (show, 전체 출력 버튼, API)
+	AuditSetPath       = "config.set_path" // 기본 프로젝트 경로 변경
+	AuditProjectAdd    = "project.add"
+	AuditProjectRemove = "project.remove"
+	AuditProjectSet    = "project.set"   // 프로젝트 설정 변경 (target: @이름 설정)
+	AuditProjectAllow  = "project.allow" // 프로젝트 허용 목록 변경
+	AuditChannelBind   = "channel.bind"
+	AuditChannelUnbind = "channel.unbind"
+	AuditRoleGrant     = "role.grant"
+	AuditRoleRevoke    = "role.revoke"
+	AuditAPIKeyCreate  = "apikey.create"
+	AuditAPIKeyRevoke  = "apikey.revoke"
+)
+
+// AuditEvent는 명령어 실행과 설정 변경 기록입니다 (v1.5)
+// audit_events 테이블은 추가만 가능하며 수정/삭제는 트리거로 거부됩니다.
+type AuditEvent struct {
+	ID        int64     `json:"id" example:"42"`
+	CreatedAt time.Time `json:"created_at"`
+	ActorType string    `json:"actor_type" example:"slack"` // slack, api_key, api, cli
+	ActorID   string    `json:"actor_id,omitempty" example:"U1234567890"`
+	ActorName string    `json:"actor_name,omitempty" example:"alice"`
+	Action    string    `json:"action" example:"config.set_path"`
+	Target    string    `json:"target,omitempty" example:"default"`
+	Before    string    `json:"before,omitempty" example:"/srv/repos/old"`
+	After     string    `json:"after,omitempty" example:"/srv/repos/new"`
+	SourceIP  string    `json:"source_ip,omitempty" example:"10.0.0.5"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// AuditFilter는 감사 이벤트 조회 조건입니다. 빈 값은 조건에서 제외합니다.
+type AuditFilter struct {
+	ActorID string    // 행위자 ID (Slack user_id 또는 API 키 ID)
+	Action  string    // 동작 이름, `job.`처럼 `.`으로 끝나면 접두사로 비교
+	Target  string    // 대상
+	Since   time.Time // 이 시각 이후 (포함)
+	Until   time.Time // 이 시각 이전 (제외)
+	Limit   int       // 최대 개수 (0이면 제한 없음)
+}
+
+// auditColumns는 audit_events 조회 시 사용하는 컬럼 목록입니다.
+const auditColumns = `id, created_at, actor_type, COALESCE(actor_id, ''), COALESCE(actor_name, ''), action,
+	COALESCE(target, ''), COALESCE(before_value, ''), COALESCE(after_value, ''), COALESCE(source_ip, ''), COALESCE(request_id, '')`
+
+// AppendAuditEvent는 감사 이벤트를 추가합니다. CreatedAt이 비어 있으면 현재 시각을 사용합니다.
+func (db *DB) AppendAuditEvent(e *AuditEvent) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	query := `
+		INSERT INTO audit_events (created_at, actor_type, actor_id, actor_name, action, target, before_value, after_value, source_ip, request_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := db.conn.Exec(query, e.CreatedAt, e.ActorType, e.ActorID, e.ActorName, e.Action, e.Target, e.Before, e.After, e.SourceIP, e.RequestID)
+	if err != nil {
+		return err
+	}
+	e.ID, err = res.LastInsertId()
+	return err
+}
+
+// ListAuditEvents는 조건에 맞는 감사 이벤트를 오래된 순으로 조회합니다.
+// Limit을 지정하면 가장 최근 이벤트 Limit개를 반환합니다.
+func (db *DB) ListAuditEvents(f AuditFilter) ([]*AuditEvent, error) {
+	where, args := auditWhere(f)
+	query := "SELECT " + auditColumns + " FROM audit_events"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	if f.Limit > 0 {
+		// 최근 이벤트 Limit개를 오래된 순으로 반환
+		query = "SELECT * FROM (" + query + " ORDER BY id DESC LIMIT ?) ORDER BY id ASC"
+		args = append(args, f.Limit)
+	} else {
+		query += " ORDER BY id ASC"
+	}
+	return db.queryAuditEvents(query, args...)
+}
+
+// ListAuditEventsAfter는 조건에 맞는 감사 이벤트 중 ID가 afterID보다 큰 이벤트를 오래된 순으로 최대 n개 조회합니다 (f.Limit은 무시).
+// 감사 로그 전체를 내보낼 때 페이지 단위로 나누어 읽어, 모든 이벤트를 메모리에 올리거나 긴 조회로 기록을 막지 않게 합니다.
+func (db *DB) ListAuditEventsAfter(f AuditFilter, afterID int64, n int) ([]*AuditEvent, error) {
+	where, args := auditWhere(f)
+	where = append(where, "id > ?")
+	args = append(args, afterID, n)
+	query := "SELECT " + auditColumns + " FROM audit_events WHERE " + strings.Join(where, " AND ") + " ORDER BY id ASC LIMIT ?"
+	return db.queryAuditEvents(query, args...)
+}
+
+// auditWhere는 감사 이벤트 조회 조건을 WHERE 절과 인자로 변환합니다.
+func auditWhere(f AuditFilter) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
+	if f.ActorID != "" {
+		where = append(where, "actor_id = ?")
+		args = append(args, f.ActorID)
+	}
+	if f.Action != "" {
+		if strings.HasSuffix(f.Action, ".") {
+			where = append(where, "substr(action, 1, ?) = ?")
+			args = append(args, len(f.Action), f.Action)
+		} else {
+			where = append(where, "action = ?")
+			args = append(args, f.Action)
+		}
+	}
+	if f.Target != "" {
+		where = append(where, "target = ?")
+		args = append(args, f.Target)
+	}
+	if !f.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, f.Since.Local())
+	}
+	if !f.Until.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, f.Until.Local())
+	}
+	return where, args
+}
+
+// queryAuditEvents는 auditColumns 순서로 조회한 감사 이벤트를 읽습니다.
+func (db *DB) queryAuditEvents(query string, args ...interface{}) ([]*AuditEvent, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*AuditEvent
+	for rows.Next() {
+		e := &AuditEvent{}
+		if err := rows.Scan(&e.ID, &e.CreatedAt, &e.ActorType, &e.ActorID, &e.ActorName, &e.Action,
+			&e.Target, &e.Before, &e.After, &e.SourceIP, &e.RequestID); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListAuditEventsAfter(t *testing.T) {
+	db := newTestDB(t)
+	for _, action := range []string{AuditJobCreate, AuditSetPath, AuditJobCancel, AuditJobCreate} {
+		if err := db.AppendAuditEvent(&AuditEvent{ActorType: AuditActorSlack, ActorID: "U1", Action: action}); err != nil {
+			t.Fatalf("AppendAuditEvent: %v", err)
+		}
+	}
+
+	// 페이지 단위로 이어서 읽으면 조건에 맞는 이벤트를 빠짐없이 오래된 순으로 읽음
+	filter := AuditFilter{Action: "job.", Limit: 1} // Limit은 무시
+	var ids []int64
+	for afterID := int64(0); ; {
+		page, err := db.ListAuditEventsAfter(filter, afterID, 2)
+		if err != nil {
+			t.Fatalf("ListAuditEventsAfter: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, e := range page {
+			ids = append(ids, e.ID)
+		}
+		afterID = page[len(page)-1].ID
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 3 || ids[2] != 4 {
+		t.Errorf("ids = %v, want [1 3 4]", ids)
+	}
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AppendAuditEvent(&AuditEvent{ActorType: AuditActorSlack, ActorID: "U1", Action: AuditJobCreate}); err != nil {
+		t.Fatalf("AppendAuditEvent: %v", err)
+	}
+
+	for _, query := range []string{
+		"UPDATE audit_events SET actor_id = 'U2'",
+		"DELETE FROM audit_events",
+	} {
+		if _, err := db.conn.Exec(query); err == nil || !strings.Contains(err.Error(), "append-only") {
+			t.Errorf("%s: err = %v, want append-only", query, err)
+		}
+	}
+	events, err := db.ListAuditEvents(AuditFilter{})
+	if err != nil || len(events) != 1 || events[0].ActorID != "U1" {
+		t.Errorf("ListAuditEvents = %+v, %v; want 변경되지 않은 이벤트 1개", events, err)
+	}
+}
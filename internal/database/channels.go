@@ -0,0 +1,79 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ChannelBinding은 Slack 채널에 연결된 기본 프로젝트입니다 (v1.5)
+// 바인딩된 채널에서 @이름 없이 요청하면 전역 기본 경로 대신 이 프로젝트에서 실행됩니다.
+// ProjectName이 있으면 등록된 프로젝트를, 없으면 ProjectPath를 직접 사용합니다.
+type ChannelBinding struct {
+	TeamID      string    `json:"team_id"`
+	ChannelID   string    `json:"channel_id"`
+	ChannelName string    `json:"channel_name,omitempty"`
+	ProjectName string    `json:"project_name,omitempty"`
+	ProjectPath string    `json:"project_path,omitempty"`
+	BoundBy     string    `json:"bound_by,omitempty"`
+	BoundAt     time.Time `json:"bound_at"`
+}
+
+// SetChannelBinding은 채널 바인딩을 저장합니다. 이미 바인딩된 채널이면 덮어씁니다.
+func (db *DB) SetChannelBinding(binding *ChannelBinding) error {
+	query := `
+		INSERT INTO channel_bindings (
+			team_id, channel_id, channel_name, project_name, project_path, bound_by, bound_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(team_id, channel_id) DO UPDATE SET
+			channel_name = excluded.channel_name,
+			project_name = excluded.project_name,
+			project_path = excluded.project_path,
+			bound_by = excluded.bound_by,
+			bound_at = excluded.bound_at
+	`
+	_, err := db.conn.Exec(query,
+		binding.TeamID,
+		binding.ChannelID,
+		binding.ChannelName,
+		binding.ProjectName,
+		binding.ProjectPath,
+		binding.BoundBy,
+		binding.BoundAt,
+	)
+	return err
+}
+
+// DeleteChannelBinding은 채널 바인딩을 해제합니다. 바인딩이 없었으면 false를 반환합니다.
+func (db *DB) DeleteChannelBinding(teamID string, channelID string) (bool, error) {
+	res, err := db.conn.Exec("DELETE FROM channel_bindings WHERE team_id = ? AND channel_id = ?", teamID, channelID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// GetChannelBinding은 채널 바인딩을 조회합니다. 바인딩되지 않은 채널이면 (nil, nil)을 반환합니다.
+func (db *DB) GetChannelBinding(teamID string, channelID string) (*ChannelBinding, error) {
+	query := `
+		SELECT team_id, channel_id, COALESCE(channel_name, ''), COALESCE(project_name, ''),
+			COALESCE(project_path, ''), COALESCE(bound_by, ''), bound_at
+		FROM channel_bindings
+		WHERE team_id = ? AND channel_id = ?
+	`
+	b := &ChannelBinding{}
+	err := db.conn.QueryRow(query, teamID, channelID).Scan(
+		&b.TeamID, &b.ChannelID, &b.ChannelName, &b.ProjectName, &b.ProjectPath, &b.BoundBy, &b.BoundAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("채널 바인딩 조회 실패: %w", err)
+	}
+	return b, nil
+}
@@ -2,10 +2,13 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -17,22 +20,79 @@ const (
 	JobStatusRunning   JobStatus = "running"
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled" // v1.5: 사용자 요청으로 취소됨
+
+	JobStatusAwaitingApproval JobStatus = "awaiting_approval" // v1.5: 실행 계획 작성 완료, 승인 대기 중
+	JobStatusRejected         JobStatus = "rejected"          // v1.5: 승인자가 실행 계획을 거절함
+)
+
+// JobKind는 작업 종류를 나타냅니다 (v1.5)
+type JobKind string
+
+const (
+	JobKindAgent  JobKind = "agent"  // cursor-agent 실행 (기본값)
+	JobKindRevert JobKind = "revert" // 다른 작업의 변경 사항 되돌리기
+
+	JobKindContinue JobKind = "continue" // 원본 작업의 cursor-agent 세션을 이어서 실행
 )
 
 // JobRecord는 작업 실행 기록을 나타냅니다
 type JobRecord struct {
-	ID          string    `json:"id"`
-	Prompt      string    `json:"prompt"`
-	ProjectPath string    `json:"project_path"`
-	Status      JobStatus `json:"status"`
-	Output      string    `json:"output,omitempty"`
-	Error       string    `json:"error,omitempty"`
-	UserID      string    `json:"user_id,omitempty"`
-	UserName    string    `json:"user_name,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	StartedAt   *time.Time `json:"started_at,omitempty"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	Duration    int64     `json:"duration,omitempty"` // milliseconds
+	ID               string        `json:"id"`
+	Prompt           string        `json:"prompt"`
+	ProjectPath      string        `json:"project_path"`
+	Status           JobStatus     `json:"status"`
+	Output           string        `json:"output,omitempty"`
+	Error            string        `json:"error,omitempty"`
+	UserID           string        `json:"user_id,omitempty"`
+	UserName         string        `json:"user_name,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+	StartedAt        *time.Time    `json:"started_at,omitempty"`
+	CompletedAt      *time.Time    `json:"completed_at,omitempty"`
+	Duration         int64         `json:"duration,omitempty"`     // milliseconds
+	CancelledBy      string        `json:"cancelled_by,omitempty"` // v1.5: 취소한 사용자 (Slack user_id 또는 "api")
+	CancelledAt      *time.Time    `json:"cancelled_at,omitempty"`
+	ProjectName      string        `json:"project_name,omitempty"`      // v1.5: @이름으로 지정한 프로젝트 (기본 경로 사용 시 빈 값)
+	ChannelID        string        `json:"channel_id,omitempty"`        // v1.5: Slack 채널 ID (API 요청은 빈 값)
+	ChannelName      string        `json:"channel_name,omitempty"`      // v1.5: Slack 채널 이름
+	TeamID           string        `json:"team_id,omitempty"`           // v1.5: Slack 워크스페이스 ID
+	EnterpriseID     string        `json:"enterprise_id,omitempty"`     // v1.5: Slack Enterprise Grid ID
+	WorktreePath     string        `json:"worktree_path,omitempty"`     // v1.5: 작업이 실행된 git worktree (정리된 뒤에도 유지)
+	Branch           string        `json:"branch,omitempty"`            // v1.5: 작업 브랜치 (worktree 격리 모드)
+	BaseCommit       string        `json:"base_commit,omitempty"`       // v1.5: 실행 시작 시점의 HEAD 커밋
+	CheckpointBefore string        `json:"checkpoint_before,omitempty"` // v1.5: 실행 전 작업 디렉토리 스냅샷 커밋
+	CheckpointAfter  string        `json:"checkpoint_after,omitempty"`  // v1.5: 실행 후 작업 디렉토리 스냅샷 커밋
+	Diff             string        `json:"diff,omitempty"`              // v1.5: 실행 전후 스냅샷의 unified diff
+	ChangedFiles     []ChangedFile `json:"changed_files,omitempty"`     // v1.5: 실행 전후 스냅샷 사이에 변경된 파일
+	OpenPR           bool          `json:"open_pr,omitempty"`           // v1.5: 완료 후 PR 생성 요청 여부 (--pr 또는 프로젝트 설정)
+	PRURL            string        `json:"pr_url,omitempty"`            // v1.5: 생성된 PR URL
+	Kind             JobKind       `json:"kind,omitempty"`              // v1.5: 작업 종류 (agent, revert, continue)
+	ParentJobID      string        `json:"parent_job_id,omitempty"`     // v1.5: 원본 작업 ID (revert: 되돌린 작업, continue: 이어서 실행한 작업, 재실행: 원본 작업)
+	RevertedBy       string        `json:"reverted_by,omitempty"`       // v1.5: 이 작업을 되돌린 revert 작업 ID
+	RequireApproval  bool          `json:"require_approval,omitempty"`  // v1.5: 파일 수정 전 실행 계획 승인 필요 (프로젝트 설정)
+	Plan             string        `json:"plan,omitempty"`              // v1.5: 승인 요청 시 제시한 실행 계획 (읽기 전용 실행 결과)
+	ReviewedBy       string        `json:"reviewed_by,omitempty"`       // v1.5: 승인/거절한 사용자
+	ReviewedAt       *time.Time    `json:"reviewed_at,omitempty"`
+	ReviewNote       string        `json:"review_note,omitempty"`       // v1.5: 거절 사유
+	SessionID        string        `json:"session_id,omitempty"`        // v1.5: cursor-agent 채팅 세션 ID (continue 작업이 재개)
+	ThreadTS         string        `json:"thread_ts,omitempty"`         // v1.5: 작업 결과를 게시하는 Slack 스레드 (멘션/DM 요청 또는 봇 토큰으로 게시한 상태 메시지)
+	MessageTS        string        `json:"message_ts,omitempty"`        // v1.5: 진행 상황을 chat.update로 갱신하는 상태 메시지
+	ResponseURL      string        `json:"-"`                           // v1.5: Slack 지연 응답 URL (영속 큐에서 작업 복원용, API로 노출하지 않음)
+	Attempts         int           `json:"attempts,omitempty"`          // v1.5: 실행 시도 횟수 (재시작 후 재실행 포함)
+	APIKeyID         string        `json:"api_key_id,omitempty"`        // v1.5: 작업을 등록한 API 키 ID (Slack 요청은 빈 값)
+	RedactionCount   int           `json:"redaction_count,omitempty"`   // v1.5: 저장/게시 전에 가린 비밀 값 수 (계획, 출력, diff)
+	Agent            string        `json:"agent,omitempty"`             // v1.5: 작업을 실행하는 에이전트 백엔드 (요청 시점에 결정, revert 작업은 빈 값)
+	Model            string        `json:"model,omitempty"`             // v1.5: 요청한 에이전트 모델 (--model, 빈 값이면 에이전트 기본 모델)
+	TimeoutSeconds   int           `json:"timeout_seconds,omitempty"`   // v1.5: 요청한 시간 제한(초) (--timeout, 0이면 기본값)
+	ReadOnly         bool          `json:"read_only,omitempty"`         // v1.5: 파일을 수정하지 않는 읽기 전용 실행 (--readonly)
+	PromptRedacted   bool          `json:"prompt_redacted,omitempty"`   // v1.5: 저장된 프롬프트에서 비밀 값을 가림 (원문은 실행 전까지 메모리에만 있음)
+}
+
+// ChangedFile은 작업이 변경한 파일입니다 (v1.5)
+type ChangedFile struct {
+	Status  string `json:"status" example:"M"` // A(추가), M(수정), D(삭제), R(이름 변경), T(타입 변경)
+	Path    string `json:"path" example:"internal/server/handlers.go"`
+	OldPath string `json:"old_path,omitempty"` // 이름 변경 시 이전 경로
 }
 
 // DB는 SQLite 데이터베이스 연결을 관리합니다
@@ -42,7 +102,17 @@ type DB struct {
 
 // NewDB는 새로운 데이터베이스 연결을 생성합니다
 func NewDB(dbPath string) (*DB, error) {
-	conn, err := sql.Open("sqlite3", dbPath)
+	// v1.5: 작업 큐로도 사용하므로 잠금 대기(busy timeout)를 설정하고,
+	// 트랜잭션은 시작 시점에 쓰기 잠금을 잡도록(IMMEDIATE) 하여 작업 claim을 직렬화합니다.
+	dsn := dbPath
+	if strings.Contains(dsn, "?") {
+		dsn += "&"
+	} else {
+		dsn += "?"
+	}
+	dsn += "_busy_timeout=5000&_txlock=immediate"
+
+	conn, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -84,28 +154,240 @@ func (db *DB) initTables() error {
 	CREATE INDEX IF NOT EXISTS idx_job_status ON job_records(status);
 	CREATE INDEX IF NOT EXISTS idx_job_created_at ON job_records(created_at DESC);
 	CREATE INDEX IF NOT EXISTS idx_job_user_id ON job_records(user_id);
+
+	CREATE TABLE IF NOT EXISTS projects (
+		name TEXT PRIMARY KEY,
+		path TEXT NOT NULL,
+		created_by TEXT,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS channel_bindings (
+		team_id TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		channel_name TEXT,
+		project_name TEXT,
+		project_path TEXT,
+		bound_by TEXT,
+		bound_at DATETIME NOT NULL,
+		PRIMARY KEY (team_id, channel_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS usage_counters (
+		scope TEXT NOT NULL,
+		subject TEXT NOT NULL,
+		period TEXT NOT NULL,
+		value INTEGER NOT NULL DEFAULT 0,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (scope, subject, period)
+	);
+
+	CREATE TABLE IF NOT EXISTS user_roles (
+		subject TEXT PRIMARY KEY,
+		role TEXT NOT NULL,
+		granted_by TEXT,
+		granted_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		key_hash TEXT NOT NULL UNIQUE,
+		prefix TEXT NOT NULL,
+		scopes TEXT NOT NULL,
+		created_by TEXT,
+		created_at DATETIME NOT NULL,
+		last_used_at DATETIME,
+		revoked_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at DATETIME NOT NULL,
+		actor_type TEXT NOT NULL,
+		actor_id TEXT,
+		actor_name TEXT,
+		action TEXT NOT NULL,
+		target TEXT,
+		before_value TEXT,
+		after_value TEXT,
+		source_ip TEXT,
+		request_id TEXT
+	);
+	CREATE INDEX IF NOT EXISTS idx_audit_created_at ON audit_events(created_at);
+	CREATE INDEX IF NOT EXISTS idx_audit_actor ON audit_events(actor_id);
+	CREATE INDEX IF NOT EXISTS idx_audit_action ON audit_events(action);
+
+	-- 감사 로그는 추가만 허용 (수정/삭제 거부)
+	CREATE TRIGGER IF NOT EXISTS audit_events_no_update BEFORE UPDATE ON audit_events
+	BEGIN
+		SELECT RAISE(ABORT, 'audit_events is append-only');
+	END;
+	CREATE TRIGGER IF NOT EXISTS audit_events_no_delete BEFORE DELETE ON audit_events
+	BEGIN
+		SELECT RAISE(ABORT, 'audit_events is append-only');
+	END;
 	`
 
-	_, err := db.conn.Exec(schema)
+	if _, err := db.conn.Exec(schema); err != nil {
+		return err
+	}
+
+	return db.migrate()
+}
+
+// migrate는 기존 DB 파일에 이후 버전에서 추가된 컬럼을 반영합니다.
+// CREATE TABLE IF NOT EXISTS는 이미 존재하는 테이블을 변경하지 않으므로 컬럼 단위로 확인 후 추가합니다.
+func (db *DB) migrate() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		// v1.5: 작업 취소
+		{"job_records", "cancelled_by", "TEXT"},
+		{"job_records", "cancelled_at", "DATETIME"},
+		// v1.5: 영속 작업 큐
+		{"job_records", "response_url", "TEXT"},
+		{"job_records", "attempts", "INTEGER NOT NULL DEFAULT 0"},
+		// v1.5: 이름 있는 프로젝트
+		{"job_records", "project_name", "TEXT"},
+		// v1.5: Slack 요청 위치
+		{"job_records", "channel_id", "TEXT"},
+		{"job_records", "channel_name", "TEXT"},
+		{"job_records", "team_id", "TEXT"},
+		{"job_records", "enterprise_id", "TEXT"},
+		// v1.5: 작업별 git worktree 격리
+		{"job_records", "worktree_path", "TEXT"},
+		{"job_records", "branch", "TEXT"},
+		// v1.5: 실행 전후 스냅샷 기반 변경 사항
+		{"job_records", "base_commit", "TEXT"},
+		{"job_records", "checkpoint_before", "TEXT"},
+		{"job_records", "checkpoint_after", "TEXT"},
+		{"job_records", "diff", "TEXT"},
+		{"job_records", "changed_files", "TEXT"},
+		// v1.5: 작업 완료 후 PR 생성
+		{"job_records", "open_pr", "INTEGER NOT NULL DEFAULT 0"},
+		{"job_records", "pr_url", "TEXT"},
+		{"projects", "auto_pr", "INTEGER NOT NULL DEFAULT 0"},
+		// v1.5: 작업 되돌리기
+		{"job_records", "kind", "TEXT NOT NULL DEFAULT 'agent'"},
+		{"job_records", "parent_job_id", "TEXT"},
+		{"job_records", "reverted_by", "TEXT"},
+		// v1.5: 실행 계획 승인
+		{"job_records", "require_approval", "INTEGER NOT NULL DEFAULT 0"},
+		{"job_records", "plan", "TEXT"},
+		{"job_records", "reviewed_by", "TEXT"},
+		{"job_records", "reviewed_at", "DATETIME"},
+		{"job_records", "review_note", "TEXT"},
+		{"projects", "require_approval", "INTEGER NOT NULL DEFAULT 0"},
+		{"projects", "reviewers", "TEXT"},
+		// v1.5: 세션 이어서 실행
+		{"job_records", "session_id", "TEXT"},
+		{"job_records", "thread_ts", "TEXT"},
+		{"job_records", "message_ts", "TEXT"},
+		// v1.5: 역할 기반 접근 제어 (프로젝트별 허용 목록)
+		{"projects", "allowed_users", "TEXT"},
+		// v1.5: API 키 인증 (작업을 등록한 키)
+		{"job_records", "api_key_id", "TEXT"},
+		// v1.5: 비밀 값 가리기
+		{"job_records", "redaction_count", "INTEGER NOT NULL DEFAULT 0"},
+		// v1.5: 에이전트 백엔드 선택 (기존 작업은 cursor-agent로 실행됨)
+		{"job_records", "agent", "TEXT NOT NULL DEFAULT 'cursor'"},
+		{"projects", "agent", "TEXT"},
+		// v1.5: 요청별 에이전트 옵션 (--model, --timeout, --readonly)
+		{"job_records", "model", "TEXT"},
+		{"job_records", "timeout_seconds", "INTEGER NOT NULL DEFAULT 0"},
+		{"job_records", "read_only", "INTEGER NOT NULL DEFAULT 0"},
+		// v1.5: 프로젝트별 작업 시간 제한 (0이면 JOB_TIMEOUT)
+		{"projects", "timeout_seconds", "INTEGER NOT NULL DEFAULT 0"},
+		// v1.5: API 키별 프로젝트 허용 목록 (비어 있으면 모든 작업)
+		{"api_keys", "projects", "TEXT"},
+		// v1.5: 비밀 값을 가린 프롬프트 (원문이 없으면 다시 실행할 수 없음)
+		{"job_records", "prompt_redacted", "INTEGER NOT NULL DEFAULT 0"},
+	}
+
+	for _, col := range columns {
+		if err := db.addColumnIfMissing(col.table, col.name, col.definition); err != nil {
+			return fmt.Errorf("%s.%s 컬럼 추가 실패: %w", col.table, col.name, err)
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing은 테이블에 컬럼이 없을 때만 ALTER TABLE로 추가합니다.
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
 // CreateJob은 새로운 작업 레코드를 생성합니다
 func (db *DB) CreateJob(job *JobRecord) error {
+	kind := job.Kind
+	if kind == "" {
+		kind = JobKindAgent
+	}
+
 	query := `
 		INSERT INTO job_records (
-			id, prompt, project_path, status, user_id, user_name, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			id, prompt, project_path, project_name, status, user_id, user_name, created_at, response_url,
+			channel_id, channel_name, team_id, enterprise_id, open_pr, kind, parent_job_id, require_approval,
+			thread_ts, api_key_id, agent, model, timeout_seconds, read_only, prompt_redacted
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := db.conn.Exec(query,
 		job.ID,
 		job.Prompt,
 		job.ProjectPath,
+		job.ProjectName,
 		job.Status,
 		job.UserID,
 		job.UserName,
 		job.CreatedAt,
+		job.ResponseURL,
+		job.ChannelID,
+		job.ChannelName,
+		job.TeamID,
+		job.EnterpriseID,
+		job.OpenPR,
+		kind,
+		job.ParentJobID,
+		job.RequireApproval,
+		job.ThreadTS,
+		job.APIKeyID,
+		job.Agent,
+		job.Model,
+		job.TimeoutSeconds,
+		job.ReadOnly,
+		job.PromptRedacted,
 	)
 
 	return err
@@ -122,6 +404,7 @@ func (db *DB) UpdateJobStatus(jobID string, status JobStatus) error {
 		_, err := db.conn.Exec(query, status, now, jobID)
 		return err
 	case JobStatusCompleted, JobStatusFailed:
+		// v1.5: 이미 취소된 작업은 완료/실패로 덮어쓰지 않음
 		// duration 계산
 		var startedAt *time.Time
 		err := db.conn.QueryRow("SELECT started_at FROM job_records WHERE id = ?", jobID).Scan(&startedAt)
@@ -134,8 +417,8 @@ func (db *DB) UpdateJobStatus(jobID string, status JobStatus) error {
 			duration = now.Sub(*startedAt).Milliseconds()
 		}
 
-		query = "UPDATE job_records SET status = ?, completed_at = ?, duration = ? WHERE id = ?"
-		_, err = db.conn.Exec(query, status, now, duration, jobID)
+		query = "UPDATE job_records SET status = ?, completed_at = ?, duration = ? WHERE id = ? AND status != ?"
+		_, err = db.conn.Exec(query, status, now, duration, jobID, JobStatusCancelled)
 		return err
 	default:
 		query = "UPDATE job_records SET status = ? WHERE id = ?"
@@ -144,6 +427,342 @@ func (db *DB) UpdateJobStatus(jobID string, status JobStatus) error {
 	}
 }
 
+// ClaimNextJob은 가장 오래된 대기 중(pending) 작업 하나를 트랜잭션으로 가져와 실행 중(running)으로 전환합니다 (v1.5)
+// 대기 중인 작업이 없으면 (nil, nil)을 반환합니다.
+// 같은 DB는 서버 하나만 사용하므로(DB 잠금 파일) 작업자 간 경합은 이 트랜잭션으로 충분합니다.
+func (db *DB) ClaimNextJob() (*JobRecord, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var jobID string
+	err = tx.QueryRow(
+		"SELECT id FROM job_records WHERE status = ? ORDER BY created_at ASC LIMIT 1",
+		JobStatusPending,
+	).Scan(&jobID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE job_records SET status = ?, started_at = ?, attempts = attempts + 1 WHERE id = ? AND status = ?"
+	if _, err := tx.Exec(query, JobStatusRunning, time.Now(), jobID, JobStatusPending); err != nil {
+		return nil, err
+	}
+
+	job, err := scanJob(tx.QueryRow("SELECT "+jobColumns+" FROM job_records WHERE id = ?", jobID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ReleaseJob은 claim했지만 실행하지 못한 작업을 다시 대기 상태로 되돌립니다 (v1.5)
+func (db *DB) ReleaseJob(jobID string) error {
+	query := "UPDATE job_records SET status = ?, started_at = NULL, attempts = MAX(attempts - 1, 0) WHERE id = ? AND status = ?"
+	_, err := db.conn.Exec(query, JobStatusPending, jobID, JobStatusRunning)
+	return err
+}
+
+// ReconcileOrphanedJobs는 서버가 비정상 종료되어 running 상태로 남은 작업을 정리합니다 (v1.5)
+// 기본적으로 사유와 함께 실패 처리하며, requeue가 true(ORPHANED_JOB_POLICY=requeue)이고 시도 횟수가
+// maxAttempts 미만인 작업만 다시 대기열에 넣습니다. 비밀 값을 가린 프롬프트(prompt_redacted)는 원문이
+// 메모리에만 있어 재시작 후 다시 실행할 수 없으므로 항상 실패 처리합니다.
+// 중단된 작업은 파일을 일부만 수정했을 수 있으므로 작업별로 부분 상태(부분 출력 크기, 작업 디렉토리)를 로그에 남기고,
+// 중단 시점의 부분 출력은 어느 쪽이든 지웁니다 (다시 실행하면 처음부터 출력하고, 실패한 작업의 결과로 보이지 않도록).
+// 실행 중인 다른 서버의 작업을 정리하지 않도록, 서버 시작 시 DB 잠금 파일을 얻은 뒤에만 호출해야 합니다.
+func (db *DB) ReconcileOrphanedJobs(requeue bool, maxAttempts int) (requeued int, failed int, err error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, attempts, prompt_redacted, COALESCE(project_path, ''), COALESCE(worktree_path, ''),
+		       LENGTH(CAST(COALESCE(output, '') AS BLOB))
+		FROM job_records WHERE status = ?
+	`, JobStatusRunning)
+	if err != nil {
+		return 0, 0, err
+	}
+	for rows.Next() {
+		var id, projectPath, worktreePath string
+		var attempts, outputBytes int
+		var promptRedacted bool
+		if err := rows.Scan(&id, &attempts, &promptRedacted, &projectPath, &worktreePath, &outputBytes); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		dir := projectPath
+		if worktreePath != "" {
+			dir = worktreePath
+		}
+		action := "실패 처리"
+		if requeue && attempts < maxAttempts && !promptRedacted {
+			action = "다시 대기열에 등록"
+		}
+		log.Printf("[%s] 서버 재시작으로 중단된 작업 %s (시도 %d회, 부분 출력 %d바이트 삭제, 파일이 일부 수정되었을 수 있음: %s)",
+			id, action, attempts, outputBytes, dir)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	if requeue {
+		res, err := tx.Exec(`
+			UPDATE job_records
+			SET status = ?, started_at = NULL, output = '',
+			    error = '서버 재시작으로 중단되어 다시 대기열에 등록되었습니다.'
+			WHERE status = ? AND attempts < ? AND prompt_redacted = 0
+		`, JobStatusPending, JobStatusRunning, maxAttempts)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, _ := res.RowsAffected()
+		requeued = int(n)
+	}
+
+	now := time.Now()
+	reason := "서버가 작업 실행 중 종료되어 작업이 중단되었습니다."
+	redactedReason := reason
+	if requeue {
+		reason = fmt.Sprintf("서버가 작업 실행 중 종료되어 작업이 중단되었습니다 (최대 시도 횟수 %d회 초과).", maxAttempts)
+		redactedReason = "서버가 작업 실행 중 종료되어 작업이 중단되었습니다 (비밀 값을 가리기 전의 프롬프트가 없어 다시 실행할 수 없습니다. 프롬프트를 다시 입력해 새로 요청해주세요)."
+	}
+	res, err := tx.Exec(`
+		UPDATE job_records
+		SET status = ?, error = CASE WHEN prompt_redacted = 1 THEN ? ELSE ? END, output = '', completed_at = ?,
+		    duration = CASE WHEN started_at IS NULL THEN 0
+		                    ELSE CAST((julianday(?) - julianday(started_at)) * 86400000 AS INTEGER) END
+		WHERE status = ?
+	`, JobStatusFailed, redactedReason, reason, now, now, JobStatusRunning)
+	if err != nil {
+		return 0, 0, err
+	}
+	n, _ := res.RowsAffected()
+	failed = int(n)
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return requeued, failed, nil
+}
+
+// UpdateJobProjectPath는 작업이 실제로 실행된 프로젝트 경로를 기록합니다 (v1.5)
+func (db *DB) UpdateJobProjectPath(jobID string, projectPath string) error {
+	query := "UPDATE job_records SET project_path = ? WHERE id = ?"
+	_, err := db.conn.Exec(query, projectPath, jobID)
+	return err
+}
+
+// UpdateJobWorktree는 작업이 실행된 git worktree와 브랜치를 기록합니다 (v1.5)
+// worktree가 정리된 뒤에도 경로는 실행 위치 기록으로 유지합니다.
+func (db *DB) UpdateJobWorktree(jobID string, worktreePath string, branch string) error {
+	query := "UPDATE job_records SET worktree_path = ?, branch = ? WHERE id = ?"
+	_, err := db.conn.Exec(query, worktreePath, branch, jobID)
+	return err
+}
+
+// maxStoredDiffBytes는 job_records에 저장하는 diff의 최대 크기입니다.
+const maxStoredDiffBytes = 1 << 20
+
+// UpdateJobChanges는 실행 전후 스냅샷으로 계산한 변경 사항을 기록합니다 (v1.5)
+// diff가 maxStoredDiffBytes를 넘으면 잘라서 저장합니다 (전체 내용은 체크포인트 커밋으로 다시 계산 가능).
+func (db *DB) UpdateJobChanges(jobID string, baseCommit string, checkpointBefore string, checkpointAfter string, diff string, files []ChangedFile) error {
+	if len(diff) > maxStoredDiffBytes {
+		diff = textutil.Truncate(diff, maxStoredDiffBytes) + "\n... (diff가 너무 커서 잘렸습니다)\n"
+	}
+
+	changedFiles, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE job_records
+		SET base_commit = ?, checkpoint_before = ?, checkpoint_after = ?, diff = ?, changed_files = ?
+		WHERE id = ?
+	`
+	_, err = db.conn.Exec(query, baseCommit, checkpointBefore, checkpointAfter, diff, string(changedFiles), jobID)
+	return err
+}
+
+// UpdateJobPullRequest는 작업 변경 사항으로 생성한 PR의 브랜치와 URL을 기록합니다 (v1.5)
+func (db *DB) UpdateJobPullRequest(jobID string, branch string, prURL string) error {
+	query := "UPDATE job_records SET branch = ?, pr_url = ? WHERE id = ?"
+	_, err := db.conn.Exec(query, branch, prURL, jobID)
+	return err
+}
+
+// MarkJobReverted는 작업이 revert 작업으로 되돌려졌음을 기록합니다 (v1.5)
+func (db *DB) MarkJobReverted(jobID string, revertJobID string) error {
+	_, err := db.conn.Exec("UPDATE job_records SET reverted_by = ? WHERE id = ?", revertJobID, jobID)
+	return err
+}
+
+// UpdateJobSession은 cursor-agent가 보고한 채팅 세션 ID를 기록합니다 (v1.5)
+func (db *DB) UpdateJobSession(jobID string, sessionID string) error {
+	_, err := db.conn.Exec("UPDATE job_records SET session_id = ? WHERE id = ?", sessionID, jobID)
+	return err
+}
+
+// ListJobContinuations는 작업을 이어서 실행한 continue 작업 목록을 생성 순으로 조회합니다 (v1.5)
+func (db *DB) ListJobContinuations(jobID string) ([]*JobRecord, error) {
+	query := `SELECT ` + jobColumns + ` FROM job_records
+		WHERE parent_job_id = ? AND kind = ?
+		ORDER BY created_at ASC`
+
+	rows, err := db.conn.Query(query, jobID, JobKindContinue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*JobRecord
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// UpdateJobThread는 작업 결과를 게시하는 Slack 스레드와 상태 메시지를 기록합니다 (v1.5)
+// 승인 후 다시 실행되는 작업은 기록된 상태 메시지를 갱신하고 같은 스레드에 결과를 게시합니다.
+func (db *DB) UpdateJobThread(jobID string, threadTS string, messageTS string) error {
+	_, err := db.conn.Exec("UPDATE job_records SET thread_ts = ?, message_ts = ? WHERE id = ?", threadTS, messageTS, jobID)
+	return err
+}
+
+// GetLatestThreadJob은 Slack 스레드에서 가장 최근에 요청된 작업을 조회합니다. 없으면 nil을 반환합니다 (v1.5)
+func (db *DB) GetLatestThreadJob(channelID string, threadTS string) (*JobRecord, error) {
+	query := `SELECT ` + jobColumns + ` FROM job_records
+		WHERE channel_id = ? AND thread_ts = ?
+		ORDER BY created_at DESC LIMIT 1`
+
+	job, err := scanJob(db.conn.QueryRow(query, channelID, threadTS))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return job, err
+}
+
+// ListJobsStartedAfter는 같은 작업 디렉토리에서 since 이후 시작된 다른 작업을 시작 순서대로 조회합니다 (v1.5)
+// worktree에서 실행된 작업은 worktreePath가 같은 작업만 같은 디렉토리로 취급합니다.
+func (db *DB) ListJobsStartedAfter(projectPath string, worktreePath string, since time.Time, excludeID string) ([]*JobRecord, error) {
+	query := `SELECT ` + jobColumns + ` FROM job_records
+		WHERE started_at > ? AND COALESCE(project_path, '') = ? AND COALESCE(worktree_path, '') = ? AND id != ?
+		ORDER BY started_at ASC`
+
+	rows, err := db.conn.Query(query, since, projectPath, worktreePath, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*JobRecord
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// CancelJob은 대기 중이거나 실행 중인 작업을 취소 상태로 전환합니다 (v1.5)
+// 승인 대기 중인 작업도 취소할 수 있습니다. 이미 종료된 작업이면 false를 반환합니다.
+func (db *DB) CancelJob(jobID string, cancelledBy string) (bool, error) {
+	now := time.Now()
+	query := `
+		UPDATE job_records
+		SET status = ?, cancelled_by = ?, cancelled_at = ?, completed_at = ?,
+		    duration = CASE WHEN started_at IS NULL THEN 0
+		                    ELSE CAST((julianday(?) - julianday(started_at)) * 86400000 AS INTEGER) END
+		WHERE id = ? AND status IN (?, ?, ?)
+	`
+	res, err := db.conn.Exec(query, JobStatusCancelled, cancelledBy, now, now, now, jobID, JobStatusPending, JobStatusRunning, JobStatusAwaitingApproval)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetJobAwaitingApproval은 계획 단계를 마친 작업을 승인 대기 상태로 전환하고 계획을 저장합니다 (v1.5)
+// 계획 단계의 부분 출력은 지웁니다. 그 사이 취소된 작업이면 false를 반환합니다.
+func (db *DB) SetJobAwaitingApproval(jobID string, plan string) (bool, error) {
+	query := "UPDATE job_records SET status = ?, plan = ?, output = '' WHERE id = ? AND status = ?"
+	res, err := db.conn.Exec(query, JobStatusAwaitingApproval, plan, jobID, JobStatusRunning)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ApproveJob은 승인 대기 중인 작업을 승인하고 다시 대기열(pending)에 넣습니다 (v1.5)
+// responseURL이 있으면 결과를 승인 메시지의 response_url로 보내도록 교체합니다 (Slack response_url은 30분 후 만료).
+// 승인 대기 상태가 아니면 false를 반환합니다.
+func (db *DB) ApproveJob(jobID string, reviewer string, responseURL string) (bool, error) {
+	query := `
+		UPDATE job_records
+		SET status = ?, reviewed_by = ?, reviewed_at = ?, started_at = NULL, attempts = 0,
+		    response_url = CASE WHEN ? != '' THEN ? ELSE response_url END
+		WHERE id = ? AND status = ?
+	`
+	res, err := db.conn.Exec(query, JobStatusPending, reviewer, time.Now(), responseURL, responseURL, jobID, JobStatusAwaitingApproval)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// RejectJob은 승인 대기 중인 작업을 거절 상태로 종료합니다 (v1.5)
+// 승인 대기 상태가 아니면 false를 반환합니다.
+func (db *DB) RejectJob(jobID string, reviewer string, note string) (bool, error) {
+	now := time.Now()
+	query := `
+		UPDATE job_records
+		SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?, completed_at = ?,
+		    duration = CASE WHEN started_at IS NULL THEN 0
+		                    ELSE CAST((julianday(?) - julianday(started_at)) * 86400000 AS INTEGER) END
+		WHERE id = ? AND status = ?
+	`
+	res, err := db.conn.Exec(query, JobStatusRejected, reviewer, now, note, now, now, jobID, JobStatusAwaitingApproval)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
 // UpdateJobResult는 작업 결과를 업데이트합니다
 func (db *DB) UpdateJobResult(jobID string, output string, errMsg string) error {
 	query := "UPDATE job_records SET output = ?, error = ? WHERE id = ?"
@@ -151,33 +770,40 @@ func (db *DB) UpdateJobResult(jobID string, output string, errMsg string) error
 	return err
 }
 
-// GetJob은 작업 레코드를 조회합니다
-func (db *DB) GetJob(jobID string) (*JobRecord, error) {
-	// v1.4.1: 8자리 prefix 검색 지원 (Slack UX 개선)
-	// 8자리면 LIKE 'prefix%' 검색, 그 외는 정확히 일치
-	var query string
-	var searchID string
-	
-	if len(jobID) == 8 {
-		// 접두사 검색 (예: "3a15a0af" → "3a15a0af%")
-		query = `
-			SELECT id, prompt, project_path, status, output, error, 
-			       user_id, user_name, created_at, started_at, completed_at, duration
-			FROM job_records WHERE id LIKE ? LIMIT 1
-		`
-		searchID = jobID + "%"
-	} else {
-		// 정확한 일치 검색
-		query = `
-			SELECT id, prompt, project_path, status, output, error, 
-			       user_id, user_name, created_at, started_at, completed_at, duration
-			FROM job_records WHERE id = ?
-		`
-		searchID = jobID
-	}
+// AddJobRedactions는 작업 결과에서 가린 비밀 값 수를 더합니다 (v1.5)
+func (db *DB) AddJobRedactions(jobID string, count int) error {
+	query := "UPDATE job_records SET redaction_count = redaction_count + ? WHERE id = ?"
+	_, err := db.conn.Exec(query, count, jobID)
+	return err
+}
+
+// jobColumns는 job_records 조회 시 사용하는 컬럼 목록입니다.
+// 실행 중인 작업은 output/error/duration이 NULL일 수 있으므로 COALESCE로 기본값을 채웁니다.
+const jobColumns = `
+	id, prompt, COALESCE(project_path, ''), status, COALESCE(output, ''), COALESCE(error, ''),
+	COALESCE(user_id, ''), COALESCE(user_name, ''), created_at, started_at, completed_at, COALESCE(duration, 0),
+	COALESCE(cancelled_by, ''), cancelled_at, COALESCE(response_url, ''), attempts,
+	COALESCE(project_name, ''), COALESCE(channel_id, ''), COALESCE(channel_name, ''),
+	COALESCE(team_id, ''), COALESCE(enterprise_id, ''), COALESCE(worktree_path, ''), COALESCE(branch, ''),
+	COALESCE(base_commit, ''), COALESCE(checkpoint_before, ''), COALESCE(checkpoint_after, ''),
+	COALESCE(diff, ''), COALESCE(changed_files, ''), open_pr, COALESCE(pr_url, ''),
+	kind, COALESCE(parent_job_id, ''), COALESCE(reverted_by, ''),
+	require_approval, COALESCE(plan, ''), COALESCE(reviewed_by, ''), reviewed_at, COALESCE(review_note, ''),
+	COALESCE(session_id, ''), COALESCE(thread_ts, ''), COALESCE(message_ts, ''),
+	COALESCE(api_key_id, ''), redaction_count, COALESCE(agent, ''),
+	COALESCE(model, ''), timeout_seconds, read_only, prompt_redacted
+`
+
+// rowScanner는 *sql.Row와 *sql.Rows의 공통 인터페이스입니다.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
+// scanJob은 jobColumns 순서대로 한 행을 JobRecord로 읽습니다.
+func scanJob(row rowScanner) (*JobRecord, error) {
 	job := &JobRecord{}
-	err := db.conn.QueryRow(query, searchID).Scan(
+	var changedFiles string
+	err := row.Scan(
 		&job.ID,
 		&job.Prompt,
 		&job.ProjectPath,
@@ -190,7 +816,79 @@ func (db *DB) GetJob(jobID string) (*JobRecord, error) {
 		&job.StartedAt,
 		&job.CompletedAt,
 		&job.Duration,
+		&job.CancelledBy,
+		&job.CancelledAt,
+		&job.ResponseURL,
+		&job.Attempts,
+		&job.ProjectName,
+		&job.ChannelID,
+		&job.ChannelName,
+		&job.TeamID,
+		&job.EnterpriseID,
+		&job.WorktreePath,
+		&job.Branch,
+		&job.BaseCommit,
+		&job.CheckpointBefore,
+		&job.CheckpointAfter,
+		&job.Diff,
+		&changedFiles,
+		&job.OpenPR,
+		&job.PRURL,
+		&job.Kind,
+		&job.ParentJobID,
+		&job.RevertedBy,
+		&job.RequireApproval,
+		&job.Plan,
+		&job.ReviewedBy,
+		&job.ReviewedAt,
+		&job.ReviewNote,
+		&job.SessionID,
+		&job.ThreadTS,
+		&job.MessageTS,
+		&job.APIKeyID,
+		&job.RedactionCount,
+		&job.Agent,
+		&job.Model,
+		&job.TimeoutSeconds,
+		&job.ReadOnly,
+		&job.PromptRedacted,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if changedFiles != "" {
+		if err := json.Unmarshal([]byte(changedFiles), &job.ChangedFiles); err != nil {
+			return nil, fmt.Errorf("changed_files 파싱 실패: %w", err)
+		}
+	}
+	return job, nil
+}
+
+// UpdateJobOutput은 실행 중인 작업의 부분 출력을 저장합니다 (v1.5: 실시간 스트리밍)
+func (db *DB) UpdateJobOutput(jobID string, output string) error {
+	query := "UPDATE job_records SET output = ? WHERE id = ?"
+	_, err := db.conn.Exec(query, output, jobID)
+	return err
+}
+
+// GetJob은 작업 레코드를 조회합니다
+func (db *DB) GetJob(jobID string) (*JobRecord, error) {
+	// v1.4.1: 8자리 prefix 검색 지원 (Slack UX 개선)
+	// 8자리면 LIKE 'prefix%' 검색, 그 외는 정확히 일치
+	var query string
+	var searchID string
+	
+	if len(jobID) == 8 {
+		// 접두사 검색 (예: "3a15a0af" → "3a15a0af%")
+		query = "SELECT " + jobColumns + " FROM job_records WHERE id LIKE ? LIMIT 1"
+		searchID = jobID + "%"
+	} else {
+		// 정확한 일치 검색
+		query = "SELECT " + jobColumns + " FROM job_records WHERE id = ?"
+		searchID = jobID
+	}
+
+	job, err := scanJob(db.conn.QueryRow(query, searchID))
 
 	if err == sql.ErrNoRows {
 		if len(jobID) == 8 {
@@ -211,9 +909,7 @@ func (db *DB) ListJobs(limit int, offset int, status JobStatus) ([]*JobRecord, e
 	var args []interface{}
 
 	if status != "" {
-		query = `
-			SELECT id, prompt, project_path, status, output, error,
-			       user_id, user_name, created_at, started_at, completed_at, duration
+		query = "SELECT " + jobColumns + `
 			FROM job_records
 			WHERE status = ?
 			ORDER BY created_at DESC
@@ -221,9 +917,7 @@ func (db *DB) ListJobs(limit int, offset int, status JobStatus) ([]*JobRecord, e
 		`
 		args = []interface{}{status, limit, offset}
 	} else {
-		query = `
-			SELECT id, prompt, project_path, status, output, error,
-			       user_id, user_name, created_at, started_at, completed_at, duration
+		query = "SELECT " + jobColumns + `
 			FROM job_records
 			ORDER BY created_at DESC
 			LIMIT ? OFFSET ?
@@ -239,21 +933,7 @@ func (db *DB) ListJobs(limit int, offset int, status JobStatus) ([]*JobRecord, e
 
 	var jobs []*JobRecord
 	for rows.Next() {
-		job := &JobRecord{}
-		err := rows.Scan(
-			&job.ID,
-			&job.Prompt,
-			&job.ProjectPath,
-			&job.Status,
-			&job.Output,
-			&job.Error,
-			&job.UserID,
-			&job.UserName,
-			&job.CreatedAt,
-			&job.StartedAt,
-			&job.CompletedAt,
-			&job.Duration,
-		)
+		job, err := scanJob(rows)
 		if err != nil {
 			return nil, err
 		}
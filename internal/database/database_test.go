@@ -0,0 +1,70 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := NewDB(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestReconcileOrphanedJobs(t *testing.T) {
+	tests := []struct {
+		name           string
+		requeue        bool
+		maxAttempts    int
+		promptRedacted bool
+		wantRequeued   int
+		wantFailed     int
+		wantStatus     JobStatus
+	}{
+		{"fail by default", false, 3, false, 0, 1, JobStatusFailed},
+		{"requeue opt-in", true, 3, false, 1, 0, JobStatusPending},
+		{"requeue over max attempts", true, 1, false, 0, 1, JobStatusFailed},
+		{"redacted prompt is not requeued", true, 3, true, 0, 1, JobStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			job := &JobRecord{ID: "11111111-0000-0000-0000-000000000000", Prompt: "README 정리해줘", ProjectPath: t.TempDir(), UserID: "U1", ChannelID: "C1", Status: JobStatusPending, CreatedAt: time.Now(), PromptRedacted: tt.promptRedacted}
+			if err := db.CreateJob(job); err != nil {
+				t.Fatalf("CreateJob: %v", err)
+			}
+			if claimed, err := db.ClaimNextJob(); err != nil || claimed == nil {
+				t.Fatalf("ClaimNextJob = %v, %v", claimed, err)
+			}
+			if err := db.UpdateJobOutput(job.ID, "절반쯤 수정한 출력"); err != nil {
+				t.Fatalf("UpdateJobOutput: %v", err)
+			}
+
+			requeued, failed, err := db.ReconcileOrphanedJobs(tt.requeue, tt.maxAttempts)
+			if err != nil {
+				t.Fatalf("ReconcileOrphanedJobs: %v", err)
+			}
+			if requeued != tt.wantRequeued || failed != tt.wantFailed {
+				t.Errorf("requeued, failed = %d, %d; want %d, %d", requeued, failed, tt.wantRequeued, tt.wantFailed)
+			}
+			got, err := db.GetJob(job.ID)
+			if err != nil || got == nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if got.Output != "" {
+				t.Errorf("부분 출력이 남아 있습니다: %q", got.Output)
+			}
+			if got.Error == "" {
+				t.Error("중단 사유가 기록되지 않았습니다")
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Project는 이름으로 참조하는 프로젝트 등록 정보입니다 (v1.5)
+// 예: backend → /srv/repos/backend
+type Project struct {
+	Name      string    `json:"name" example:"backend"`
+	Path      string    `json:"path" example:"/srv/repos/backend"`
+	AutoPR    bool      `json:"auto_pr" example:"false"` // 모든 작업 완료 후 PR 생성 (--pr 없이도)
+	CreatedBy string    `json:"created_by,omitempty" example:"U1234567890"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// v1.5: 파일 수정 전 실행 계획 승인
+	RequireApproval bool     `json:"require_approval" example:"false"`
+	Reviewers       []string `json:"reviewers,omitempty" example:"U1234567890,U0987654321"` // 승인 가능한 Slack user_id 또는 사용자 그룹 ID (비어 있으면 APPROVAL_REVIEWERS)
+
+	// v1.5: 작업을 실행할 수 있는 Slack user_id / user group ID (비어 있으면 operator 이상 모두)
+	AllowedUsers []string `json:"allowed_users,omitempty" example:"U1234567890,S0123456789"`
+
+	// v1.5: 작업을 실행할 에이전트 백엔드 (비어 있으면 기본 백엔드, --agent 옵션이 우선)
+	Agent string `json:"agent,omitempty" example:"cursor"`
+
+	// v1.5: 작업 시간 제한(초) (0이면 JOB_TIMEOUT, --timeout 옵션이 우선)
+	TimeoutSeconds int `json:"timeout_seconds,omitempty" example:"1800"`
+}
+
+// projectColumns는 projects 조회 시 사용하는 컬럼 목록입니다.
+const projectColumns = `name, path, auto_pr, COALESCE(created_by, ''), created_at, require_approval, COALESCE(reviewers, ''), COALESCE(allowed_users, ''), COALESCE(agent, ''), timeout_seconds`
+
+// scanProject는 projectColumns 순서대로 한 행을 Project로 읽습니다.
+func scanProject(row rowScanner) (*Project, error) {
+	p := &Project{}
+	var reviewers, allowed string
+	if err := row.Scan(&p.Name, &p.Path, &p.AutoPR, &p.CreatedBy, &p.CreatedAt, &p.RequireApproval, &reviewers, &allowed, &p.Agent, &p.TimeoutSeconds); err != nil {
+		return nil, err
+	}
+	if reviewers != "" {
+		p.Reviewers = strings.Split(reviewers, ",")
+	}
+	if allowed != "" {
+		p.AllowedUsers = strings.Split(allowed, ",")
+	}
+	return p, nil
+}
+
+// CreateProject는 새 프로젝트를 등록합니다. 같은 이름이 이미 있으면 에러를 반환합니다.
+func (db *DB) CreateProject(project *Project) error {
+	query := "INSERT INTO projects (name, path, auto_pr, created_by, created_at, require_approval, reviewers, agent, timeout_seconds) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err := db.conn.Exec(query, project.Name, project.Path, project.AutoPR, project.CreatedBy, project.CreatedAt,
+		project.RequireApproval, strings.Join(project.Reviewers, ","), project.Agent, project.TimeoutSeconds)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE") {
+		return fmt.Errorf("이미 등록된 프로젝트입니다: %s", project.Name)
+	}
+	return err
+}
+
+// DeleteProject는 프로젝트 등록을 삭제합니다. 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) DeleteProject(name string) (bool, error) {
+	res, err := db.conn.Exec("DELETE FROM projects WHERE name = ?", name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectAutoPR은 프로젝트의 자동 PR 생성 설정을 변경합니다. 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectAutoPR(name string, autoPR bool) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET auto_pr = ? WHERE name = ?", autoPR, name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectApproval은 프로젝트의 실행 계획 승인 필요 여부를 변경합니다 (v1.5)
+// 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectApproval(name string, required bool) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET require_approval = ? WHERE name = ?", required, name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectReviewers는 프로젝트의 승인자 목록(Slack user_id)을 변경합니다 (v1.5)
+// 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectReviewers(name string, reviewers []string) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET reviewers = ? WHERE name = ?", strings.Join(reviewers, ","), name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectAllowedUsers는 프로젝트에서 작업을 실행할 수 있는 사용자/그룹 목록을 변경합니다 (v1.5)
+// 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectAllowedUsers(name string, subjects []string) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET allowed_users = ? WHERE name = ?", strings.Join(subjects, ","), name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectAgent는 프로젝트의 기본 에이전트 백엔드를 변경합니다 (v1.5)
+// agent가 비어 있으면 서버 기본 백엔드를 사용합니다. 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectAgent(name string, agent string) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET agent = ? WHERE name = ?", agent, name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SetProjectTimeout은 프로젝트 작업의 시간 제한(초)을 변경합니다 (v1.5)
+// seconds가 0이면 서버 기본값(JOB_TIMEOUT)을 사용합니다. 등록되지 않은 이름이면 false를 반환합니다.
+func (db *DB) SetProjectTimeout(name string, seconds int) (bool, error) {
+	res, err := db.conn.Exec("UPDATE projects SET timeout_seconds = ? WHERE name = ?", seconds, name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// GetProject는 이름으로 프로젝트를 조회합니다. 등록되지 않은 이름이면 (nil, nil)을 반환합니다.
+func (db *DB) GetProject(name string) (*Project, error) {
+	p, err := scanProject(db.conn.QueryRow("SELECT "+projectColumns+" FROM projects WHERE name = ?", name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	return p, nil
+}
+
+// ListProjects는 등록된 모든 프로젝트를 이름순으로 조회합니다.
+func (db *DB) ListProjects() ([]*Project, error) {
+	rows, err := db.conn.Query("SELECT " + projectColumns + " FROM projects ORDER BY name ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*Project
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
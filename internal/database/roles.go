@@ -0,0 +1,105 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Role은 역할 기반 접근 제어의 역할입니다 (v1.5)
+// 상위 역할은 하위 역할의 권한을 모두 가집니다: admin > operator > viewer.
+type Role string
+
+const (
+	RoleNone     Role = ""         // 역할 없음 (도움말만 사용 가능)
+	RoleViewer   Role = "viewer"   // 작업 목록/결과 조회
+	RoleOperator Role = "operator" // 작업 실행, 취소, 되돌리기, 이어서 실행
+	RoleAdmin    Role = "admin"    // 경로/프로젝트/채널 바인딩/역할 관리
+)
+
+// Rank는 역할의 순위입니다. 역할 비교에 사용합니다 (알 수 없는 역할은 RoleNone과 같음).
+func (r Role) Rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ParseRole은 역할 이름을 Role로 변환합니다. 알 수 없는 이름이면 false를 반환합니다.
+func ParseRole(name string) (Role, bool) {
+	switch r := Role(name); r {
+	case RoleViewer, RoleOperator, RoleAdmin:
+		return r, true
+	}
+	return RoleNone, false
+}
+
+// RoleAssignment는 Slack 사용자(U...) 또는 사용자 그룹(S...)에 부여한 역할입니다 (v1.5)
+type RoleAssignment struct {
+	Subject   string    `json:"subject"` // Slack user_id 또는 user group ID
+	Role      Role      `json:"role"`
+	GrantedBy string    `json:"granted_by,omitempty"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+// SetRole은 대상에게 역할을 부여합니다. 이미 역할이 있으면 바꿉니다.
+func (db *DB) SetRole(a *RoleAssignment) error {
+	query := `
+		INSERT INTO user_roles (subject, role, granted_by, granted_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(subject) DO UPDATE SET
+			role = excluded.role,
+			granted_by = excluded.granted_by,
+			granted_at = excluded.granted_at
+	`
+	_, err := db.conn.Exec(query, a.Subject, string(a.Role), a.GrantedBy, a.GrantedAt)
+	return err
+}
+
+// DeleteRole은 대상의 역할을 해제합니다. 역할이 없었으면 false를 반환합니다.
+func (db *DB) DeleteRole(subject string) (bool, error) {
+	res, err := db.conn.Exec("DELETE FROM user_roles WHERE subject = ?", subject)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// GetRole은 대상에게 부여된 역할을 조회합니다. 역할이 없으면 RoleNone을 반환합니다.
+func (db *DB) GetRole(subject string) (Role, error) {
+	var role string
+	err := db.conn.QueryRow("SELECT role FROM user_roles WHERE subject = ?", subject).Scan(&role)
+	if err == sql.ErrNoRows {
+		return RoleNone, nil
+	}
+	return Role(role), err
+}
+
+// ListRoles는 부여된 모든 역할을 역할, 대상 순으로 조회합니다.
+func (db *DB) ListRoles() ([]*RoleAssignment, error) {
+	rows, err := db.conn.Query("SELECT subject, role, COALESCE(granted_by, ''), granted_at FROM user_roles ORDER BY role ASC, subject ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*RoleAssignment
+	for rows.Next() {
+		a := &RoleAssignment{}
+		var role string
+		if err := rows.Scan(&a.Subject, &role, &a.GrantedBy, &a.GrantedAt); err != nil {
+			return nil, err
+		}
+		a.Role = Role(role)
+		roles = append(roles, a)
+	}
+	return roles, rows.Err()
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// 사용량 카운터 종류 (v1.5)
+const (
+	UsageUserJobs            = "user_jobs"             // 사용자별 시간당 등록한 작업 수
+	UsageChannelAgentSeconds = "channel_agent_seconds" // 채널별 하루 cursor-agent 실행 시간(초)
+)
+
+// HourPeriod는 시간 단위 사용량 카운터의 구간 키입니다 (서버 시간대 기준).
+func HourPeriod(t time.Time) string {
+	return t.Format("2006-01-02T15")
+}
+
+// DayPeriod는 일 단위 사용량 카운터의 구간 키입니다 (서버 시간대 기준).
+func DayPeriod(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// GetUsage는 구간의 사용량을 조회합니다. 기록이 없으면 0을 반환합니다.
+func (db *DB) GetUsage(scope string, subject string, period string) (int64, error) {
+	var value int64
+	err := db.conn.QueryRow("SELECT value FROM usage_counters WHERE scope = ? AND subject = ? AND period = ?",
+		scope, subject, period).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return value, err
+}
+
+// AddUsage는 구간의 사용량에 delta를 더합니다.
+func (db *DB) AddUsage(scope string, subject string, period string, delta int64) error {
+	query := `
+		INSERT INTO usage_counters (scope, subject, period, value, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(scope, subject, period) DO UPDATE SET
+			value = value + excluded.value,
+			updated_at = excluded.updated_at
+	`
+	_, err := db.conn.Exec(query, scope, subject, period, delta, time.Now())
+	return err
+}
+
+// TryAddUsage는 더한 결과가 limit 이하일 때만 구간의 사용량에 delta를 더합니다.
+// 확인과 증가를 하나의 UPDATE로 처리하므로 동시 요청이 함께 limit을 넘지 않습니다.
+// 더하지 못했으면 false와 현재 사용량을 반환합니다.
+func (db *DB) TryAddUsage(scope string, subject string, period string, delta int64, limit int64) (bool, int64, error) {
+	_, err := db.conn.Exec(`INSERT INTO usage_counters (scope, subject, period, value, updated_at) VALUES (?, ?, ?, 0, ?)
+		ON CONFLICT(scope, subject, period) DO NOTHING`, scope, subject, period, time.Now())
+	if err != nil {
+		return false, 0, err
+	}
+
+	res, err := db.conn.Exec(`UPDATE usage_counters SET value = value + ?, updated_at = ?
+		WHERE scope = ? AND subject = ? AND period = ? AND value + ? <= ?`,
+		delta, time.Now(), scope, subject, period, delta, limit)
+	if err != nil {
+		return false, 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, 0, err
+	}
+	current, err := db.GetUsage(scope, subject, period)
+	return affected == 1, current, err
+}
+
+// PruneUsage는 updated_at이 before 이전인 사용량 카운터를 삭제합니다.
+func (db *DB) PruneUsage(before time.Time) (int64, error) {
+	res, err := db.conn.Exec("DELETE FROM usage_counters WHERE updated_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// CountActiveJobs는 사용자의 끝나지 않은 작업(대기, 실행 중, 승인 대기) 수를 반환합니다.
+func (db *DB) CountActiveJobs(userID string) (int, error) {
+	var count int
+	err := db.conn.QueryRow("SELECT COUNT(*) FROM job_records WHERE user_id = ? AND status IN (?, ?, ?)",
+		userID, JobStatusPending, JobStatusRunning, JobStatusAwaitingApproval).Scan(&count)
+	return count, err
+}
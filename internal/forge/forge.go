@@ -0,0 +1,165 @@
+// Package forge는 GitHub, GitLab, Gitea REST API로 Pull Request(Merge Request)를 생성하는 클라이언트입니다.
+//
+// 모든 클라이언트는 API 기본 URL과 http.Client를 주입받으므로 로컬 HTTP 서버로 대체하여 테스트할 수 있습니다.
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
+)
+
+// 지원하는 forge 종류
+const (
+	TypeGitHub = "github"
+	TypeGitLab = "gitlab"
+	TypeGitea  = "gitea"
+)
+
+// PullRequestInput은 PR 생성 요청입니다.
+type PullRequestInput struct {
+	Repo  string // 저장소 경로 (예: owner/repo, GitLab은 group/subgroup/repo)
+	Title string
+	Body  string
+	Head  string // 변경 사항이 있는 브랜치
+	Base  string // 병합 대상 브랜치
+}
+
+// PullRequest는 생성된 PR 정보입니다.
+type PullRequest struct {
+	Number int    // PR 번호 (GitLab은 MR iid)
+	URL    string // 웹 브라우저에서 열 수 있는 URL
+}
+
+// Client는 forge별 PR 생성 클라이언트 인터페이스입니다.
+type Client interface {
+	// Name은 forge 종류를 반환합니다 (github, gitlab, gitea).
+	Name() string
+	// CreatePullRequest는 PR을 생성합니다.
+	CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error)
+}
+
+// Config는 forge 클라이언트 설정입니다.
+type Config struct {
+	Type       string       // github, gitlab, gitea
+	BaseURL    string       // API 기본 URL (GitHub/GitLab은 생략 시 공개 서비스 주소 사용)
+	Token      string       // API 토큰
+	HTTPClient *http.Client // 생략 시 30초 타임아웃 클라이언트 사용
+}
+
+// New는 설정에 맞는 forge 클라이언트를 생성합니다.
+func New(cfg Config) (Client, error) {
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("forge API 토큰이 필요합니다")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	base := strings.TrimRight(cfg.BaseURL, "/")
+	api := apiClient{token: cfg.Token, http: cfg.HTTPClient}
+
+	switch strings.ToLower(cfg.Type) {
+	case TypeGitHub:
+		if base == "" {
+			base = "https://api.github.com"
+		}
+		api.baseURL = base
+		return &githubClient{api}, nil
+	case TypeGitLab:
+		if base == "" {
+			base = "https://gitlab.com/api/v4"
+		}
+		api.baseURL = base
+		return &gitlabClient{api}, nil
+	case TypeGitea:
+		if base == "" {
+			return nil, fmt.Errorf("Gitea는 API URL이 필요합니다 (예: https://gitea.example.com/api/v1)")
+		}
+		api.baseURL = base
+		return &giteaClient{api}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 forge 종류입니다: %s (github, gitlab, gitea 중 선택)", cfg.Type)
+	}
+}
+
+// ParseRepo는 git remote URL에서 저장소 경로(owner/repo)를 추출합니다.
+//
+// 지원 형식:
+//
+//	https://github.com/owner/repo.git
+//	ssh://git@gitlab.example.com:2222/group/sub/repo.git
+//	git@github.com:owner/repo.git
+func ParseRepo(remoteURL string) (string, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	var path string
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" && u.Host != "" {
+		path = u.Path
+	} else if _, after, ok := strings.Cut(remoteURL, ":"); ok && !strings.Contains(remoteURL, "://") {
+		// scp 형식: git@host:owner/repo.git
+		path = after
+	} else {
+		return "", fmt.Errorf("remote URL을 해석할 수 없습니다: %s", remoteURL)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return "", fmt.Errorf("remote URL에서 저장소 경로를 찾을 수 없습니다: %s", remoteURL)
+	}
+	return path, nil
+}
+
+// apiClient는 forge 클라이언트들이 공유하는 JSON HTTP 호출 도우미입니다.
+type apiClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// postJSON은 body를 JSON으로 POST하고 응답을 out에 디코딩합니다.
+// 2xx가 아니면 응답 본문 일부를 포함한 에러를 반환합니다.
+func (a apiClient) postJSON(ctx context.Context, path string, headers map[string]string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("forge API 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(respBody))
+		if len(msg) > 500 {
+			msg = textutil.Truncate(msg, 500) + "..."
+		}
+		return fmt.Errorf("forge API 오류 (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("forge API 응답 파싱 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordedRequest는 가짜 forge 서버가 받은 요청입니다.
+type recordedRequest struct {
+	Method string
+	Path   string // 인코딩된 경로 (GitLab 프로젝트 경로의 %2F 확인용)
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// newForgeServer는 요청을 기록하고 status와 response를 돌려주는 가짜 forge API 서버를 시작합니다.
+func newForgeServer(t *testing.T, status int, response string) (*httptest.Server, *recordedRequest) {
+	t.Helper()
+	got := &recordedRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Method = r.Method
+		got.Path = r.URL.EscapedPath()
+		got.Header = r.Header.Clone()
+		if err := json.NewDecoder(r.Body).Decode(&got.Body); err != nil {
+			t.Errorf("요청 본문이 JSON이 아닙니다: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+var testInput = PullRequestInput{
+	Repo:  "acme/widgets",
+	Title: "로그인 버그 수정",
+	Body:  "cursor 작업 결과",
+	Head:  "cursor/job-1234abcd",
+	Base:  "main",
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		forgeType  string
+		repo       string
+		response   string
+		wantPath   string
+		wantHeader map[string]string
+		wantBody   map[string]interface{}
+		want       PullRequest
+	}{
+		{
+			name:      "github",
+			forgeType: TypeGitHub,
+			repo:      "acme/widgets",
+			response:  `{"number": 42, "html_url": "https://github.example/acme/widgets/pull/42"}`,
+			wantPath:  "/repos/acme/widgets/pulls",
+			wantHeader: map[string]string{
+				"Authorization":        "Bearer secret-token",
+				"Accept":               "application/vnd.github+json",
+				"X-GitHub-Api-Version": "2022-11-28",
+				"Content-Type":         "application/json",
+			},
+			wantBody: map[string]interface{}{
+				"title": testInput.Title, "body": testInput.Body, "head": testInput.Head, "base": testInput.Base,
+			},
+			want: PullRequest{Number: 42, URL: "https://github.example/acme/widgets/pull/42"},
+		},
+		{
+			name:      "gitlab",
+			forgeType: TypeGitLab,
+			repo:      "group/sub/widgets",
+			response:  `{"iid": 7, "web_url": "https://gitlab.example/group/sub/widgets/-/merge_requests/7"}`,
+			wantPath:  "/projects/group%2Fsub%2Fwidgets/merge_requests",
+			wantHeader: map[string]string{
+				"PRIVATE-TOKEN": "secret-token",
+				"Content-Type":  "application/json",
+			},
+			wantBody: map[string]interface{}{
+				"title": testInput.Title, "description": testInput.Body,
+				"source_branch": testInput.Head, "target_branch": testInput.Base, "remove_source_branch": true,
+			},
+			want: PullRequest{Number: 7, URL: "https://gitlab.example/group/sub/widgets/-/merge_requests/7"},
+		},
+		{
+			name:      "gitea",
+			forgeType: TypeGitea,
+			repo:      "acme/widgets",
+			response:  `{"number": 3, "html_url": "https://gitea.example/acme/widgets/pulls/3"}`,
+			wantPath:  "/repos/acme/widgets/pulls",
+			wantHeader: map[string]string{
+				"Authorization": "token secret-token",
+				"Content-Type":  "application/json",
+			},
+			wantBody: map[string]interface{}{
+				"title": testInput.Title, "body": testInput.Body, "head": testInput.Head, "base": testInput.Base,
+			},
+			want: PullRequest{Number: 3, URL: "https://gitea.example/acme/widgets/pulls/3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := newForgeServer(t, http.StatusCreated, tt.response)
+			client, err := New(Config{Type: tt.forgeType, BaseURL: srv.URL + "/", Token: "secret-token", HTTPClient: srv.Client()})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if client.Name() != tt.forgeType {
+				t.Errorf("Name() = %q, want %q", client.Name(), tt.forgeType)
+			}
+
+			in := testInput
+			in.Repo = tt.repo
+			pr, err := client.CreatePullRequest(context.Background(), in)
+			if err != nil {
+				t.Fatalf("CreatePullRequest: %v", err)
+			}
+			if *pr != tt.want {
+				t.Errorf("PullRequest = %+v, want %+v", *pr, tt.want)
+			}
+
+			if got.Method != http.MethodPost {
+				t.Errorf("method = %s, want POST", got.Method)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("path = %s, want %s", got.Path, tt.wantPath)
+			}
+			for k, v := range tt.wantHeader {
+				if h := got.Header.Get(k); h != v {
+					t.Errorf("header %s = %q, want %q", k, h, v)
+				}
+			}
+			if len(got.Body) != len(tt.wantBody) {
+				t.Errorf("body = %v, want %v", got.Body, tt.wantBody)
+			}
+			for k, v := range tt.wantBody {
+				if got.Body[k] != v {
+					t.Errorf("body[%s] = %v, want %v", k, got.Body[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestCreatePullRequestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  []string
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"message": "Bad credentials"}`, []string{"HTTP 401", "Bad credentials"}},
+		{"already exists", http.StatusUnprocessableEntity, `{"message": "A pull request already exists"}`, []string{"HTTP 422", "already exists"}},
+		{"server error", http.StatusInternalServerError, strings.Repeat("x", 600), []string{"HTTP 500", strings.Repeat("x", 500) + "..."}},
+	}
+
+	for _, forgeType := range []string{TypeGitHub, TypeGitLab, TypeGitea} {
+		for _, tt := range tests {
+			t.Run(forgeType+"/"+tt.name, func(t *testing.T) {
+				srv, _ := newForgeServer(t, tt.status, tt.response)
+				client, err := New(Config{Type: forgeType, BaseURL: srv.URL, Token: "secret-token", HTTPClient: srv.Client()})
+				if err != nil {
+					t.Fatalf("New: %v", err)
+				}
+				pr, err := client.CreatePullRequest(context.Background(), testInput)
+				if err == nil {
+					t.Fatalf("CreatePullRequest = %+v, want error", pr)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not contain %q", err.Error(), want)
+					}
+				}
+				if strings.Contains(err.Error(), strings.Repeat("x", 501)) {
+					t.Errorf("error body was not truncated: %d bytes", len(err.Error()))
+				}
+			})
+		}
+	}
+}
+
+func TestCreatePullRequestInvalidJSON(t *testing.T) {
+	srv, _ := newForgeServer(t, http.StatusCreated, `not json`)
+	client, err := New(Config{Type: TypeGitHub, BaseURL: srv.URL, Token: "secret-token", HTTPClient: srv.Client()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := client.CreatePullRequest(context.Background(), testInput); err == nil || !strings.Contains(err.Error(), "응답 파싱 실패") {
+		t.Errorf("error = %v, want 응답 파싱 실패", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"github default url", Config{Type: "GitHub", Token: "t"}, false},
+		{"gitlab default url", Config{Type: TypeGitLab, Token: "t"}, false},
+		{"gitea requires url", Config{Type: TypeGitea, Token: "t"}, true},
+		{"missing token", Config{Type: TypeGitHub}, true},
+		{"unknown type", Config{Type: "bitbucket", Token: "t"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"https://github.com/owner/repo.git", "owner/repo", false},
+		{"ssh://git@gitlab.example.com:2222/group/sub/repo.git", "group/sub/repo", false},
+		{"git@github.com:owner/repo.git", "owner/repo", false},
+		{"https://github.com/owner", "", true},
+		{"not a url", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRepo(tt.url)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseRepo(%q) = %q, %v; want %q, wantErr %v", tt.url, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// giteaClient는 Gitea(Forgejo 포함) REST API 클라이언트입니다.
+// https://gitea.com/api/swagger#/repository/repoCreatePullRequest
+type giteaClient struct {
+	api apiClient
+}
+
+func (c *giteaClient) Name() string { return TypeGitea }
+
+func (c *giteaClient) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	body := map[string]string{
+		"title": in.Title,
+		"body":  in.Body,
+		"head":  in.Head,
+		"base":  in.Base,
+	}
+	headers := map[string]string{
+		"Authorization": "token " + c.api.token,
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.api.postJSON(ctx, fmt.Sprintf("/repos/%s/pulls", in.Repo), headers, body, &resp); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: resp.Number, URL: resp.HTMLURL}, nil
+}
@@ -0,0 +1,37 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// githubClient는 GitHub REST API 클라이언트입니다.
+// https://docs.github.com/rest/pulls/pulls#create-a-pull-request
+type githubClient struct {
+	api apiClient
+}
+
+func (c *githubClient) Name() string { return TypeGitHub }
+
+func (c *githubClient) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	body := map[string]string{
+		"title": in.Title,
+		"body":  in.Body,
+		"head":  in.Head,
+		"base":  in.Base,
+	}
+	headers := map[string]string{
+		"Authorization":        "Bearer " + c.api.token,
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.api.postJSON(ctx, fmt.Sprintf("/repos/%s/pulls", in.Repo), headers, body, &resp); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: resp.Number, URL: resp.HTMLURL}, nil
+}
@@ -0,0 +1,38 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// gitlabClient는 GitLab REST API 클라이언트입니다 (Merge Request).
+// https://docs.gitlab.com/ee/api/merge_requests.html#create-mr
+type gitlabClient struct {
+	api apiClient
+}
+
+func (c *gitlabClient) Name() string { return TypeGitLab }
+
+func (c *gitlabClient) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	body := map[string]interface{}{
+		"title":                in.Title,
+		"description":          in.Body,
+		"source_branch":        in.Head,
+		"target_branch":        in.Base,
+		"remove_source_branch": true,
+	}
+	headers := map[string]string{
+		"PRIVATE-TOKEN": c.api.token,
+	}
+
+	var resp struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}
+	path := fmt.Sprintf("/projects/%s/merge_requests", url.PathEscape(in.Repo))
+	if err := c.api.postJSON(ctx, path, headers, body, &resp); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: resp.IID, URL: resp.WebURL}, nil
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CommitChanges는 base 커밋 위에 snapshot 커밋의 files 변경만 반영한 새 커밋을 만듭니다.
+//
+// 작업 전부터 있던 다른 미커밋 변경 사항은 포함하지 않고, 작업이 변경한 파일만 파일 단위로 커밋합니다.
+// 임시 인덱스를 사용하므로 실제 인덱스, 브랜치, 작업 디렉토리는 변경되지 않습니다.
+// 반환된 커밋은 어떤 브랜치에도 연결되지 않으므로 Push 또는 UpdateRef로 보존해야 합니다.
+func CommitChanges(dir string, base string, snapshot string, files []FileChange, message string) (string, error) {
+	tmpIndex, err := os.CreateTemp("", "cursor-commit-index-*")
+	if err != nil {
+		return "", fmt.Errorf("임시 인덱스 생성 실패: %w", err)
+	}
+	tmpIndexPath := tmpIndex.Name()
+	tmpIndex.Close()
+	os.Remove(tmpIndexPath)
+	defer os.Remove(tmpIndexPath)
+
+	env := []string{"GIT_INDEX_FILE=" + tmpIndexPath}
+	if _, err := run(dir, env, "read-tree", base); err != nil {
+		return "", err
+	}
+
+	for _, f := range files {
+		if f.OldPath != "" {
+			if _, err := run(dir, env, "update-index", "--force-remove", "--", f.OldPath); err != nil {
+				return "", err
+			}
+		}
+		if f.Status == "D" {
+			if _, err := run(dir, env, "update-index", "--force-remove", "--", f.Path); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		// 스냅샷 트리에서 파일 모드와 blob을 가져와 그대로 반영
+		out, err := run(dir, nil, "ls-tree", "-z", snapshot, "--", f.Path)
+		if err != nil {
+			return "", err
+		}
+		meta, _, ok := strings.Cut(strings.TrimRight(out, "\x00"), "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 {
+			return "", fmt.Errorf("스냅샷에서 파일을 찾을 수 없습니다: %s", f.Path)
+		}
+		cacheInfo := fmt.Sprintf("%s,%s,%s", fields[0], fields[2], f.Path)
+		if _, err := run(dir, env, "update-index", "--add", "--cacheinfo", cacheInfo); err != nil {
+			return "", err
+		}
+	}
+
+	tree, err := run(dir, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := run(dir, commitIdentity(dir), "commit-tree", strings.TrimSpace(tree), "-p", base, "-m", message)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// commitIdentity는 저장소에 작성자 정보가 설정되어 있으면 그대로 사용하고,
+// 없으면 스냅샷과 같은 고정 작성자 정보를 반환합니다.
+func commitIdentity(dir string) []string {
+	if _, err := run(dir, nil, "var", "GIT_AUTHOR_IDENT"); err == nil {
+		return nil
+	}
+	return snapshotIdentity
+}
+
+// Push는 커밋을 원격 저장소의 브랜치로 push합니다.
+// 로컬 브랜치를 만들지 않고 `<commit>:refs/heads/<branch>` refspec으로 직접 push합니다.
+func Push(dir string, remote string, commit string, branch string) error {
+	_, err := run(dir, nil, "push", remote, fmt.Sprintf("%s:refs/heads/%s", commit, branch))
+	return err
+}
+
+// RemoteURL은 원격 저장소의 URL을 반환합니다.
+func RemoteURL(dir string, remote string) (string, error) {
+	out, err := run(dir, nil, "remote", "get-url", remote)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CurrentBranch는 dir에서 체크아웃된 브랜치 이름을 반환합니다. detached HEAD면 에러를 반환합니다.
+func CurrentBranch(dir string) (string, error) {
+	out, err := run(dir, nil, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("현재 브랜치를 확인할 수 없습니다 (detached HEAD): %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
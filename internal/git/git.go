@@ -0,0 +1,77 @@
+// Package git은 작업 격리와 변경 사항 추적에 필요한 git 명령을 감싼 유틸리티입니다.
+// go-git 등의 라이브러리 대신 시스템의 git 바이너리를 사용합니다.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// run은 dir에서 git 명령을 실행하고 stdout을 반환합니다.
+// env가 주어지면 현재 환경 변수에 추가됩니다. 실패하면 stderr를 에러 메시지에 포함합니다.
+func run(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), fmt.Errorf("git %s 실패: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// TopLevel은 dir이 속한 저장소의 최상위 경로를 반환합니다.
+// git 저장소가 아니면 에러를 반환합니다.
+func TopLevel(dir string) (string, error) {
+	out, err := run(dir, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("git 저장소가 아닙니다: %s", dir)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// AddWorktree는 repoDir의 HEAD에서 branch를 만들고 path에 worktree로 체크아웃합니다.
+// 같은 이름의 브랜치가 이미 있으면 HEAD로 재설정합니다 (재시도된 작업).
+func AddWorktree(repoDir string, path string, branch string) error {
+	_, err := run(repoDir, nil, "worktree", "add", "-B", branch, path, "HEAD")
+	return err
+}
+
+// RemoveWorktree는 worktree를 삭제합니다. 커밋되지 않은 변경 사항도 함께 삭제됩니다.
+func RemoveWorktree(repoDir string, path string) error {
+	if _, err := run(repoDir, nil, "worktree", "remove", "--force", path); err != nil {
+		return err
+	}
+	_, err := run(repoDir, nil, "worktree", "prune")
+	return err
+}
+
+// PruneWorktrees는 디렉토리가 사라진 worktree 등록 정보를 정리합니다.
+func PruneWorktrees(repoDir string) error {
+	_, err := run(repoDir, nil, "worktree", "prune")
+	return err
+}
+
+// DeleteBranch는 로컬 브랜치를 강제로 삭제합니다.
+func DeleteBranch(repoDir string, branch string) error {
+	_, err := run(repoDir, nil, "branch", "-D", branch)
+	return err
+}
+
+// IsClean은 작업 디렉토리에 커밋되지 않은 변경 사항(추적되지 않은 파일 포함)이 없는지 확인합니다.
+func IsClean(dir string) (bool, error) {
+	out, err := run(dir, nil, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) == "", nil
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"strings"
+)
+
+// RestorePaths는 작업 디렉토리의 paths를 source 커밋의 내용으로 되돌립니다.
+// 인덱스는 변경하지 않습니다 (`git restore --worktree`, git 2.23 이상 필요).
+func RestorePaths(dir string, source string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	args := append([]string{"restore", "--source=" + source, "--worktree", "--"}, paths...)
+	_, err := run(dir, nil, args...)
+	return err
+}
+
+// ChangedPaths는 from과 to 사이에서 paths 중 내용이 다른 파일 목록을 반환합니다.
+func ChangedPaths(dir string, from string, to string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"diff", "--name-only", "-z", "--no-renames", from, to, "--"}, paths...)
+	out, err := run(dir, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" {
+			changed = append(changed, p)
+		}
+	}
+	return changed, nil
+}
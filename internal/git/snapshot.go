@@ -0,0 +1,155 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotIdentity는 스냅샷 커밋의 작성자 정보입니다.
+// 저장소에 user.name/user.email이 설정되지 않은 환경에서도 commit-tree가 실패하지 않도록 고정값을 사용합니다.
+var snapshotIdentity = []string{
+	"GIT_AUTHOR_NAME=cursor-slack-server",
+	"GIT_AUTHOR_EMAIL=cursor-slack-server@localhost",
+	"GIT_COMMITTER_NAME=cursor-slack-server",
+	"GIT_COMMITTER_EMAIL=cursor-slack-server@localhost",
+}
+
+// FileChange는 두 커밋 사이에서 변경된 파일입니다.
+type FileChange struct {
+	Status  string // A(추가), M(수정), D(삭제), R(이름 변경), T(타입 변경)
+	Path    string
+	OldPath string // 이름 변경 시 이전 경로
+}
+
+// HeadCommit은 dir의 HEAD 커밋 해시를 반환합니다. 커밋이 없는 저장소면 빈 문자열을 반환합니다.
+func HeadCommit(dir string) (string, error) {
+	out, err := run(dir, nil, "rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		if strings.TrimSpace(out) == "" {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Snapshot은 작업 디렉토리의 현재 상태(추적되지 않은 파일 포함, .gitignore 제외)를 커밋 객체로 저장합니다.
+//
+// 임시 인덱스 파일(GIT_INDEX_FILE)을 사용하므로 실제 인덱스, 브랜치, 작업 디렉토리는 변경되지 않습니다.
+// 반환된 커밋은 어떤 ref에도 연결되지 않으므로, 보존하려면 UpdateRef로 ref를 만들어야 합니다.
+func Snapshot(dir string, message string) (string, error) {
+	head, err := HeadCommit(dir)
+	if err != nil {
+		return "", err
+	}
+
+	tmpIndex, err := os.CreateTemp("", "cursor-snapshot-index-*")
+	if err != nil {
+		return "", fmt.Errorf("임시 인덱스 생성 실패: %w", err)
+	}
+	tmpIndexPath := tmpIndex.Name()
+	tmpIndex.Close()
+	defer os.Remove(tmpIndexPath)
+
+	// 실제 인덱스를 복사하면 stat 캐시를 재사용하여 큰 저장소에서도 빠르게 스냅샷할 수 있습니다.
+	env := []string{"GIT_INDEX_FILE=" + tmpIndexPath}
+	if err := copyIndex(dir, tmpIndexPath); err != nil {
+		os.Remove(tmpIndexPath)
+		if head != "" {
+			if _, err := run(dir, env, "read-tree", head); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if _, err := run(dir, env, "add", "-A"); err != nil {
+		return "", err
+	}
+	tree, err := run(dir, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"commit-tree", strings.TrimSpace(tree), "-m", message}
+	if head != "" {
+		args = append(args, "-p", head)
+	}
+	commit, err := run(dir, snapshotIdentity, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// copyIndex는 dir의 실제 인덱스 파일을 dst로 복사합니다.
+func copyIndex(dir string, dst string) error {
+	out, err := run(dir, nil, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return err
+	}
+	src := strings.TrimSpace(out)
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(dir, src)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	outFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, in)
+	return err
+}
+
+// UpdateRef는 ref가 commit을 가리키도록 생성하거나 갱신합니다.
+func UpdateRef(dir string, ref string, commit string) error {
+	_, err := run(dir, nil, "update-ref", ref, commit)
+	return err
+}
+
+// Diff는 두 커밋 사이의 unified diff를 반환합니다.
+func Diff(dir string, from string, to string) (string, error) {
+	return run(dir, nil, "diff", "--no-color", "--no-ext-diff", "--find-renames", from, to)
+}
+
+// ChangedFiles는 두 커밋 사이에서 변경된 파일 목록을 반환합니다.
+func ChangedFiles(dir string, from string, to string) ([]FileChange, error) {
+	out, err := run(dir, nil, "diff", "--name-status", "-z", "--find-renames", from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// -z 출력: <status>\0<path>\0 (이름 변경은 <status>\0<old>\0<new>\0)
+	fields := strings.Split(strings.TrimRight(out, "\x00"), "\x00")
+	var changes []FileChange
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" || i+1 >= len(fields) {
+			break
+		}
+		change := FileChange{Status: status[:1]}
+		if change.Status == "R" || change.Status == "C" {
+			if i+2 >= len(fields) {
+				break
+			}
+			change.OldPath = fields[i+1]
+			change.Path = fields[i+2]
+			i += 2
+		} else {
+			change.Path = fields[i+1]
+			i++
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
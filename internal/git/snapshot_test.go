@@ -0,0 +1,125 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newTestRepo는 files를 커밋한 임시 git 저장소를 만듭니다.
+func newTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	repo := t.TempDir()
+	writeFiles(t, repo, files)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v 실패: %v\n%s", args, err, out)
+		}
+	}
+	return repo
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSnapshotChangedFilesAndRestore(t *testing.T) {
+	repo := newTestRepo(t, map[string]string{
+		"sub/keep.txt": "keep\n",
+		"sub/old.txt":  "old\n",
+		"sub/move.txt": "이름을 바꿀 파일입니다\n",
+	})
+	dir := filepath.Join(repo, "sub") // 하위 디렉토리에서 실행해도 경로는 저장소 최상위 기준
+
+	before, err := Snapshot(dir, "before")
+	if err != nil {
+		t.Fatalf("Snapshot(before): %v", err)
+	}
+	writeFiles(t, dir, map[string]string{"keep.txt": "changed\n", "new.txt": "new\n"})
+	if err := os.Remove(filepath.Join(dir, "old.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(dir, "move.txt"), filepath.Join(dir, "moved.txt")); err != nil {
+		t.Fatal(err)
+	}
+	after, err := Snapshot(dir, "after")
+	if err != nil {
+		t.Fatalf("Snapshot(after): %v", err)
+	}
+
+	// 스냅샷은 실제 인덱스를 건드리지 않음
+	if out, err := run(repo, nil, "diff", "--cached", "--name-only"); err != nil || out != "" {
+		t.Errorf("스냅샷 후 인덱스 변경 = %q, %v; want 없음", out, err)
+	}
+
+	changes, err := ChangedFiles(dir, before, after)
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	want := []FileChange{
+		{Status: "M", Path: "sub/keep.txt"},
+		{Status: "R", Path: "sub/moved.txt", OldPath: "sub/move.txt"},
+		{Status: "A", Path: "sub/new.txt"},
+		{Status: "D", Path: "sub/old.txt"},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("ChangedFiles = %+v, want %+v", changes, want)
+	}
+
+	paths := []string{"sub/keep.txt", "sub/old.txt", "sub/move.txt", "sub/moved.txt", "sub/new.txt"}
+	changed, err := ChangedPaths(repo, before, after, paths)
+	if err != nil {
+		t.Fatalf("ChangedPaths: %v", err)
+	}
+	if len(changed) != len(paths) {
+		t.Errorf("ChangedPaths = %v, want %v", changed, paths)
+	}
+	if changed, err := ChangedPaths(repo, after, after, paths); err != nil || len(changed) != 0 {
+		t.Errorf("ChangedPaths(after, after) = %v, %v; want 없음", changed, err)
+	}
+
+	if err := RestorePaths(repo, before, []string{"sub/keep.txt", "sub/old.txt", "sub/move.txt"}); err != nil {
+		t.Fatalf("RestorePaths: %v", err)
+	}
+	for name, content := range map[string]string{"keep.txt": "keep\n", "old.txt": "old\n", "move.txt": "이름을 바꿀 파일입니다\n"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, content)
+		}
+	}
+}
+
+func TestSnapshotWithoutCommit(t *testing.T) {
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Skipf("git init 실패: %v\n%s", err, out)
+	}
+	writeFiles(t, repo, map[string]string{"a.txt": "a\n"})
+
+	if head, err := HeadCommit(repo); err != nil || head != "" {
+		t.Errorf("HeadCommit = %q, %v; want 빈 문자열", head, err)
+	}
+	commit, err := Snapshot(repo, "snapshot")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if out, err := run(repo, nil, "show", commit+":a.txt"); err != nil || out != "a\n" {
+		t.Errorf("스냅샷의 a.txt = %q, %v; want %q", out, err, "a\n")
+	}
+}
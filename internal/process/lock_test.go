@@ -0,0 +1,29 @@
+package process
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db.lock")
+
+	unlock, err := LockFile(path)
+	if err != nil {
+		t.Fatalf("LockFile: %v", err)
+	}
+	// A second server on the same DB must be refused while the lock is held
+	if _, err := LockFile(path); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second LockFile error = %v, want ErrLocked", err)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("unlock: %v", err)
+	}
+	unlock, err = LockFile(path)
+	if err != nil {
+		t.Fatalf("LockFile after unlock: %v", err)
+	}
+	unlock()
+}
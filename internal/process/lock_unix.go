@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// ErrLocked is returned by LockFile when another process holds the lock
+var ErrLocked = errors.New("lock is held by another process")
+
+// LockFile takes an exclusive, non-blocking lock on path (created if missing)
+// v1.5: the lock is released by the returned function or automatically when the process exits,
+// so a crashed server never leaves a stale lock behind
+func LockFile(path string) (func() error, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%s: %w", path, ErrLocked)
+		}
+		return nil, err
+	}
+	// Record the holder's PID to help diagnose a refused start
+	f.Truncate(0)
+	fmt.Fprintf(f, "%d\n", os.Getpid())
+	return f.Close, nil
+}
@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package process
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// errorSharingViolation is ERROR_SHARING_VIOLATION (not exported by the syscall package)
+const errorSharingViolation syscall.Errno = 32
+
+// ErrLocked is returned by LockFile when another process holds the lock
+var ErrLocked = errors.New("lock is held by another process")
+
+// LockFile takes an exclusive lock on path (created if missing)
+// v1.5: Windows has no flock, so the file is opened without sharing; the handle (and the lock)
+// is released by the returned function or automatically when the process exits
+func LockFile(path string) (func() error, error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	h, err := syscall.CreateFile(name, syscall.GENERIC_READ|syscall.GENERIC_WRITE, 0, nil, syscall.OPEN_ALWAYS, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		if errors.Is(err, errorSharingViolation) {
+			return nil, fmt.Errorf("%s: %w", path, ErrLocked)
+		}
+		return nil, err
+	}
+	return func() error { return syscall.CloseHandle(h) }, nil
+}
@@ -4,10 +4,15 @@
 package process
 
 import (
+	"errors"
 	"os/exec"
 	"syscall"
+	"time"
 )
 
+// groupPollInterval is how often KillProcessGroup checks whether the group has exited after SIGTERM
+const groupPollInterval = 100 * time.Millisecond
+
 // SetupProcessGroup configures the process to be in its own process group
 // This allows killing the entire process tree on timeout
 func SetupProcessGroup(cmd *exec.Cmd) {
@@ -18,12 +23,34 @@ func SetupProcessGroup(cmd *exec.Cmd) {
 
 // KillProcessGroup kills the entire process group
 // On Unix, we use negative PID to target the process group
-func KillProcessGroup(cmd *exec.Cmd) error {
+// v1.5: SIGTERM is sent first so the agent can clean up; if any process in the group
+// is still alive after grace, the group is killed with SIGKILL (grace <= 0 kills immediately)
+func KillProcessGroup(cmd *exec.Cmd, grace time.Duration) error {
 	if cmd.Process == nil {
 		return nil
 	}
-	
+	pgid := -cmd.Process.Pid
+
+	if grace > 0 {
+		if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+			if errors.Is(err, syscall.ESRCH) {
+				return nil // already exited
+			}
+			return err
+		}
+		// Signal 0 only checks whether any process in the group still exists
+		deadline := time.Now().Add(grace)
+		for time.Now().Before(deadline) {
+			time.Sleep(groupPollInterval)
+			if err := syscall.Kill(pgid, 0); errors.Is(err, syscall.ESRCH) {
+				return nil
+			}
+		}
+	}
+
 	// Kill the process group (negative PID)
-	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	if err := syscall.Kill(pgid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
 }
-
@@ -6,6 +6,7 @@ package process
 import (
 	"os/exec"
 	"syscall"
+	"time"
 )
 
 // SetupProcessGroup configures the process for Windows
@@ -21,12 +22,14 @@ func SetupProcessGroup(cmd *exec.Cmd) {
 // KillProcessGroup kills the process on Windows
 // Windows doesn't have process groups like Unix, so we just kill the main process
 // Note: Child processes may not be killed automatically
-func KillProcessGroup(cmd *exec.Cmd) error {
+// v1.5: Windows has no SIGTERM equivalent for console processes, so grace (JOB_KILL_GRACE) is ignored
+// and the process is terminated immediately without a chance to clean up
+func KillProcessGroup(cmd *exec.Cmd, grace time.Duration) error {
 	if cmd.Process == nil {
 		return nil
 	}
 	
-	// On Windows, cmd.Process.Kill() sends SIGTERM
+	// On Windows, cmd.Process.Kill() calls TerminateProcess (no graceful shutdown)
 	return cmd.Process.Kill()
 }
 
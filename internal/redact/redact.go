@@ -0,0 +1,215 @@
+// Package redact는 작업 출력, 프롬프트, 로그에서 비밀 값과 자격 증명을 가리는 유틸리티입니다 (v1.5)
+//
+// 잘 알려진 키 형식(AWS, GitHub, Slack, 개인 키 등), `PASSWORD=...` 같은 할당문,
+// 엔트로피가 높은 문자열과 설정 파일의 정규식에 일치하는 부분을 `[REDACTED:<종류>]`로 바꿉니다.
+package redact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// CustomRule은 REDACT_PATTERNS_FILE로 추가한 정규식의 종류 이름입니다.
+const CustomRule = "custom"
+
+// 엔트로피 검사 기준
+// 16진수 문자열(커밋 해시, UUID)은 엔트로피가 최대 4이므로 가리지 않습니다.
+const (
+	minEntropyLength = 32
+	minEntropy       = 4.3
+)
+
+// rule은 가릴 값을 찾는 정규식입니다.
+// 정규식에 `secret` 이름 그룹이 있으면 그 그룹을, 이름 없는 그룹만 있으면 첫 번째 그룹을, 그룹이 없으면 일치한 전체를 가립니다.
+type rule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// builtinRules는 기본 탐지 규칙입니다. 구체적인 형식을 먼저 적용합니다.
+var builtinRules = []rule{
+	{"private_key", regexp.MustCompile(`-----BEGIN[A-Z0-9 ]*PRIVATE KEY-----[\s\S]*?(?:-----END[A-Z0-9 ]*PRIVATE KEY-----|$)`)},
+	{"aws_access_key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github_token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"gitlab_token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"slack_token", regexp.MustCompile(`\bxox[abposre]-[A-Za-z0-9-]{10,}`)},
+	{"slack_webhook", regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/_-]+`)},
+	{"google_api_key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"stripe_key", regexp.MustCompile(`\b[rs]k_(?:live|test)_[0-9A-Za-z]{16,}\b`)},
+	{"api_key", regexp.MustCompile(`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`)},
+	{"server_api_key", regexp.MustCompile(`\bcsk_[0-9a-f]{32,}\b`)}, // 이 서버가 발급한 /api 키
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{"auth_header", regexp.MustCompile(`(?i)\b(?:authorization|x-api-key)["']?\s*[:=]\s*["']?(?:(?:bearer|basic|token)\s+)?(?P<secret>[^\s"',;\[]{8,})`)},
+	{"url_credentials", regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:(?P<secret>[^/\s@\[]+)@`)},
+	// 비밀 값 할당문 (값만 가림): .env/셸 형식(대문자 이름), 따옴표로 감싼 값, 한 줄짜리 YAML/INI 값
+	// `Token: token,`처럼 변수를 넘기는 코드는 가리지 않도록 따옴표 없는 소문자 할당은 줄 전체가 할당문일 때만 가립니다.
+	{"secret_assignment", regexp.MustCompile(`\b[A-Z0-9_]*(?:SECRET|PASSW(?:OR)?D|PWD|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIALS?)[A-Z0-9_]*\s*=\s*["']?(?P<secret>[^\s"'#\[]{6,})`)},
+	{"secret_assignment", regexp.MustCompile(`(?i)\b[a-z0-9_.-]*(?:secret|passw(?:or)?d|token|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)["']?\s*[:=]\s*["'](?P<secret>[^"'\s\[]{6,})["']`)},
+	{"secret_assignment", regexp.MustCompile(`(?im)^[+-]?\s*[a-z0-9_.-]*(?:secret|passw(?:or)?d|token|api[_-]?key|access[_-]?key|private[_-]?key)[a-z0-9_]*\s*[:=]\s*(?P<secret>[^\s"'#,()\[]{6,})\s*$`)},
+}
+
+// entropyCandidate는 엔트로피 검사를 할 토큰입니다 (base64/hex/URL-safe 문자).
+var entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/=_-]{` + fmt.Sprint(minEntropyLength) + `,}`)
+
+// Redactor는 문자열에서 비밀 값을 찾아 가립니다 (v1.5)
+// nil Redactor는 아무것도 가리지 않습니다.
+type Redactor struct {
+	HighEntropy bool // 엔트로피가 높은 문자열을 가림 (REDACT_HIGH_ENTROPY, 기본값: on)
+
+	rules []rule
+}
+
+// New는 기본 탐지 규칙에 custom 정규식을 더한 Redactor를 생성합니다.
+func New(custom []*regexp.Regexp) *Redactor {
+	r := &Redactor{HighEntropy: true, rules: append([]rule(nil), builtinRules...)}
+	for _, re := range custom {
+		r.rules = append(r.rules, rule{name: CustomRule, re: re})
+	}
+	return r
+}
+
+// LoadPatterns는 한 줄에 정규식 하나씩 적은 파일을 읽습니다.
+// 빈 줄과 `#`으로 시작하는 줄은 무시합니다.
+func LoadPatterns(path string) ([]*regexp.Regexp, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []*regexp.Regexp
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := regexp.Compile(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: 잘못된 정규식: %w", path, lineNo, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, scanner.Err()
+}
+
+// Redact는 s에서 비밀 값을 가린 문자열과 가린 개수를 반환합니다.
+func (r *Redactor) Redact(s string) (string, int) {
+	if r == nil || s == "" {
+		return s, 0
+	}
+	count := 0
+	for _, rl := range r.rules {
+		var n int
+		s, n = rl.apply(s)
+		count += n
+	}
+	if r.HighEntropy {
+		var n int
+		s, n = redactHighEntropy(s)
+		count += n
+	}
+	return s, count
+}
+
+// String은 s에서 비밀 값을 가린 문자열을 반환합니다 (개수가 필요 없는 로그/메시지용).
+func (r *Redactor) String(s string) string {
+	s, _ = r.Redact(s)
+	return s
+}
+
+// marker는 가린 값 대신 표시하는 문자열입니다.
+func marker(name string) string {
+	return "[REDACTED:" + name + "]"
+}
+
+// apply는 규칙에 일치하는 부분을 모두 가립니다.
+func (rl rule) apply(s string) (string, int) {
+	matches := rl.re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+
+	group := 0
+	if idx := rl.re.SubexpIndex("secret"); idx > 0 {
+		group = idx
+	} else if rl.re.NumSubexp() > 0 {
+		group = 1
+	}
+
+	var b strings.Builder
+	last, count := 0, 0
+	for _, m := range matches {
+		start, end := m[2*group], m[2*group+1]
+		if start < 0 || start == end {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(marker(rl.name))
+		last = end
+		count++
+	}
+	b.WriteString(s[last:])
+	return b.String(), count
+}
+
+// redactHighEntropy는 엔트로피가 높은 긴 토큰을 가립니다.
+// 문자만 있거나 숫자만 있는 토큰(긴 식별자, 숫자열)은 제외합니다.
+func redactHighEntropy(s string) (string, int) {
+	count := 0
+	s = entropyCandidate.ReplaceAllStringFunc(s, func(token string) string {
+		if !strings.ContainsAny(token, "0123456789") || !strings.ContainsFunc(token, isLetter) {
+			return token
+		}
+		if shannonEntropy(token) < minEntropy {
+			return token
+		}
+		count++
+		return marker("high_entropy")
+	})
+	return s, count
+}
+
+func isLetter(c rune) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// shannonEntropy는 문자당 섀넌 엔트로피(비트)를 계산합니다.
+func shannonEntropy(s string) float64 {
+	freq := make(map[rune]int)
+	for _, c := range s {
+		freq[c]++
+	}
+	n := float64(len(s))
+	entropy := 0.0
+	for _, count := range freq {
+		p := float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// writer는 쓰기 전에 비밀 값을 가리는 io.Writer입니다.
+type writer struct {
+	w io.Writer
+	r *Redactor
+}
+
+// NewWriter는 w에 쓰기 전에 비밀 값을 가리는 io.Writer를 반환합니다.
+// log 패키지는 로그 한 줄을 한 번의 Write로 기록하므로 log.SetOutput에 사용할 수 있습니다.
+func NewWriter(w io.Writer, r *Redactor) io.Writer {
+	return &writer{w: w, r: r}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	redacted := w.r.String(string(p))
+	if _, err := io.WriteString(w.w, redacted); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// adminUsage는 `/cursor admin` 사용법입니다.
+const adminUsage = "❌ 사용법: `/cursor admin roles` | `/cursor admin grant <@사용자|@그룹> admin|operator|viewer` | " +
+	"`/cursor admin revoke <@사용자|@그룹>` | `/cursor admin allow <@프로젝트> <@사용자|@그룹>...|none`"
+
+// handleAdminCommand는 `/cursor admin roles|grant|revoke|allow` 명령어를 처리합니다 (v1.5)
+// admin 역할은 HandleSlashCursor에서 확인합니다.
+func handleAdminCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload, args []string) {
+	sub := "roles"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	var text string
+	switch sub {
+	case "roles", "list":
+		text = roleListText(cfg)
+
+	case "grant":
+		if len(args) < 3 {
+			text = adminUsage
+			break
+		}
+		text = grantRoleText(c, cfg, args[1], args[2], payload.UserID)
+
+	case "revoke":
+		if len(args) < 2 {
+			text = adminUsage
+			break
+		}
+		text = revokeRoleText(c, cfg, args[1], payload.UserID)
+
+	case "allow":
+		if len(args) < 3 || !strings.HasPrefix(args[1], "@") {
+			text = adminUsage
+			break
+		}
+		text = allowProjectText(c, cfg, strings.ToLower(strings.TrimPrefix(args[1], "@")), args[2:], payload.UserID)
+
+	default:
+		text = adminUsage
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          text,
+	})
+}
+
+// roleListText는 환경 변수로 지정한 관리자와 부여된 역할 목록을 Slack 메시지로 만듭니다.
+func roleListText(cfg *Config) string {
+	if !cfg.RBAC.Enabled() {
+		return "ℹ️ 역할 기반 접근 제어가 비활성화되어 있어 모든 사용자가 모든 명령어를 사용할 수 있습니다.\n\n💡 활성화하려면 `RBAC_ADMINS` 환경 변수에 관리자 user_id 또는 사용자 그룹 ID를 지정하세요."
+	}
+	assignments, err := cfg.DB.ListRoles()
+	if err != nil {
+		log.Printf("역할 목록 조회 실패: %v", err)
+		return "❌ 역할 목록을 가져오는 중 오류가 발생했습니다."
+	}
+
+	var response strings.Builder
+	response.WriteString("🔐 *역할 목록*\n\n")
+	response.WriteString(fmt.Sprintf("• admin (RBAC_ADMINS): %s\n", formatSubjects(cfg.RBAC.Admins)))
+	for _, a := range assignments {
+		response.WriteString(fmt.Sprintf("• %s: %s (부여: %s, %s)\n",
+			a.Role, formatSubject(a.Subject), formatActor(a.GrantedBy), a.GrantedAt.Format("2006-01-02 15:04")))
+	}
+	response.WriteString(fmt.Sprintf("\n*기본 역할*: %s\n", roleName(cfg.RBAC.DefaultRole)))
+	response.WriteString("\n💡 viewer: 결과 조회 | operator: 작업 실행/취소/되돌리기 | admin: 경로/프로젝트/채널/역할 관리")
+	return response.String()
+}
+
+// grantRoleText는 `/cursor admin grant <대상> <역할>`을 처리하고 결과 메시지를 반환합니다.
+func grantRoleText(c *gin.Context, cfg *Config, arg string, roleArg string, userID string) string {
+	subject, ok := parseSubject(arg)
+	if !ok {
+		return fmt.Sprintf("❌ Slack 사용자 또는 사용자 그룹을 멘션해주세요: `%s`", arg)
+	}
+	role, ok := database.ParseRole(strings.ToLower(roleArg))
+	if !ok {
+		return fmt.Sprintf("❌ 알 수 없는 역할입니다: `%s` (admin, operator, viewer 중 하나)", roleArg)
+	}
+
+	previous, err := cfg.DB.GetRole(subject)
+	if err == nil {
+		err = cfg.DB.SetRole(&database.RoleAssignment{
+			Subject:   subject,
+			Role:      role,
+			GrantedBy: userID,
+			GrantedAt: time.Now(),
+		})
+	}
+	if err != nil {
+		log.Printf("역할 부여 실패 (%s): %v", subject, err)
+		return "❌ 역할을 부여하는 중 오류가 발생했습니다."
+	}
+	log.Printf("[%s] 역할 부여: %s → %s", userID, subject, role)
+	recordAudit(cfg, c, database.AuditEvent{ActorID: userID, Action: database.AuditRoleGrant, Target: subject, Before: string(previous), After: string(role)})
+	return fmt.Sprintf("✅ %s 에게 `%s` 역할을 부여했습니다.", formatSubject(subject), role)
+}
+
+// revokeRoleText는 `/cursor admin revoke <대상>`을 처리하고 결과 메시지를 반환합니다.
+func revokeRoleText(c *gin.Context, cfg *Config, arg string, userID string) string {
+	subject, ok := parseSubject(arg)
+	if !ok {
+		return fmt.Sprintf("❌ Slack 사용자 또는 사용자 그룹을 멘션해주세요: `%s`", arg)
+	}
+	previous, err := cfg.DB.GetRole(subject)
+	removed := false
+	if err == nil {
+		removed, err = cfg.DB.DeleteRole(subject)
+	}
+	switch {
+	case err != nil:
+		log.Printf("역할 해제 실패 (%s): %v", subject, err)
+		return "❌ 역할을 해제하는 중 오류가 발생했습니다."
+	case !removed:
+		if contains(cfg.RBAC.Admins, subject) {
+			return fmt.Sprintf("ℹ️ %s 은(는) `RBAC_ADMINS` 환경 변수로 지정된 관리자입니다. 환경 변수에서 제거해주세요.", formatSubject(subject))
+		}
+		return fmt.Sprintf("ℹ️ %s 에게 부여된 역할이 없습니다.", formatSubject(subject))
+	}
+	log.Printf("[%s] 역할 해제: %s", userID, subject)
+	recordAudit(cfg, c, database.AuditEvent{ActorID: userID, Action: database.AuditRoleRevoke, Target: subject, Before: string(previous)})
+	return fmt.Sprintf("✅ %s 의 역할을 해제했습니다. (기본 역할: %s)", formatSubject(subject), roleName(cfg.RBAC.DefaultRole))
+}
+
+// allowProjectText는 `/cursor admin allow <@프로젝트> <대상>...|none`을 처리하고 결과 메시지를 반환합니다.
+// 허용 목록이 있는 프로젝트는 admin과 목록의 사용자/그룹(operator 이상)만 작업을 실행할 수 있습니다.
+func allowProjectText(c *gin.Context, cfg *Config, name string, args []string, userID string) string {
+	var subjects []string
+	if args[0] != "none" {
+		for _, arg := range args {
+			for _, part := range strings.Split(arg, ",") {
+				if part == "" {
+					continue
+				}
+				subject, ok := parseSubject(part)
+				if !ok {
+					return fmt.Sprintf("❌ Slack 사용자 또는 사용자 그룹을 멘션해주세요: `%s`", part)
+				}
+				if !contains(subjects, subject) {
+					subjects = append(subjects, subject)
+				}
+			}
+		}
+	}
+
+	var before []string
+	if project, err := cfg.DB.GetProject(name); err == nil && project != nil {
+		before = project.AllowedUsers
+	}
+	updated, err := cfg.DB.SetProjectAllowedUsers(name, subjects)
+	switch {
+	case err != nil:
+		log.Printf("프로젝트 허용 목록 변경 실패 (%s): %v", name, err)
+		return "❌ 프로젝트 허용 목록을 변경하는 중 오류가 발생했습니다."
+	case !updated:
+		return fmt.Sprintf("❌ 등록되지 않은 프로젝트입니다: `%s`", name)
+	}
+	log.Printf("[%s] 프로젝트 허용 목록 변경: %s → %s", userID, name, strings.Join(subjects, ","))
+	recordAudit(cfg, c, database.AuditEvent{
+		ActorID: userID, Action: database.AuditProjectAllow, Target: "@" + name,
+		Before: formatAuditList(before), After: formatAuditList(subjects),
+	})
+	if len(subjects) == 0 {
+		return fmt.Sprintf("✅ `@%s` 프로젝트의 허용 목록을 해제했습니다. operator 이상이면 누구나 작업을 실행할 수 있습니다.", name)
+	}
+	return fmt.Sprintf("🔒 `@%s` 프로젝트는 이제 admin과 %s 만 작업을 실행할 수 있습니다.", name, formatSubjects(subjects))
+}
+
+// parseSubject는 `<@U123|name>`, `<!subteam^S123|@group>` 멘션 또는 ID를 user_id/user group ID로 변환합니다.
+func parseSubject(arg string) (string, bool) {
+	id := strings.TrimSpace(arg)
+	if strings.HasPrefix(id, "<") && strings.HasSuffix(id, ">") {
+		id = strings.TrimPrefix(strings.TrimPrefix(id[1:len(id)-1], "@"), "!subteam^")
+		if i := strings.Index(id, "|"); i >= 0 {
+			id = id[:i]
+		}
+	}
+	if len(id) < 2 || !strings.ContainsAny(id[:1], "UWS") || strings.ToUpper(id) != id {
+		return "", false
+	}
+	return id, true
+}
+
+// formatSubject는 user_id/user group ID를 Slack 멘션으로 표시합니다.
+func formatSubject(subject string) string {
+	if isUserGroup(subject) {
+		return fmt.Sprintf("<!subteam^%s>", subject)
+	}
+	return formatActor(subject)
+}
+
+// formatSubjects는 대상 목록을 Slack 멘션으로 표시합니다.
+func formatSubjects(subjects []string) string {
+	mentions := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		mentions = append(mentions, formatSubject(s))
+	}
+	return strings.Join(mentions, ", ")
+}
@@ -0,0 +1,322 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// apiKeyContextKey는 인증된 API 키를 Gin Context에 저장하는 키입니다.
+const apiKeyContextKey = "apiKey"
+
+// APIKeyRequest는 API 키 발급 요청입니다 (v1.5)
+type APIKeyRequest struct {
+	Name   string   `json:"name" binding:"required" example:"ci-bot"`
+	Scopes []string `json:"scopes" binding:"required" example:"run,read"` // run, read, configure
+
+	// 작업을 실행/조회할 수 있는 등록된 프로젝트 (비어 있으면 모든 작업)
+	Projects []string `json:"projects" example:"backend"`
+}
+
+// APIKeyResponse는 발급한 API 키입니다. Key는 이 응답에서만 확인할 수 있습니다 (v1.5)
+type APIKeyResponse struct {
+	database.APIKey
+	Key string `json:"key" example:"csk_1a2b3c4d..."`
+}
+
+// apiKeyAuth는 /api 요청의 API 키를 확인하는 미들웨어입니다 (v1.5)
+// `Authorization: Bearer <키>` 또는 `X-API-Key: <키>` 헤더를 사용하며, 해시가 일치하고 폐기되지 않은 키만 허용합니다.
+// API_AUTH=off이면 인증하지 않습니다 (로컬 개발용).
+func apiKeyAuth(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.APIAuthDisabled {
+			c.Next()
+			return
+		}
+
+		token := apiKeyFromRequest(c.Request)
+		if token == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="api"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "API 키가 필요합니다 (Authorization: Bearer <키> 또는 X-API-Key 헤더)"})
+			return
+		}
+		key, err := cfg.DB.GetAPIKeyByHash(database.HashAPIKey(token))
+		if err != nil {
+			log.Printf("API 키 조회 실패: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{Error: "API 키를 확인하는 중 오류가 발생했습니다"})
+			return
+		}
+		if key == nil {
+			log.Printf("유효하지 않은 API 키로 요청 거부: %s %s (%s)", c.Request.Method, c.Request.URL.Path, c.ClientIP())
+			c.Header("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "유효하지 않거나 폐기된 API 키입니다"})
+			return
+		}
+		if err := cfg.DB.TouchAPIKey(key.ID, time.Now()); err != nil {
+			log.Printf("API 키 사용 시각 기록 실패 (%s): %v", key.ID, err)
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// apiKeyFromRequest는 Authorization Bearer 또는 X-API-Key 헤더에서 키를 읽습니다.
+func apiKeyFromRequest(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if scheme, token, ok := strings.Cut(auth, " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+// requireScope는 인증된 API 키에 권한 범위가 있는지 확인하는 미들웨어입니다 (v1.5)
+func requireScope(cfg *Config, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.APIAuthDisabled {
+			c.Next()
+			return
+		}
+		if key := requestAPIKey(c); key == nil || !key.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: "API 키에 `" + scope + "` 권한이 없습니다"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireAllProjects는 프로젝트 허용 목록(APIKey.Projects)이 있는 API 키를 거부하는 미들웨어입니다 (v1.5)
+// 프로젝트 등록/삭제, 기본 경로 설정, 감사 로그 조회처럼 한 프로젝트로 범위를 좁힐 수 없는 엔드포인트에 사용합니다.
+func requireAllProjects() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := requestAPIKey(c); key != nil && len(key.Projects) > 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: "프로젝트 허용 목록이 있는 API 키(" + keyProjectsLabel(key) + ")로는 사용할 수 없습니다"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestAPIKey는 요청을 인증한 API 키를 반환합니다 (API_AUTH=off이거나 API 요청이 아니면 nil).
+func requestAPIKey(c *gin.Context) *database.APIKey {
+	if c == nil {
+		return nil
+	}
+	if v, ok := c.Get(apiKeyContextKey); ok {
+		return v.(*database.APIKey)
+	}
+	return nil
+}
+
+// apiCanViewJob은 API 요청으로 작업의 프롬프트와 결과를 보거나 취소할 수 있는지 반환합니다 (v1.5)
+// Slack 사용자와 달리 API 요청에는 역할이 없으므로, API 키의 프로젝트 허용 목록(APIKey.Projects)으로 확인합니다.
+// 프로젝트별 사용자 허용 목록(`/cursor admin allow`)은 Slack 사용자에게만 적용됩니다.
+func apiCanViewJob(c *gin.Context, job *database.JobRecord) bool {
+	key := requestAPIKey(c)
+	return key == nil || key.AllowsProject(job.ProjectName)
+}
+
+// apiHidesJob은 작업이 API 키의 프로젝트 허용 목록 밖에 있어 존재를 드러내지 않아야 하는지 반환합니다.
+// 취소, 되돌리기, 이어서 실행 API는 이때 찾을 수 없는 작업으로 응답합니다.
+func apiHidesJob(c *gin.Context, cfg *Config, jobID string) bool {
+	job, err := cfg.DB.GetJob(jobID)
+	return err == nil && job != nil && !apiCanViewJob(c, job)
+}
+
+// authorizeAPIKey는 API 요청으로 등록하는 작업이 API 키의 프로젝트 허용 목록에 있는지 확인합니다 (v1.5)
+// Slack 요청(API 키 없음)은 항상 통과합니다.
+func authorizeAPIKey(c *gin.Context, record *database.JobRecord) error {
+	key := requestAPIKey(c)
+	if key == nil || key.AllowsProject(record.ProjectName) {
+		return nil
+	}
+	return &permissionError{Required: database.RoleOperator, Current: database.RoleAdmin, Project: record.ProjectName, APIKey: key.Name}
+}
+
+// parseKeyProjects는 API 키 프로젝트 허용 목록을 등록된 프로젝트 이름으로 정규화합니다.
+func parseKeyProjects(cfg *Config, values []string) ([]string, error) {
+	var projects []string
+	for _, v := range values {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), "@"))
+		if name == "" || slices.Contains(projects, name) {
+			continue
+		}
+		project, err := cfg.DB.GetProject(name)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, fmt.Errorf("등록되지 않은 프로젝트입니다: %s", name)
+		}
+		projects = append(projects, name)
+	}
+	return projects, nil
+}
+
+// keyProjectsLabel은 로그/감사 이벤트에 표시할 API 키의 프로젝트 허용 목록입니다.
+func keyProjectsLabel(key *database.APIKey) string {
+	if len(key.Projects) == 0 {
+		return "전체"
+	}
+	return strings.Join(key.Projects, ",")
+}
+
+// apiJobBase는 API 요청으로 등록하는 작업 레코드의 요청자 정보를 만듭니다.
+// 작업에는 요청한 API 키 ID를 기록하고, 요청자 이름은 `api:<키 이름>`으로 표시합니다.
+func apiJobBase(c *gin.Context) *database.JobRecord {
+	record := &database.JobRecord{
+		UserID:   apiUserID,
+		UserName: apiUserName,
+	}
+	if key := requestAPIKey(c); key != nil {
+		record.UserName = apiUserID + ":" + key.Name
+		record.APIKeyID = key.ID
+	}
+	return record
+}
+
+// apiActor는 API 요청으로 바뀐 설정의 변경자(created_by 등)를 반환합니다.
+func apiActor(c *gin.Context) string {
+	if key := requestAPIKey(c); key != nil {
+		return apiUserID + ":" + key.Name
+	}
+	return apiUserID
+}
+
+// HandleListAPIKeys godoc
+// @Summary      API 키 목록 조회 (v1.5)
+// @Description  발급된 API 키 목록을 조회합니다. 키 원문과 해시는 포함되지 않으며, 폐기된 키도 감사를 위해 표시됩니다.
+// @Tags         keys
+// @Produce      json
+// @Success      200  {array}   database.APIKey  "API 키 목록"
+// @Failure      401  {object}  ErrorResponse    "인증 실패"
+// @Failure      403  {object}  ErrorResponse    "권한 없음"
+// @Failure      500  {object}  ErrorResponse    "서버 오류"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/keys [get]
+func HandleListAPIKeys(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys, err := cfg.DB.ListAPIKeys()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "API 키 목록 조회 실패: " + err.Error()})
+			return
+		}
+		if keys == nil {
+			keys = []*database.APIKey{}
+		}
+		c.JSON(http.StatusOK, keys)
+	}
+}
+
+// HandleCreateAPIKey godoc
+// @Summary      API 키 발급 (v1.5)
+// @Description  권한 범위(run: 작업 실행/취소/되돌리기/이어서 실행, read: 조회, configure: 경로/프로젝트/API 키 관리)를 지정하여 API 키를 발급합니다.
+// @Description  요청한 키에 없는 권한 범위나 프로젝트 허용 목록 밖의 프로젝트(허용 목록이 있는 키는 모든 작업 포함)를 가진 키는 발급할 수 없습니다 (403).
+// @Description  키 원문(key)은 이 응답에서만 확인할 수 있으며 서버에는 SHA-256 해시만 저장됩니다.
+// @Tags         keys
+// @Accept       json
+// @Produce      json
+// @Param        request  body      APIKeyRequest   true  "키 이름과 권한 범위"
+// @Success      201      {object}  APIKeyResponse  "발급된 API 키"
+// @Failure      400      {object}  ErrorResponse   "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse   "인증 실패"
+// @Failure      403      {object}  ErrorResponse   "권한 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/keys [post]
+func HandleCreateAPIKey(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req APIKeyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid JSON payload: " + err.Error()})
+			return
+		}
+		scopes, err := database.ParseScopes(req.Scopes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		projects, err := parseKeyProjects(cfg, req.Projects)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		// v1.5: 요청한 키보다 넓은 권한 범위나 프로젝트의 키는 발급할 수 없음
+		if caller := requestAPIKey(c); caller != nil && !caller.Covers(scopes, projects) {
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: "요청한 API 키의 권한 범위와 프로젝트 허용 목록을 넘는 키는 발급할 수 없습니다"})
+			return
+		}
+
+		key, plain, err := database.NewAPIKey(strings.TrimSpace(req.Name), scopes, projects, apiActor(c))
+		if err == nil {
+			err = cfg.DB.CreateAPIKey(key)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "API 키 발급 실패: " + err.Error()})
+			return
+		}
+
+		log.Printf("[%s] API 키 발급: %s (%s, 권한: %s, 프로젝트: %s)", key.CreatedBy, key.Name, key.Prefix, strings.Join(key.Scopes, ","), keyProjectsLabel(key))
+		recordAudit(cfg, c, database.AuditEvent{
+			Action: database.AuditAPIKeyCreate, Target: key.ID,
+			After: fmt.Sprintf("%s (%s, 권한: %s, 프로젝트: %s)", key.Name, key.Prefix, strings.Join(key.Scopes, ","), keyProjectsLabel(key)),
+		})
+		c.JSON(http.StatusCreated, APIKeyResponse{APIKey: *key, Key: plain})
+	}
+}
+
+// HandleRevokeAPIKey godoc
+// @Summary      API 키 폐기 (v1.5)
+// @Description  API 키를 폐기합니다. 폐기한 키로 보낸 요청은 즉시 401로 거부되며, 키 기록은 감사를 위해 유지됩니다.
+// @Description  요청한 키보다 넓은 권한 범위나 프로젝트를 가진 키는 폐기할 수 없습니다 (403).
+// @Tags         keys
+// @Produce      json
+// @Param        id   path  string  true  "API 키 ID"
+// @Success      204  "폐기 성공"
+// @Failure      401  {object}  ErrorResponse  "인증 실패"
+// @Failure      403  {object}  ErrorResponse  "권한 없음"
+// @Failure      404  {object}  ErrorResponse  "API 키를 찾을 수 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/keys/{id} [delete]
+func HandleRevokeAPIKey(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		// v1.5: 요청한 키보다 넓은 권한 범위나 프로젝트의 키는 폐기할 수 없음
+		if caller := requestAPIKey(c); caller != nil {
+			target, err := cfg.DB.GetAPIKey(id)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "API 키 조회 실패: " + err.Error()})
+				return
+			}
+			if target != nil && !caller.Covers(target.Scopes, target.Projects) {
+				c.JSON(http.StatusForbidden, ErrorResponse{Error: "요청한 API 키의 권한 범위와 프로젝트 허용 목록을 넘는 키는 폐기할 수 없습니다"})
+				return
+			}
+		}
+		revoked, err := cfg.DB.RevokeAPIKey(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "API 키 폐기 실패: " + err.Error()})
+			return
+		}
+		if !revoked {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "API 키를 찾을 수 없거나 이미 폐기되었습니다."})
+			return
+		}
+
+		log.Printf("[%s] API 키 폐기: %s", apiActor(c), id)
+		recordAudit(cfg, c, database.AuditEvent{Action: database.AuditAPIKeyRevoke, Target: id})
+		c.Status(http.StatusNoContent)
+	}
+}
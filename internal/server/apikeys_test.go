@@ -0,0 +1,199 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// apiTestContext는 key로 인증된 API 요청 컨텍스트를 만듭니다 (key가 nil이면 API_AUTH=off와 같음).
+func apiTestContext(key *database.APIKey, method string, target string, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if key != nil {
+		c.Set(apiKeyContextKey, key)
+	}
+	return c, w
+}
+
+func TestAPIKeyCannotExceedCaller(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	for _, name := range []string{"backend", "frontend"} {
+		if err := cfg.DB.CreateProject(&database.Project{Name: name, Path: repo, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+	caller := &database.APIKey{ID: "caller", Name: "backend-admin", Scopes: []string{database.ScopeRead, database.ScopeConfigure}, Projects: []string{"backend"}}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"unrestricted key", `{"name":"x","scopes":["read"]}`, http.StatusForbidden},
+		{"other project", `{"name":"x","scopes":["read"],"projects":["frontend"]}`, http.StatusForbidden},
+		{"wider scope", `{"name":"x","scopes":["run"],"projects":["backend"]}`, http.StatusForbidden},
+		{"subset", `{"name":"x","scopes":["read"],"projects":["@backend"]}`, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := apiTestContext(caller, http.MethodPost, "/api/keys", tt.body)
+			HandleCreateAPIKey(cfg)(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	// 더 넓은 키는 폐기할 수 없고, 허용 목록 안의 키는 폐기할 수 있음
+	wide, _, _ := database.NewAPIKey("wide", []string{database.ScopeRead}, nil, "cli")
+	narrow, _, _ := database.NewAPIKey("narrow", []string{database.ScopeRead}, []string{"backend"}, "cli")
+	for _, k := range []*database.APIKey{wide, narrow} {
+		if err := cfg.DB.CreateAPIKey(k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	for _, tt := range []struct {
+		key  *database.APIKey
+		want int
+	}{{wide, http.StatusForbidden}, {narrow, http.StatusOK}} {
+		c, w := apiTestContext(caller, http.MethodDelete, "/api/keys/"+tt.key.ID, "")
+		c.Params = gin.Params{{Key: "id", Value: tt.key.ID}}
+		HandleRevokeAPIKey(cfg)(c)
+		if w.Code != tt.want {
+			t.Errorf("revoke %s status = %d, want %d", tt.key.Name, w.Code, tt.want)
+		}
+	}
+}
+
+// issueTestKey는 API 키를 발급해 DB에 저장하고 평문 키를 반환합니다.
+func issueTestKey(t *testing.T, cfg *Config, scopes []string, projects []string) string {
+	t.Helper()
+	key, plain, err := database.NewAPIKey("test", scopes, projects, "cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.DB.CreateAPIKey(key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	return plain
+}
+
+func TestRestrictedKeyCannotConfigureServer(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	if err := cfg.DB.CreateProject(&database.Project{Name: "backend", Path: repo, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	router := SetupRouter(cfg)
+	restricted := issueTestKey(t, cfg, []string{database.ScopeRead, database.ScopeConfigure}, []string{"backend"})
+
+	for _, r := range []struct{ method, path, body string }{
+		{http.MethodPost, "/api/config/project-path", `{"path":"` + repo + `"}`},
+		{http.MethodPost, "/api/projects", `{"name":"other","path":"` + repo + `"}`},
+		{http.MethodDelete, "/api/projects/backend", ""},
+		{http.MethodGet, "/api/audit", ""},
+	} {
+		req := httptest.NewRequest(r.method, r.path, strings.NewReader(r.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+restricted)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s %s status = %d, want 403", r.method, r.path, w.Code)
+		}
+	}
+	if project, _ := cfg.DB.GetProject("backend"); project == nil {
+		t.Error("프로젝트 허용 목록이 있는 키로 프로젝트가 삭제됨")
+	}
+}
+
+func TestAPIKeyFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"bearer", map[string]string{"Authorization": "Bearer csk_abc"}, "csk_abc"},
+		{"bearer case", map[string]string{"Authorization": "bearer  csk_abc "}, "csk_abc"},
+		{"x-api-key", map[string]string{"X-API-Key": " csk_abc "}, "csk_abc"},
+		{"authorization wins", map[string]string{"Authorization": "Bearer csk_abc", "X-API-Key": "csk_other"}, "csk_abc"},
+		{"other scheme", map[string]string{"Authorization": "Basic dXNlcjpwYXNz", "X-API-Key": "csk_abc"}, ""},
+		{"no scheme", map[string]string{"Authorization": "csk_abc"}, ""},
+		{"none", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := apiKeyFromRequest(req); got != tt.want {
+				t.Errorf("apiKeyFromRequest = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	router := SetupRouter(cfg)
+	reader := issueTestKey(t, cfg, []string{database.ScopeRead}, nil)
+	revoked, revokedPlain, _ := database.NewAPIKey("revoked", []string{database.ScopeRead, database.ScopeConfigure}, nil, "cli")
+	if err := cfg.DB.CreateAPIKey(revoked); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if ok, err := cfg.DB.RevokeAPIKey(revoked.ID); !ok || err != nil {
+		t.Fatalf("RevokeAPIKey = %v, %v", ok, err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		key    string
+		want   int
+	}{
+		{"no key", http.MethodGet, "/api/config/project-path", "", "", http.StatusUnauthorized},
+		{"unknown key", http.MethodGet, "/api/config/project-path", "Authorization", "Bearer csk_unknown", http.StatusUnauthorized},
+		{"revoked key", http.MethodGet, "/api/config/project-path", "Authorization", "Bearer " + revokedPlain, http.StatusUnauthorized},
+		{"bearer", http.MethodGet, "/api/config/project-path", "Authorization", "Bearer " + reader, http.StatusOK},
+		{"x-api-key", http.MethodGet, "/api/config/project-path", "X-API-Key", reader, http.StatusOK},
+		{"missing run scope", http.MethodPost, "/api/cursor", "Authorization", "Bearer " + reader, http.StatusForbidden},
+		{"missing configure scope", http.MethodGet, "/api/keys", "Authorization", "Bearer " + reader, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"text":"README 정리해줘"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+			if tt.want == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 응답에 WWW-Authenticate 헤더가 없습니다")
+			}
+		})
+	}
+
+	// API_AUTH=off이면 키 없이도 권한 범위를 확인하지 않음
+	cfg.APIAuthDisabled = true
+	c, w := apiTestContext(nil, http.MethodGet, "/api/keys", "")
+	requireScope(cfg, database.ScopeConfigure)(c)
+	if c.IsAborted() || w.Code != http.StatusOK {
+		t.Errorf("API_AUTH=off: aborted = %v, status = %d", c.IsAborted(), w.Code)
+	}
+}
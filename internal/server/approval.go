@@ -0,0 +1,189 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// 실행 계획 승인 관련 에러 (v1.5)
+var (
+	errJobNotAwaitingApproval = errors.New("승인 대기 중인 작업이 아닙니다")
+	errNotReviewer            = errors.New("이 작업을 승인/거절할 권한이 없습니다")
+	errSelfApproval           = errors.New("자신이 요청한 작업의 실행 계획은 승인할 수 없습니다")
+	errNoReviewers            = errors.New("승인자가 설정되지 않았습니다 (`/cursor project set <이름> reviewers` 또는 APPROVAL_REVIEWERS)")
+)
+
+// approvalReviewers는 작업을 승인/거절할 수 있는 Slack user_id/사용자 그룹 ID 목록을 반환합니다 (v1.5)
+// 프로젝트에 승인자가 지정되어 있으면 그 목록을, 없으면 APPROVAL_REVIEWERS를 사용합니다.
+func approvalReviewers(cfg *Config, job *database.JobRecord) ([]string, error) {
+	if job.ProjectName != "" {
+		project, err := cfg.DB.GetProject(job.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+		if project != nil && len(project.Reviewers) > 0 {
+			return project.Reviewers, nil
+		}
+	}
+	return cfg.ApprovalReviewers, nil
+}
+
+// reviewJob은 승인 대기 중인 작업을 승인하거나 거절합니다 (v1.5: 명령어/버튼 공용)
+//
+// 승인하면 작업이 다시 대기열에 들어가 파일 수정 권한으로 실행되며, 결과는 responseURL로 전송됩니다.
+// 요청자 본인은 승인자 목록에 있어도 승인할 수 없습니다 (거절은 가능).
+// 서버 재시작으로 비밀 값을 가리기 전의 프롬프트가 없으면 승인하지 않습니다 (거절은 가능).
+func reviewJob(cfg *Config, jobID string, reviewer string, approve bool, note string, responseURL string) (*database.JobRecord, error) {
+	job, err := cfg.DB.GetJob(jobID)
+	if err != nil || job == nil {
+		return nil, errJobNotFound
+	}
+	if job.Status != database.JobStatusAwaitingApproval {
+		return job, errJobNotAwaitingApproval
+	}
+
+	reviewers, err := approvalReviewers(cfg, job)
+	if err != nil {
+		return job, err
+	}
+	if len(reviewers) == 0 {
+		return job, errNoReviewers
+	}
+	if !isReviewer(cfg, reviewers, reviewer) {
+		return job, errNotReviewer
+	}
+	if approve && reviewer == job.UserID {
+		return job, errSelfApproval
+	}
+	if approve && !cfg.JobQueue.HasPrompt(job) {
+		return job, worker.ErrPromptUnavailable
+	}
+
+	var updated bool
+	if approve {
+		updated, err = cfg.DB.ApproveJob(job.ID, reviewer, responseURL)
+	} else {
+		updated, err = cfg.DB.RejectJob(job.ID, reviewer, note)
+	}
+	if err != nil {
+		return job, fmt.Errorf("승인 상태 변경 실패: %w", err)
+	}
+	if !updated {
+		// 그 사이에 다른 승인자가 처리했거나 취소됨 (다시 조회하지 못하면 조회한 작업으로 응답)
+		if refreshed, err := cfg.DB.GetJob(job.ID); err == nil && refreshed != nil {
+			job = refreshed
+		}
+		return job, errJobNotAwaitingApproval
+	}
+
+	if approve {
+		log.Printf("[%s] 실행 계획 승인 (승인자: %s)", job.ID, reviewer)
+		cfg.JobQueue.Notify()
+	} else {
+		log.Printf("[%s] 실행 계획 거절 (승인자: %s, 사유: %s)", job.ID, reviewer, note)
+	}
+
+	if refreshed, err := cfg.DB.GetJob(job.ID); err == nil && refreshed != nil {
+		job = refreshed
+	}
+	return job, nil
+}
+
+// reviewResult는 승인/거절 결과 메시지입니다. 성공하면 채널에 공개하고, 실패하면 요청자에게만 보입니다.
+func reviewResult(cfg *Config, jobID string, job *database.JobRecord, err error, approve bool) *types.SlackDelayedResponse {
+	switch {
+	case errors.Is(err, errJobNotFound):
+		return ephemeralReply(fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`\n\n💡 `/cursor list` 명령어로 최근 작업 목록을 확인하세요.", jobID))
+	case errors.Is(err, errJobNotAwaitingApproval):
+		return ephemeralReply(fmt.Sprintf("⚠️ %s: `%s` (상태: %s)", err.Error(), job.ID[:8], job.Status))
+	case errors.Is(err, errNotReviewer), errors.Is(err, errNoReviewers), errors.Is(err, errSelfApproval):
+		return ephemeralReply("🔒 " + err.Error())
+	case errors.Is(err, worker.ErrPromptUnavailable):
+		return ephemeralReply("❌ " + err.Error())
+	case err != nil:
+		log.Printf("승인 처리 실패 (%s): %v", jobID, err)
+		return ephemeralReply("❌ 승인 요청을 처리하는 중 오류가 발생했습니다.")
+	}
+
+	if approve {
+		text := fmt.Sprintf("✅ %s님이 실행 계획을 승인했습니다. 파일 수정 권한으로 실행합니다... (ID: `%s`)\n> %s",
+			formatActor(job.ReviewedBy), job.ID[:8], cfg.Redactor.String(job.Prompt))
+		return &types.SlackDelayedResponse{
+			Text:         text,
+			ResponseType: "in_channel",
+			Blocks:       worker.MessageBlocks(text, job.ID, worker.ActionCancelJob),
+		}
+	}
+
+	text := fmt.Sprintf("🚫 %s님이 실행 계획을 거절했습니다. (ID: `%s`)\n> %s", formatActor(job.ReviewedBy), job.ID[:8], cfg.Redactor.String(job.Prompt))
+	if job.ReviewNote != "" {
+		text += fmt.Sprintf("\n*사유:* %s", job.ReviewNote)
+	}
+	return &types.SlackDelayedResponse{Text: text, ResponseType: "in_channel"}
+}
+
+// handleReviewCommand는 `/cursor approve <job-id>` / `/cursor reject <job-id> [사유]`를 처리합니다 (v1.5)
+func handleReviewCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload, args []string, approve bool) {
+	if len(args) == 0 {
+		usage := "❌ Job ID를 입력해주세요.\n사용법: `/cursor approve <job-id>`"
+		if !approve {
+			usage = "❌ Job ID를 입력해주세요.\n사용법: `/cursor reject <job-id> [사유]`"
+		}
+		c.JSON(http.StatusOK, gin.H{"response_type": "ephemeral", "text": usage})
+		return
+	}
+
+	note := strings.Join(args[1:], " ")
+	job, err := reviewJob(cfg, args[0], payload.UserID, approve, note, payload.ResponseURL)
+	if err == nil {
+		recordAudit(cfg, c, reviewAuditEvent(payload.UserID, payload.UserName, job, approve, note))
+	}
+	reply := reviewResult(cfg, args[0], job, err, approve)
+	c.JSON(http.StatusOK, reply)
+}
+
+// parseReviewers는 `<@U123|name>` 멘션 또는 user_id 목록을 user_id 목록으로 변환합니다.
+func parseReviewers(args []string) []string {
+	var reviewers []string
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			id, ok := parseSubject(part)
+			if ok && !contains(reviewers, id) {
+				reviewers = append(reviewers, id)
+			}
+		}
+	}
+	return reviewers
+}
+
+// isReviewer는 사용자가 승인자 목록에 있는지 반환합니다. 사용자 그룹(S…)은 구성원인지 확인합니다 (v1.5)
+func isReviewer(cfg *Config, reviewers []string, userID string) bool {
+	for _, subject := range reviewers {
+		if subject == userID || (isUserGroup(subject) && cfg.groups.isMember(cfg, subject, userID)) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatReviewers는 승인자 목록을 Slack 멘션으로 표시합니다.
+func formatReviewers(reviewers []string) string {
+	return formatSubjects(reviewers)
+}
+
+func contains(items []string, item string) bool {
+	for _, s := range items {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
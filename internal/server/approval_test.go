@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+func TestReviewJobReviewers(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	cfg.ApprovalReviewers = parseReviewers([]string{"<@U1>", "<!subteam^S1|@reviewers>"})
+	cfg.groups.entries = map[string]userGroupEntry{"S1": {members: []string{"U2"}, fetchedAt: time.Now()}}
+
+	repo, _ := cfg.GetProjectPath()
+	job := &database.JobRecord{ID: "11111111-0000-0000-0000-000000000000", Prompt: "수정해줘", ProjectPath: repo, UserID: "U1", Status: database.JobStatusPending, CreatedAt: time.Now(), RequireApproval: true}
+	if err := cfg.DB.CreateJob(job); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if claimed, err := cfg.DB.ClaimNextJob(); err != nil || claimed == nil {
+		t.Fatalf("ClaimNextJob = %v, %v", claimed, err)
+	}
+	if ok, err := cfg.DB.SetJobAwaitingApproval(job.ID, "계획"); err != nil || !ok {
+		t.Fatalf("SetJobAwaitingApproval = %v, %v", ok, err)
+	}
+
+	tests := []struct {
+		name     string
+		reviewer string
+		want     error
+	}{
+		{"requester", "U1", errSelfApproval},
+		{"not reviewer", "U3", errNotReviewer},
+		{"group member", "U2", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := reviewJob(cfg, job.ID, tt.reviewer, true, "", ""); !errors.Is(err, tt.want) {
+				t.Errorf("reviewJob(%s) error = %v, want %v", tt.reviewer, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/server/middleware"
+)
+
+// auditExportPageSize는 감사 로그를 파일(csv, jsonl)로 내보낼 때 한 번에 읽는 이벤트 수입니다.
+const auditExportPageSize = 1000
+
+// recordAudit는 감사 이벤트를 기록합니다 (v1.5)
+//
+// event에는 동작, 대상, 변경 전후 값과 Slack 요청자(ActorID/ActorName)를 지정합니다.
+// c가 있으면 요청 IP와 요청 ID(middleware.RequestIDKey)를 채우고, API 키로 인증된 요청이면 행위자를 키로 기록합니다.
+// 기록에 실패해도 요청은 계속 처리하고 로그만 남깁니다.
+func recordAudit(cfg *Config, c *gin.Context, event database.AuditEvent) {
+	var key *database.APIKey
+	if c != nil {
+		event.SourceIP = c.ClientIP()
+		if event.RequestID == "" {
+			event.RequestID = c.GetString(middleware.RequestIDKey)
+		}
+		key = requestAPIKey(c)
+	}
+	switch {
+	case key != nil:
+		event.ActorType = database.AuditActorAPIKey
+		event.ActorID = key.ID
+		event.ActorName = key.Name
+	case event.ActorID == "" || event.ActorID == apiUserID:
+		event.ActorType = database.AuditActorAPI
+		event.ActorID = apiUserID
+	default:
+		event.ActorType = database.AuditActorSlack
+	}
+
+	if err := cfg.DB.AppendAuditEvent(&event); err != nil {
+		log.Printf("감사 이벤트 기록 실패 (%s %s): %v", event.Action, event.Target, err)
+	}
+}
+
+// auditJob은 등록된 작업을 감사 이벤트로 기록합니다.
+// c가 없으면(Events API 비동기 처리) 작업 ID를 요청 ID로 기록합니다.
+func auditJob(cfg *Config, c *gin.Context, record *database.JobRecord) {
+	event := database.AuditEvent{
+		ActorID:   record.UserID,
+		ActorName: record.UserName,
+		Action:    database.AuditJobCreate,
+		Target:    record.ID,
+		Before:    record.ParentJobID,
+		After:     cfg.Redactor.String(record.Prompt), // v1.5: 감사 로그는 수정할 수 없으므로 비밀 값을 가려서 기록
+	}
+	if c == nil {
+		event.RequestID = record.ID
+	}
+	recordAudit(cfg, c, event)
+}
+
+// reviewAuditEvent는 실행 계획 승인/거절 감사 이벤트를 만듭니다 (after: 거절 사유).
+func reviewAuditEvent(userID string, userName string, job *database.JobRecord, approve bool, note string) database.AuditEvent {
+	action := database.AuditJobReject
+	if approve {
+		action = database.AuditJobApprove
+	}
+	return database.AuditEvent{ActorID: userID, ActorName: userName, Action: action, Target: job.ID, After: note}
+}
+
+// HandleListAudit godoc
+// @Summary      감사 로그 조회 (v1.5)
+// @Description  명령어 실행과 설정 변경 기록을 오래된 순으로 조회합니다. 기록은 추가만 가능하며 수정/삭제할 수 없습니다.
+// @Description  format=csv 또는 format=jsonl이면 파일로 내려받습니다 (limit을 지정하지 않으면 조건에 맞는 이벤트 전체).
+// @Description  CSV에서는 스프레드시트 수식으로 실행되지 않도록 =, +, -, @로 시작하는 값 앞에 '를 붙입니다.
+// @Description  v1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).
+// @Tags         audit
+// @Produce      json
+// @Produce      text/csv
+// @Param        actor   query     string  false  "행위자 ID (Slack user_id 또는 API 키 ID)"
+// @Param        action  query     string  false  "동작 (예: config.set_path, `job.`처럼 `.`으로 끝나면 접두사 검색)"
+// @Param        target  query     string  false  "대상 (작업 ID, @프로젝트 등)"
+// @Param        since   query     string  false  "시작 시각 (RFC3339 또는 2006-01-02, 포함)"
+// @Param        until   query     string  false  "종료 시각 (RFC3339 또는 2006-01-02, 제외)"
+// @Param        limit   query     int     false  "최근 이벤트 개수 (기본값: 100, json 형식)"
+// @Param        format  query     string  false  "응답 형식 (json/csv/jsonl, 기본값: json)"
+// @Success      200     {array}   database.AuditEvent  "감사 이벤트 목록"
+// @Failure      400     {object}  ErrorResponse        "잘못된 요청"
+// @Failure      401     {object}  ErrorResponse        "인증 실패"
+// @Failure      403     {object}  ErrorResponse        "권한 없음"
+// @Failure      500     {object}  ErrorResponse        "서버 오류"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/audit [get]
+func HandleListAudit(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format := c.DefaultQuery("format", "json")
+		if format != "json" && format != "csv" && format != "jsonl" {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "format은 json, csv, jsonl 중 하나여야 합니다"})
+			return
+		}
+
+		filter := database.AuditFilter{
+			ActorID: c.Query("actor"),
+			Action:  c.Query("action"),
+			Target:  c.Query("target"),
+			Limit:   100,
+		}
+		if format != "json" {
+			filter.Limit = 0 // 조건에 맞는 이벤트 전체
+		}
+		if l := c.Query("limit"); l != "" {
+			parsed, err := strconv.Atoi(l)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "limit은 양의 정수여야 합니다"})
+				return
+			}
+			filter.Limit = parsed
+		}
+		for _, p := range []struct {
+			name  string
+			value *time.Time
+		}{{"since", &filter.Since}, {"until", &filter.Until}} {
+			if v := c.Query(p.name); v != "" {
+				t, err := parseAuditTime(v)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("%s 형식이 올바르지 않습니다 (RFC3339 또는 2006-01-02): %s", p.name, v)})
+					return
+				}
+				*p.value = t
+			}
+		}
+
+		if format != "json" {
+			exportAudit(c, cfg, filter, format)
+			return
+		}
+
+		events, err := cfg.DB.ListAuditEvents(filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "감사 로그 조회 실패: " + err.Error()})
+			return
+		}
+		if events == nil {
+			events = []*database.AuditEvent{}
+		}
+		c.JSON(http.StatusOK, events)
+	}
+}
+
+// parseAuditTime은 RFC3339 시각 또는 날짜(서버 시간대 자정)를 해석합니다.
+func parseAuditTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", v, time.Local)
+}
+
+// auditFilename은 내보내는 감사 로그 파일 이름입니다.
+func auditFilename(ext string) string {
+	return fmt.Sprintf("audit-%s.%s", time.Now().Format("20060102-150405"), ext)
+}
+
+// exportAudit는 감사 이벤트를 CSV 또는 JSONL 파일로 응답합니다.
+// limit을 지정하지 않으면 조건에 맞는 이벤트 전체를 auditExportPageSize개씩 읽어 바로 전송하므로,
+// 이벤트 수와 관계없이 잘리지 않고 메모리에 모두 올리지도 않습니다.
+func exportAudit(c *gin.Context, cfg *Config, filter database.AuditFilter, format string) {
+	next := func(afterID int64) ([]*database.AuditEvent, error) {
+		return cfg.DB.ListAuditEventsAfter(filter, afterID, auditExportPageSize)
+	}
+	if filter.Limit > 0 {
+		// limit을 지정하면 최근 이벤트 limit개를 한 번에 조회
+		done := false
+		next = func(int64) ([]*database.AuditEvent, error) {
+			if done {
+				return nil, nil
+			}
+			done = true
+			return cfg.DB.ListAuditEvents(filter)
+		}
+	}
+
+	// 첫 페이지 조회에 실패하면 아직 응답을 쓰지 않았으므로 오류로 응답
+	events, err := next(0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "감사 로그 조회 실패: " + err.Error()})
+		return
+	}
+
+	var write func(*database.AuditEvent) error
+	var flush func() error
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w := csv.NewWriter(c.Writer)
+		w.Write([]string{"id", "created_at", "actor_type", "actor_id", "actor_name", "action", "target", "before", "after", "source_ip", "request_id"})
+		write = func(e *database.AuditEvent) error { return w.Write(auditCSVRecord(e)) }
+		flush = func() error {
+			w.Flush()
+			return w.Error()
+		}
+	} else {
+		c.Header("Content-Type", "application/x-ndjson; charset=utf-8")
+		enc := json.NewEncoder(c.Writer)
+		write = func(e *database.AuditEvent) error { return enc.Encode(e) }
+		flush = func() error { return nil }
+	}
+	c.Header("Content-Disposition", "attachment; filename="+auditFilename(format))
+	c.Status(http.StatusOK)
+
+	for len(events) > 0 {
+		for _, e := range events {
+			if err := write(e); err != nil {
+				log.Printf("감사 로그 %s 전송 실패: %v", format, err)
+				return
+			}
+		}
+		if err := flush(); err != nil {
+			log.Printf("감사 로그 %s 전송 실패: %v", format, err)
+			return
+		}
+		if events, err = next(events[len(events)-1].ID); err != nil {
+			// 이미 응답을 보내기 시작했으므로 상태 코드를 바꿀 수 없음 (파일이 중간에 끝남)
+			log.Printf("감사 로그 %s 내보내기 중단: %v", format, err)
+			return
+		}
+	}
+	if err := flush(); err != nil {
+		log.Printf("감사 로그 %s 전송 실패: %v", format, err)
+	}
+}
+
+// auditCSVRecord는 감사 이벤트를 CSV 행으로 변환합니다.
+// 스프레드시트에서 열었을 때 수식으로 실행되지 않도록 문자열 값은 csvCell로 변환합니다.
+func auditCSVRecord(e *database.AuditEvent) []string {
+	return []string{
+		strconv.FormatInt(e.ID, 10), e.CreatedAt.Format(time.RFC3339Nano), csvCell(e.ActorType), csvCell(e.ActorID), csvCell(e.ActorName),
+		csvCell(e.Action), csvCell(e.Target), csvCell(e.Before), csvCell(e.After), csvCell(e.SourceIP), csvCell(e.RequestID),
+	}
+}
+
+// csvCell은 =, +, -, @ (또는 탭, CR)로 시작하는 값 앞에 '를 붙여 CSV 수식 삽입(CSV injection)을 막습니다.
+// 프롬프트와 Slack 이름처럼 사용자가 입력한 값이 그대로 감사 로그에 기록되기 때문입니다.
+func csvCell(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// formatAuditList는 목록 값을 감사 이벤트의 변경 전후 값으로 표시합니다.
+func formatAuditList(values []string) string {
+	return strings.Join(values, ",")
+}
+
+// onOff는 설정 값을 감사 이벤트의 변경 전후 값으로 표시합니다.
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+func TestExportAuditCSV(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	for _, after := range []string{"=HYPERLINK(\"http://evil\")", "+1", "-1", "@SUM(A1)", "README 정리해줘"} {
+		if err := cfg.DB.AppendAuditEvent(&database.AuditEvent{ActorType: database.AuditActorSlack, ActorID: "U1", Action: database.AuditJobCreate, After: after}); err != nil {
+			t.Fatalf("AppendAuditEvent: %v", err)
+		}
+	}
+
+	c, w := apiTestContext(nil, http.MethodGet, "/api/audit?format=csv", "")
+	HandleListAudit(cfg)(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d (%s)", w.Code, w.Body.String())
+	}
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV: %v", err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("rows = %d, want 헤더 + 5", len(records))
+	}
+	want := []string{"'=HYPERLINK(\"http://evil\")", "'+1", "'-1", "'@SUM(A1)", "README 정리해줘"}
+	for i, record := range records[1:] {
+		if after := record[8]; after != want[i] {
+			t.Errorf("after[%d] = %q, want %q", i, after, want[i])
+		}
+	}
+}
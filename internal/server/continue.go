@@ -0,0 +1,274 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// 이어서 실행 관련 에러 (v1.5)
+var (
+	errJobNotContinuable = errors.New("이어서 실행할 수 없는 작업입니다")
+	errContinueProject   = errors.New("이어서 실행하는 작업은 원본 작업의 프로젝트에서 실행됩니다")
+	errContinueAgent     = errors.New("이어서 실행하는 작업은 원본 작업의 에이전트로 실행됩니다")
+	errEmptyPrompt       = errors.New("프롬프트를 입력해주세요")
+)
+
+// maxConversationDepth는 `/cursor show`에서 거슬러 올라가며 표시하는 대화 작업 최대 개수입니다.
+const maxConversationDepth = 20
+
+// ContinueJobRequest는 작업 이어서 실행 요청입니다 (v1.5)
+type ContinueJobRequest struct {
+	Prompt string `json:"prompt" example:"이제 테스트도 추가해줘" binding:"required"`
+	PR     bool   `json:"pr" example:"false"` // 완료 후 변경 사항으로 PR 생성
+}
+
+// ContinueJobResponse는 이어서 실행 요청 접수 응답입니다 (v1.5)
+type ContinueJobResponse struct {
+	JobID       string `json:"job_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentJobID string `json:"parent_job_id" example:"3f2c1a9e-0b7d-4c1e-9a55-2d6f0e8b1c44"`
+	Status      string `json:"status" example:"pending"`
+}
+
+// enqueueContinue는 원본 작업의 cursor-agent 세션을 이어서 실행하는 continue 작업을 큐에 등록합니다 (v1.5)
+//
+// 새 작업은 원본 작업의 프로젝트에서 실행되며 parent_job_id로 원본 작업과 연결됩니다.
+// PR 생성/승인 정책은 현재 프로젝트 설정을 따릅니다. 요청 정보(요청자, 채널, response_url)는 base에서 가져옵니다.
+func enqueueContinue(c *gin.Context, cfg *Config, parentID string, spec worker.PromptSpec, base *database.JobRecord) (*database.JobRecord, *database.JobRecord, error) {
+	parent, err := cfg.DB.GetJob(parentID)
+	if err != nil || parent == nil {
+		return nil, nil, errJobNotFound
+	}
+	if err := worker.CheckContinuable(parent, cfg.Agents); err != nil {
+		return nil, parent, fmt.Errorf("%w: %v", errJobNotContinuable, err)
+	}
+	if strings.TrimSpace(spec.Prompt) == "" {
+		return nil, parent, errEmptyPrompt
+	}
+	if spec.Project != "" && spec.Project != parent.ProjectName {
+		return nil, parent, errContinueProject
+	}
+	// v1.5: 채팅 세션은 원본 작업의 에이전트 백엔드에만 있으므로 다른 백엔드로 이어서 실행할 수 없음
+	if spec.Agent != "" && spec.Agent != parent.Agent {
+		return nil, parent, errContinueAgent
+	}
+	agent, err := cfg.Agents.Get(parent.Agent)
+	if err != nil {
+		return nil, parent, err
+	}
+	if err := checkRunOptions(cfg, agent, spec); err != nil {
+		return nil, parent, err
+	}
+
+	target := &jobTarget{ProjectName: parent.ProjectName, ProjectPath: parent.ProjectPath}
+	if parent.ProjectName != "" {
+		if project, err := cfg.DB.GetProject(parent.ProjectName); err == nil && project != nil {
+			target.Project = project
+		}
+	}
+
+	record := base
+	if record.ID == "" {
+		record.ID = uuid.NewString()
+	}
+	record.Kind = database.JobKindContinue
+	record.ParentJobID = parent.ID
+	record.Prompt = spec.Prompt
+	record.ProjectName = target.ProjectName
+	record.ProjectPath = target.ProjectPath
+	record.OpenPR = target.openPR(spec)
+	record.RequireApproval = target.requireApproval(spec)
+	if err := checkApprovalAgent(agent, record.RequireApproval); err != nil {
+		return nil, parent, &runOptionError{err: err}
+	}
+	record.Agent = parent.Agent
+	setRunOptions(cfg, record, target, spec)
+	record.CreatedAt = time.Now()
+
+	if err := enqueueJob(c, cfg, record); err != nil {
+		return nil, parent, err
+	}
+	log.Printf("[%s] 작업 %s 이어서 실행 요청 (세션: %s, 요청자: %s)", record.ID, parent.ID, parent.SessionID, record.UserID)
+	return record, parent, nil
+}
+
+// handleReplyCommand는 `/cursor reply <job-id> "프롬프트"`를 처리합니다 (v1.5)
+func handleReplyCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload, jobID string, text string) {
+	spec, err := worker.ParsePrompt(text)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"response_type": "ephemeral", "text": "❌ " + err.Error()})
+		return
+	}
+
+	record, parent, err := enqueueContinue(c, cfg, jobID, spec, &database.JobRecord{
+		UserID:       payload.UserID,
+		UserName:     payload.UserName,
+		ResponseURL:  payload.ResponseURL,
+		ChannelID:    payload.ChannelID,
+		ChannelName:  payload.ChannelName,
+		TeamID:       payload.TeamID,
+		EnterpriseID: payload.EnterpriseID,
+	})
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"response_type": "ephemeral", "text": continueErrorText(jobID, parent, err)})
+		return
+	}
+
+	ackText := continueAckText(record, parent)
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          ackText,
+		"blocks":        worker.MessageBlocks(ackText, record.ID, worker.ActionCancelJob),
+	})
+}
+
+// continueAckText는 이어서 실행 요청 접수 메시지입니다 (v1.5: 명령어/버튼 공용)
+func continueAckText(record *database.JobRecord, parent *database.JobRecord) string {
+	text := fmt.Sprintf("💬 작업 `%s`에 이어서 요청을 접수했습니다... (ID: `%s`)\n📁 대상: %s%s\n> %s",
+		parent.ID[:8], record.ID[:8], formatProject(record.ProjectName, record.ProjectPath), prNote(record.OpenPR), record.Prompt)
+	if record.RequireApproval {
+		text += "\n📝 승인이 필요한 프로젝트입니다. 실행 계획을 먼저 작성합니다."
+	}
+	return text
+}
+
+// continueErrorText는 이어서 실행 요청 실패를 Slack 메시지로 변환합니다 (v1.5)
+func continueErrorText(jobID string, parent *database.JobRecord, err error) string {
+	if text, rejected := rejectionText(err); rejected {
+		return text
+	}
+	switch {
+	case errors.Is(err, errJobNotFound):
+		return fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`\n\n💡 `/cursor list` 명령어로 최근 작업 목록을 확인하세요.", jobID)
+	case errors.Is(err, errJobNotContinuable):
+		return fmt.Sprintf("⚠️ `%s` %s", parent.ID[:8], err.Error())
+	case errors.Is(err, errEmptyPrompt):
+		return "❌ " + err.Error() + "\n사용법: `/cursor reply <job-id> \"프롬프트\"`"
+	case errors.Is(err, errContinueProject):
+		return fmt.Sprintf("❌ %s: %s", err.Error(), formatProject(parent.ProjectName, parent.ProjectPath))
+	case errors.Is(err, errContinueAgent):
+		return fmt.Sprintf("❌ %s: `%s`", err.Error(), parent.Agent)
+	case errors.Is(err, worker.ErrUnknownAgent), errors.As(err, new(*runOptionError)):
+		return "❌ " + err.Error()
+	default:
+		log.Printf("이어서 실행 요청 실패 (%s): %v", jobID, err)
+		return "❌ 작업을 등록하는 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요."
+	}
+}
+
+// conversationText는 `/cursor show`에 표시할 대화(이어서 실행한 작업) 흐름을 만듭니다 (v1.5)
+// 원본 작업부터 현재 작업까지, 그리고 현재 작업을 이어서 실행한 작업을 표시합니다. 대화가 없으면 빈 문자열을 반환합니다.
+func conversationText(cfg *Config, job *database.JobRecord) string {
+	chain := []*database.JobRecord{job}
+	for cur := job; cur.Kind == database.JobKindContinue && len(chain) < maxConversationDepth; {
+		parent, err := cfg.DB.GetJob(cur.ParentJobID)
+		if err != nil || parent == nil {
+			break
+		}
+		chain = append([]*database.JobRecord{parent}, chain...)
+		cur = parent
+	}
+
+	continuations, err := cfg.DB.ListJobContinuations(job.ID)
+	if err != nil {
+		log.Printf("이어진 작업 조회 실패 (%s): %v", job.ID, err)
+	}
+	if len(chain) == 1 && len(continuations) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("*대화:*\n")
+	if chain[0].Kind == database.JobKindContinue {
+		b.WriteString("  …\n")
+	}
+	for i, j := range chain {
+		current := ""
+		if j.ID == job.ID {
+			current = " ← 현재"
+		}
+		b.WriteString(fmt.Sprintf("  %d. %s `%s` %s%s\n", i+1, jobStatusEmoji(j.Status), j.ID[:8], truncatePrompt(cfg.Redactor.String(j.Prompt), 50), current))
+	}
+	for _, j := range continuations {
+		b.WriteString(fmt.Sprintf("  ↳ %s `%s` %s\n", jobStatusEmoji(j.Status), j.ID[:8], truncatePrompt(cfg.Redactor.String(j.Prompt), 50)))
+	}
+	return b.String()
+}
+
+// truncatePrompt는 목록 표시용으로 프롬프트를 줄입니다.
+func truncatePrompt(prompt string, n int) string {
+	runes := []rune(strings.ReplaceAll(prompt, "\n", " "))
+	if len(runes) > n {
+		return string(runes[:n-3]) + "..."
+	}
+	return string(runes)
+}
+
+// HandleContinueJob godoc
+// @Summary      작업 이어서 실행 (v1.5)
+// @Description  종료된 작업의 cursor-agent 채팅 세션을 재개(--resume)하여 후속 프롬프트를 실행하는 작업을 큐에 등록합니다.
+// @Description  새 작업은 kind=continue, parent_job_id=원본 작업으로 기록되며 원본 작업의 프로젝트에서 실행됩니다.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Job ID"
+// @Param        request  body      ContinueJobRequest   true  "후속 프롬프트"
+// @Success      202      {object}  ContinueJobResponse  "이어서 실행 작업 접수"
+// @Failure      400      {object}  ErrorResponse        "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse        "인증 실패"
+// @Failure      403      {object}  ErrorResponse        "권한 없음"
+// @Failure      404      {object}  ErrorResponse        "작업을 찾을 수 없음"
+// @Failure      409      {object}  ErrorResponse        "이어서 실행할 수 없는 작업"
+// @Failure      429      {object}  ErrorResponse        "요청 한도 초과"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/jobs/{id}/continue [post]
+func HandleContinueJob(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ContinueJobRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid JSON payload: " + err.Error()})
+			return
+		}
+		spec, err := worker.ParsePrompt(req.Prompt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if req.PR {
+			spec.OpenPR = true
+		}
+
+		if apiHidesJob(c, cfg, c.Param("id")) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: errJobNotFound.Error()})
+			return
+		}
+		record, _, err := enqueueContinue(c, cfg, c.Param("id"), spec, apiJobBase(c))
+		switch {
+		case errors.Is(err, errJobNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, errJobNotContinuable):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, errEmptyPrompt), errors.Is(err, errContinueProject), errors.Is(err, errContinueAgent),
+			errors.Is(err, worker.ErrUnknownAgent), errors.As(err, new(*runOptionError)):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case err != nil:
+			c.JSON(enqueueErrorStatus(c, err), ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusAccepted, ContinueJobResponse{
+				JobID:       record.ID,
+				ParentJobID: record.ParentJobID,
+				Status:      string(database.JobStatusPending),
+			})
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/server/middleware"
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// eventDedupeTTL은 같은 event_id를 중복으로 취급하는 기간입니다.
+// Slack은 3초 안에 응답을 받지 못하면 최대 3회(약 5분)까지 같은 이벤트를 다시 보냅니다.
+const eventDedupeTTL = 10 * time.Minute
+
+// leadingMentions는 메시지 앞의 사용자 멘션(`<@U123>` 또는 `<@U123|name>`)입니다.
+var leadingMentions = regexp.MustCompile(`^(\s*<@[A-Z0-9]+(\|[^>]*)?>)+\s*`)
+
+// eventHelpText는 멘션/DM 사용법입니다.
+const eventHelpText = "🤖 *Cursor AI 사용법*\n" +
+	"• `@cursor 프롬프트` - 이 스레드에서 작업을 요청하고 결과를 스레드에 받습니다\n" +
+	"• `@cursor @프로젝트 프롬프트 --pr` - 슬래시 명령어와 같은 옵션을 사용할 수 있습니다\n" +
+	"• 작업이 끝난 스레드에서 다시 멘션하면 같은 cursor-agent 대화를 이어서 실행합니다\n" +
+	"• 작업 관리는 `/cursor list`, `/cursor show <job-id>`, `/cursor cancel <job-id>`를 사용하세요"
+
+// eventDeduper는 Slack이 재전송한 이벤트를 걸러냅니다 (v1.5)
+type eventDeduper struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// firstSeen은 처음 받은 event_id이면 true를 반환합니다.
+func (d *eventDeduper) firstSeen(eventID string) bool {
+	if eventID == "" {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if d.seen == nil {
+		d.seen = make(map[string]time.Time)
+	}
+	for id, at := range d.seen {
+		if now.Sub(at) > eventDedupeTTL {
+			delete(d.seen, id)
+		}
+	}
+	if _, ok := d.seen[eventID]; ok {
+		return false
+	}
+	d.seen[eventID] = now
+	return true
+}
+
+// HandleSlackEvents godoc
+// @Summary      Slack Events API 처리 (v1.5)
+// @Description  url_verification 요청에 challenge를 응답하고, app_mention / message.im 이벤트를 작업으로 등록합니다.
+// @Description  "@cursor 프롬프트"처럼 멘션하면 작업 결과가 멘션한 스레드에 게시됩니다 (SLACK_BOT_TOKEN 필요).
+// @Description  작업이 끝난 스레드에서 다시 멘션하면 마지막 작업의 cursor-agent 세션을 이어서 실행합니다.
+// @Tags         slack
+// @Accept       json
+// @Produce      json
+// @Param        request  body      types.SlackEventEnvelope  true  "Events API 요청"
+// @Success      200      {object}  map[string]string         "challenge 응답 또는 빈 응답"
+// @Failure      400      {object}  ErrorResponse             "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse             "인증 실패"
+// @Security     SlackSignature
+// @Security     SlackTimestamp
+// @Router       /slack/events [post]
+func HandleSlackEvents(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var envelope types.SlackEventEnvelope
+		if err := c.ShouldBindJSON(&envelope); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid event payload"})
+			return
+		}
+
+		switch envelope.Type {
+		case "url_verification":
+			c.JSON(http.StatusOK, gin.H{"challenge": envelope.Challenge})
+			return
+		case "event_callback":
+		default:
+			c.Status(http.StatusOK)
+			return
+		}
+
+		if !cfg.events.firstSeen(envelope.EventID) {
+			log.Printf("재전송된 Slack 이벤트 무시: %s (재시도: %s)", envelope.EventID, c.GetHeader("X-Slack-Retry-Num"))
+			c.Status(http.StatusOK)
+			return
+		}
+
+		// Slack은 3초 안에 200 응답을 기대하므로 작업 등록과 스레드 응답은 별도로 처리
+		jobID := c.GetString(middleware.RequestIDKey)
+		if jobID == "" {
+			jobID = uuid.NewString()
+		}
+		go handleSlackEvent(cfg, envelope, jobID)
+		c.Status(http.StatusOK)
+	}
+}
+
+// handleSlackEvent는 멘션/DM 메시지를 작업으로 등록하고 접수 메시지를 스레드에 게시합니다 (v1.5)
+func handleSlackEvent(cfg *Config, envelope types.SlackEventEnvelope, jobID string) {
+	ev := envelope.Event
+	if ev.BotID != "" || ev.Subtype != "" || ev.User == "" {
+		// 봇 자신의 메시지, 수정/삭제 알림 등은 무시
+		return
+	}
+	if ev.Type != "app_mention" && !(ev.Type == "message" && ev.ChannelType == "im") {
+		return
+	}
+	if cfg.Slack == nil {
+		log.Printf("SLACK_BOT_TOKEN이 설정되지 않아 Slack 이벤트를 처리하지 않습니다: %s (%s)", ev.Type, envelope.EventID)
+		return
+	}
+
+	// 멘션한 메시지가 스레드 밖에 있으면 그 메시지를 부모로 새 스레드 시작
+	threadTS := ev.ThreadTS
+	if threadTS == "" {
+		threadTS = ev.TS
+	}
+	post := func(text string, blocks []types.SlackBlock) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := cfg.Slack.PostMessage(ctx, slack.Message{Channel: ev.Channel, Text: text, Blocks: blocks, ThreadTS: threadTS}); err != nil {
+			log.Printf("[%s] 스레드 메시지 게시 실패: %v", jobID, err)
+		}
+	}
+
+	text := strings.TrimSpace(leadingMentions.ReplaceAllString(ev.Text, ""))
+	if text == "" || text == "help" || text == "?" {
+		post(eventHelpText, nil)
+		return
+	}
+
+	base := &database.JobRecord{
+		ID:           jobID,
+		UserID:       ev.User,
+		ChannelID:    ev.Channel,
+		TeamID:       envelope.TeamID,
+		EnterpriseID: envelope.EnterpriseID,
+		ThreadTS:     threadTS,
+	}
+
+	spec, err := worker.ParsePrompt(text)
+	if err != nil {
+		post("❌ "+err.Error(), nil)
+		return
+	}
+
+	// 스레드의 마지막 작업이 끝났으면 같은 cursor-agent 세션을 이어서 실행
+	if ev.ThreadTS != "" {
+		previous, err := cfg.DB.GetLatestThreadJob(ev.Channel, ev.ThreadTS)
+		if err != nil {
+			log.Printf("[%s] 스레드 작업 조회 실패: %v", jobID, err)
+		}
+		if previous != nil && worker.CheckContinuable(previous, cfg.Agents) == nil {
+			record, parent, err := enqueueContinue(nil, cfg, previous.ID, spec, base)
+			if err != nil {
+				post(continueErrorText(previous.ID, parent, err), nil)
+				return
+			}
+			ackText := continueAckText(record, parent)
+			post(ackText, worker.MessageBlocks(ackText, record.ID, worker.ActionCancelJob))
+			return
+		}
+	}
+
+	target, err := resolveJobTarget(cfg, spec, envelope.TeamID, ev.Channel)
+	if err != nil {
+		post(targetErrorText(err), nil)
+		return
+	}
+
+	record := base
+	record.Prompt = spec.Prompt
+	record.ProjectName = target.ProjectName
+	record.ProjectPath = target.ProjectPath
+	record.OpenPR = target.openPR(spec)
+	record.RequireApproval = target.requireApproval(spec)
+	record.Agent = target.Agent
+	setRunOptions(cfg, record, target, spec)
+	record.CreatedAt = time.Now()
+	if err := enqueueJob(nil, cfg, record); err != nil {
+		if text, rejected := rejectionText(err); rejected {
+			log.Printf("[%s] 작업 등록 거부 (요청자: %s, 채널: %s): %v", jobID, ev.User, ev.Channel, err)
+			post(text, nil)
+			return
+		}
+		log.Printf("[%s] 작업 큐 등록 실패: %v", jobID, err)
+		post("❌ 작업을 등록하는 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요.", nil)
+		return
+	}
+	log.Printf("[%s] Slack %s 이벤트로 작업 등록 (요청자: %s, 채널: %s, 스레드: %s)", jobID, ev.Type, ev.User, ev.Channel, threadTS)
+
+	ackText := jobAckText(cfg, fmt.Sprintf("<@%s>", ev.User), record)
+	post(ackText, worker.MessageBlocks(ackText, record.ID, worker.ActionCancelJob))
+}
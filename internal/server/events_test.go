@@ -0,0 +1,312 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/slack/slacktest"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+func mentionEvent(eventID string, text string, threadTS string) types.SlackEventEnvelope {
+	return types.SlackEventEnvelope{
+		Type:    "event_callback",
+		TeamID:  "T1",
+		EventID: eventID,
+		Event: types.SlackEvent{
+			Type:     "app_mention",
+			User:     "U1",
+			Text:     "<@UBOT> " + text,
+			Channel:  "C1",
+			TS:       "1700000100.000100",
+			ThreadTS: threadTS,
+		},
+	}
+}
+
+func postEvent(t *testing.T, cfg *Config, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/slack/events", HandleSlackEvents(cfg))
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(string(payload)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// waitForMessages는 가짜 Slack 서버가 chat.postMessage를 n번 받을 때까지 기다립니다.
+func waitForMessages(t *testing.T, srv *slacktest.Server, n int) []map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		msgs := srv.Messages("chat.postMessage")
+		if len(msgs) >= n || time.Now().After(deadline) {
+			return msgs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandleSlackEventsURLVerification(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+
+	w := postEvent(t, cfg, map[string]string{"type": "url_verification", "challenge": "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, w.Body.String())
+	}
+	if resp["challenge"] != "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P" {
+		t.Errorf("challenge = %q", resp["challenge"])
+	}
+	if calls := srv.Calls(); len(calls) != 0 {
+		t.Errorf("url_verification은 Slack API를 호출하지 않아야 합니다: %v", calls)
+	}
+}
+
+func TestHandleSlackEventsIgnoresRetriedEvent(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+	envelope := mentionEvent("Ev001", "README 정리해줘", "")
+
+	if w := postEvent(t, cfg, envelope); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if msgs := waitForMessages(t, srv, 1); len(msgs) != 1 {
+		t.Fatalf("접수 메시지 = %d, want 1", len(msgs))
+	}
+
+	// Slack이 응답을 받지 못했다고 판단해 같은 event_id로 다시 보낸 경우
+	if w := postEvent(t, cfg, envelope); w.Code != http.StatusOK {
+		t.Fatalf("retry status = %d, want 200", w.Code)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if msgs := srv.Messages("chat.postMessage"); len(msgs) != 1 {
+		t.Errorf("재전송 후 접수 메시지 = %d, want 1", len(msgs))
+	}
+	if jobs := listTestJobs(t, cfg); len(jobs) != 1 {
+		t.Errorf("등록된 작업 = %d, want 1", len(jobs))
+	}
+
+	// 다른 event_id는 새 요청으로 처리
+	postEvent(t, cfg, mentionEvent("Ev002", "README 정리해줘", ""))
+	if msgs := waitForMessages(t, srv, 2); len(msgs) != 2 {
+		t.Errorf("새 이벤트 접수 메시지 = %d, want 2", len(msgs))
+	}
+}
+
+func TestHandleSlackEventStartsThread(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+
+	handleSlackEvent(cfg, mentionEvent("Ev001", "README 정리해줘", ""), "11111111-0000-0000-0000-000000000000")
+
+	jobs := listTestJobs(t, cfg)
+	if len(jobs) != 1 {
+		t.Fatalf("등록된 작업 = %d, want 1", len(jobs))
+	}
+	job := jobs[0]
+	if job.Prompt != "README 정리해줘" || job.UserID != "U1" || job.ChannelID != "C1" || job.TeamID != "T1" {
+		t.Errorf("job = %+v", job)
+	}
+	// 스레드 밖의 멘션은 그 메시지를 부모로 스레드를 시작
+	if job.ThreadTS != "1700000100.000100" {
+		t.Errorf("job.ThreadTS = %q, want 멘션 메시지 ts", job.ThreadTS)
+	}
+
+	msgs := srv.Messages("chat.postMessage")
+	if len(msgs) != 1 {
+		t.Fatalf("접수 메시지 = %d, want 1", len(msgs))
+	}
+	if msgs[0]["channel"] != "C1" || msgs[0]["thread_ts"] != "1700000100.000100" {
+		t.Errorf("접수 메시지 위치 = %v", msgs[0])
+	}
+	if text, _ := msgs[0]["text"].(string); !strings.Contains(text, "<@U1>") || !strings.Contains(text, "11111111") {
+		t.Errorf("접수 메시지 = %q", text)
+	}
+}
+
+func TestHandleSlackEventDirectMessage(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+
+	envelope := mentionEvent("Ev001", "", "")
+	envelope.Event.Type = "message"
+	envelope.Event.ChannelType = "im"
+	envelope.Event.Text = "테스트 추가해줘"
+	handleSlackEvent(cfg, envelope, "22222222-0000-0000-0000-000000000000")
+
+	if jobs := listTestJobs(t, cfg); len(jobs) != 1 || jobs[0].Prompt != "테스트 추가해줘" {
+		t.Errorf("DM 작업 = %v", jobs)
+	}
+	if msgs := srv.Messages("chat.postMessage"); len(msgs) != 1 {
+		t.Errorf("접수 메시지 = %d, want 1", len(msgs))
+	}
+}
+
+func TestHandleSlackEventContinuesThread(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	const threadTS = "1700000000.000001"
+
+	previous := &database.JobRecord{
+		ID:          "33333333-0000-0000-0000-000000000000",
+		Prompt:      "로그인 버그 수정해줘",
+		ProjectPath: repo,
+		UserID:      "U1",
+		ChannelID:   "C1",
+		ThreadTS:    threadTS,
+		CreatedAt:   time.Now().Add(-time.Minute),
+	}
+	if err := cfg.JobQueue.Enqueue(previous); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if err := cfg.DB.UpdateJobSession(previous.ID, "session-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.DB.UpdateJobStatus(previous.ID, database.JobStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+
+	// 스레드의 이전 작업이 세션을 남기고 끝났으면 같은 세션을 이어서 실행
+	handleSlackEvent(cfg, mentionEvent("Ev001", "테스트도 추가해줘", threadTS), "44444444-0000-0000-0000-000000000000")
+	cont, err := cfg.DB.GetJob("44444444-0000-0000-0000-000000000000")
+	if err != nil || cont == nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if cont.Kind != database.JobKindContinue || cont.ParentJobID != previous.ID {
+		t.Errorf("continue 작업: kind=%s parent=%q, want continue of %s", cont.Kind, cont.ParentJobID, previous.ID)
+	}
+	if cont.ThreadTS != threadTS || cont.ProjectPath != repo {
+		t.Errorf("continue 작업 스레드/경로 = %q %q", cont.ThreadTS, cont.ProjectPath)
+	}
+
+	// 스레드의 마지막 작업(continue 작업)이 아직 대기 중이면 이어서 실행하지 않고 새 작업으로 등록
+	handleSlackEvent(cfg, mentionEvent("Ev002", "문서도 고쳐줘", threadTS), "55555555-0000-0000-0000-000000000000")
+	job, err := cfg.DB.GetJob("55555555-0000-0000-0000-000000000000")
+	if err != nil || job == nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if job.Kind != database.JobKindAgent || job.ParentJobID != "" {
+		t.Errorf("대기 중인 작업 뒤의 요청: kind=%s parent=%q, want 새 agent 작업", job.Kind, job.ParentJobID)
+	}
+
+	msgs := srv.Messages("chat.postMessage")
+	if len(msgs) != 2 {
+		t.Fatalf("접수 메시지 = %d, want 2", len(msgs))
+	}
+	if msgs[0]["thread_ts"] != threadTS {
+		t.Errorf("이어서 실행 접수 메시지 thread_ts = %v, want %s", msgs[0]["thread_ts"], threadTS)
+	}
+	if text, _ := msgs[0]["text"].(string); !strings.Contains(text, "33333333") || !strings.Contains(text, "이어서") {
+		t.Errorf("이어서 실행 접수 메시지 = %q", text)
+	}
+}
+
+func TestHandleSlackEventWithoutResumeStartsNewJob(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	const threadTS = "1700000000.000001"
+
+	// 세션 ID는 보고하지만 resume_args가 없어 세션을 재개할 수 없는 백엔드
+	agent, err := worker.NewTemplateAgent("oneshot", worker.AgentTemplate{
+		Command: "oneshot",
+		Args:    []string{"{{prompt}}"},
+		Output:  worker.AgentOutputStreamJSON,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Agents.Register(agent); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := &database.JobRecord{
+		ID:          "33333333-0000-0000-0000-000000000000",
+		Prompt:      "로그인 버그 수정해줘",
+		ProjectPath: repo,
+		UserID:      "U1",
+		ChannelID:   "C1",
+		ThreadTS:    threadTS,
+		Agent:       "oneshot",
+		CreatedAt:   time.Now().Add(-time.Minute),
+	}
+	if err := cfg.JobQueue.Enqueue(previous); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	cfg.DB.UpdateJobSession(previous.ID, "session-1")
+	cfg.DB.UpdateJobStatus(previous.ID, database.JobStatusCompleted)
+
+	if _, _, err := enqueueContinue(nil, cfg, previous.ID, worker.PromptSpec{Prompt: "테스트도 추가해줘"}, &database.JobRecord{UserID: "U1"}); !errors.Is(err, errJobNotContinuable) {
+		t.Errorf("enqueueContinue error = %v, want errJobNotContinuable", err)
+	}
+
+	handleSlackEvent(cfg, mentionEvent("Ev001", "테스트도 추가해줘", threadTS), "44444444-0000-0000-0000-000000000000")
+	job, err := cfg.DB.GetJob("44444444-0000-0000-0000-000000000000")
+	if err != nil || job == nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if job.Kind != database.JobKindAgent || job.ParentJobID != "" {
+		t.Errorf("kind=%s parent=%q, want 새 agent 작업", job.Kind, job.ParentJobID)
+	}
+	if msgs := srv.Messages("chat.postMessage"); len(msgs) != 1 || msgs[0]["thread_ts"] != threadTS {
+		t.Errorf("접수 메시지 = %v", msgs)
+	}
+}
+
+func TestHandleSlackEventIgnoresBotAndEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func(ev *types.SlackEvent)
+	}{
+		{"bot message", func(ev *types.SlackEvent) { ev.BotID = "B1" }},
+		{"bot_message subtype", func(ev *types.SlackEvent) { ev.Subtype = "bot_message"; ev.User = "" }},
+		{"edited message", func(ev *types.SlackEvent) { ev.Subtype = "message_changed" }},
+		{"no user", func(ev *types.SlackEvent) { ev.User = "" }},
+		{"channel message without mention", func(ev *types.SlackEvent) { ev.Type = "message"; ev.ChannelType = "channel" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, srv := newTestConfig(t)
+			envelope := mentionEvent("Ev001", "README 정리해줘", "")
+			tt.event(&envelope.Event)
+
+			handleSlackEvent(cfg, envelope, "66666666-0000-0000-0000-000000000000")
+
+			if calls := srv.Calls(); len(calls) != 0 {
+				t.Errorf("Slack API calls = %v, want none", calls)
+			}
+			if jobs := listTestJobs(t, cfg); len(jobs) != 0 {
+				t.Errorf("등록된 작업 = %d, want 0", len(jobs))
+			}
+		})
+	}
+}
+
+func TestHandleSlackEventHelp(t *testing.T) {
+	cfg, srv := newTestConfig(t)
+
+	handleSlackEvent(cfg, mentionEvent("Ev001", "", ""), "77777777-0000-0000-0000-000000000000")
+
+	msgs := srv.Messages("chat.postMessage")
+	if len(msgs) != 1 || msgs[0]["text"] != eventHelpText {
+		t.Errorf("도움말 메시지 = %v", msgs)
+	}
+	if jobs := listTestJobs(t, cfg); len(jobs) != 0 {
+		t.Errorf("등록된 작업 = %d, want 0", len(jobs))
+	}
+}
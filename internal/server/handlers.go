@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,10 +10,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/kakaovx/cursor-slack-server/internal/database"
 	"github.com/kakaovx/cursor-slack-server/internal/server/middleware"
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
 	"github.com/kakaovx/cursor-slack-server/internal/types"
 	"github.com/kakaovx/cursor-slack-server/internal/worker"
 )
@@ -28,11 +31,25 @@ type ErrorResponse struct {
 	Error string `json:"error" example:"Invalid request payload"`
 }
 
+// API 요청으로 생성된 작업의 사용자 정보
+const (
+	apiUserID   = "api"
+	apiUserName = "api-user"
+)
+
 // APICursorRequest는 일반 API용 cursor 실행 요청 구조체입니다.
 // v1.1: 자연어 프롬프트 방식 (파일명을 프롬프트에 포함)
 type APICursorRequest struct {
-	Prompt string `json:"prompt" example:"main.go의 버그를 수정해줘" binding:"required"`
-	Async  bool   `json:"async" example:"false"`
+	Prompt  string `json:"prompt" example:"main.go의 버그를 수정해줘" binding:"required"`
+	Project string `json:"project,omitempty" example:"backend"` // v1.5: 등록된 프로젝트 이름 (프롬프트의 @이름보다 우선)
+	PR      bool   `json:"pr,omitempty" example:"false"` // v1.5: 완료 후 변경 사항으로 PR 생성 (프롬프트의 --pr과 동일)
+	Agent   string `json:"agent,omitempty" example:"cursor"` // v1.5: 실행할 에이전트 백엔드 (프롬프트의 --agent보다 우선)
+	Async   bool   `json:"async" example:"false"`
+
+	// v1.5: 에이전트 실행 옵션 (프롬프트의 --model, --timeout, --readonly보다 우선)
+	Model    string `json:"model,omitempty" example:"gpt-5"`    // 에이전트 모델 (에이전트별 허용 목록에 있어야 함)
+	Timeout  string `json:"timeout,omitempty" example:"30m"`    // 시간 제한 (Go duration 형식, 1분 이상, 서버 최대값 이하)
+	ReadOnly bool   `json:"readonly,omitempty" example:"false"` // 파일을 수정하지 않고 실행 (pr과 함께 사용할 수 없음)
 }
 
 // APICursorResponse는 일반 API용 cursor 실행 응답 구조체입니다.
@@ -68,6 +85,10 @@ type ProjectPathResponse struct {
 // @Param        user_id       formData  string  true   "Slack 사용자 ID"
 // @Param        response_url  formData  string  true   "지연 응답을 보낼 Slack Webhook URL"
 // @Param        trigger_id    formData  string  true   "Slack 트리거 ID"
+// @Param        channel_id    formData  string  false  "명령어를 실행한 채널 ID (채널 바인딩에 사용)"
+// @Param        channel_name  formData  string  false  "명령어를 실행한 채널 이름"
+// @Param        team_id       formData  string  false  "Slack 워크스페이스 ID"
+// @Param        enterprise_id formData  string  false  "Slack Enterprise Grid ID"
 // @Success      200  {object}  SlackImmediateResponse  "즉시 ACK 응답"
 // @Failure      400  {object}  ErrorResponse           "잘못된 요청"
 // @Failure      401  {object}  ErrorResponse           "인증 실패"
@@ -97,6 +118,17 @@ func HandleSlashCursor(cfg *Config) gin.HandlerFunc {
 		}
 		
 		command := parts[0]
+
+		// v1.5: 역할 기반 접근 제어 (작업 실행 권한은 enqueueJob에서 확인)
+		if err := requireRole(cfg, payload.UserID, commandRole(command, parts[1:])); err != nil {
+			text, _ := rejectionText(err)
+			log.Printf("[%s] 권한 부족으로 거부: /cursor %s (%v)", payload.UserID, command, err)
+			c.JSON(http.StatusOK, gin.H{
+				"response_type": "ephemeral",
+				"text":          text,
+			})
+			return
+		}
 		
 		// 명령어별 처리
 		switch command {
@@ -116,11 +148,61 @@ func HandleSlashCursor(cfg *Config) gin.HandlerFunc {
 				})
 				return
 			}
-			handleShowCommand(c, cfg, parts[1])
+			handleShowCommand(c, cfg, parts[1], payload.UserID)
 			return
 			
+		case "cancel", "stop":
+			if len(parts) < 2 {
+				c.JSON(http.StatusOK, gin.H{
+					"response_type": "ephemeral",
+					"text":          "❌ Job ID를 입력해주세요.\n사용법: `/cursor cancel <job-id>`",
+				})
+				return
+			}
+			handleCancelCommand(c, cfg, parts[1], payload.UserID)
+			return
+
+		case "revert", "undo":
+			if len(parts) < 2 {
+				c.JSON(http.StatusOK, gin.H{
+					"response_type": "ephemeral",
+					"text":          "❌ Job ID를 입력해주세요.\n사용법: `/cursor revert <job-id>`",
+				})
+				return
+			}
+			handleRevertCommand(c, cfg, payload, parts[1])
+			return
+
+		case "reply", "continue":
+			if len(parts) < 2 {
+				c.JSON(http.StatusOK, gin.H{
+					"response_type": "ephemeral",
+					"text":          "❌ Job ID를 입력해주세요.\n사용법: `/cursor reply <job-id> \"프롬프트\"`",
+				})
+				return
+			}
+			rest := strings.TrimSpace(strings.TrimPrefix(text, command))
+			handleReplyCommand(c, cfg, payload, parts[1], strings.TrimSpace(strings.TrimPrefix(rest, parts[1])))
+			return
+
+		case "approve", "reject":
+			handleReviewCommand(c, cfg, payload, parts[1:], command == "approve")
+			return
+
 		case "path", "get-path":
-			handlePathCommand(c, cfg)
+			handlePathCommand(c, cfg, payload)
+			return
+
+		case "project", "projects":
+			handleProjectCommand(c, cfg, parts[1:], payload.UserID)
+			return
+
+		case "bind", "unbind":
+			handleBindCommand(c, cfg, payload, parts[1:], command == "unbind")
+			return
+
+		case "admin":
+			handleAdminCommand(c, cfg, payload, parts[1:])
 			return
 			
 		case "set-path":
@@ -131,8 +213,21 @@ func HandleSlashCursor(cfg *Config) gin.HandlerFunc {
 				})
 				return
 			}
-			path := strings.TrimSpace(strings.TrimPrefix(text, "set-path "))
+			// v1.5: 경로 검증 (허용된 디렉토리, 존재 여부, git 저장소)
+			path, err := cfg.ValidateProjectPath(strings.TrimPrefix(text, "set-path "))
+			if err != nil {
+				c.JSON(http.StatusOK, gin.H{
+					"response_type": "ephemeral",
+					"text":          "❌ " + err.Error(),
+				})
+				return
+			}
+			before, _ := cfg.GetProjectPath()
 			cfg.SetProjectPath(path)
+			recordAudit(cfg, c, database.AuditEvent{
+				ActorID: payload.UserID, ActorName: payload.UserName,
+				Action: database.AuditSetPath, Before: before, After: path,
+			})
 			log.Printf("[%s] Slack을 통해 프로젝트 경로 설정: %s", payload.UserID, path)
 			c.JSON(http.StatusOK, gin.H{
 				"response_type": "ephemeral",
@@ -141,45 +236,82 @@ func HandleSlashCursor(cfg *Config) gin.HandlerFunc {
 			return
 		}
 
-		// 1. 즉시 응답 (ACK) - 3초 룰 준수
-		c.JSON(http.StatusOK, gin.H{
-			"response_type": "ephemeral",
-			"text":          fmt.Sprintf("⏳ %s님의 요청을 접수했습니다. 작업을 처리 중입니다...\n💡 최대 대기시간: 15분", payload.UserName),
-		})
+		// v1.5: @프로젝트 지정 해석 및 실행 대상 결정
+		spec, err := worker.ParsePrompt(text)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"response_type": "ephemeral",
+				"text":          "❌ " + err.Error(),
+			})
+			return
+		}
+		target, err := resolveJobTarget(cfg, spec, payload.TeamID, payload.ChannelID)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"response_type": "ephemeral",
+				"text":          targetErrorText(err),
+			})
+			return
+		}
 
-		// 2. Worker Pool을 통해 작업 제출 (v1.4)
+		// 1. Job ID 발급 (v1.4)
 		reqID, exists := c.Get(middleware.RequestIDKey)
 		if !exists {
 			reqID = uuid.NewString()
 		}
 		jobID := reqID.(string)
 
-		// Job 생성 및 큐에 제출 (ConfigFull wrapper 생성)
-		job := worker.Job{
-			ID:         jobID,
-			Payload:    payload,
-			ReceivedAt: time.Now(),
-			Config:     cfg.ToWorkerConfig(),
+		// 2. 작업 큐에 등록 (v1.5: job_records에 pending으로 저장되어 재시작 후에도 유지됨)
+		jobRecord := &database.JobRecord{
+			ID:              jobID,
+			Prompt:          spec.Prompt,
+			ProjectName:     target.ProjectName,
+			ProjectPath:     target.ProjectPath,
+			OpenPR:          target.openPR(spec),
+			RequireApproval: target.requireApproval(spec),
+			Agent:           target.Agent,
+			UserID:          payload.UserID,
+			UserName:        payload.UserName,
+			ResponseURL:     payload.ResponseURL,
+			ChannelID:       payload.ChannelID,
+			ChannelName:     payload.ChannelName,
+			TeamID:          payload.TeamID,
+			EnterpriseID:    payload.EnterpriseID,
+			CreatedAt:       time.Now(),
+		}
+		setRunOptions(cfg, jobRecord, target, spec)
+		// v1.5: 권한이 없거나 사용자/채널 요청 한도를 넘으면 등록하지 않음
+		if err := enqueueJob(c, cfg, jobRecord); err != nil {
+			text, rejected := rejectionText(err)
+			if rejected {
+				log.Printf("[%s] 작업 등록 거부 (요청자: %s, 채널: %s): %v", jobID, payload.UserID, payload.ChannelID, err)
+			} else {
+				log.Printf("[%s] 작업 큐 등록 실패: %v", jobID, err)
+				text = "❌ 작업을 등록하는 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요."
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"response_type": "ephemeral",
+				"text":          text,
+			})
+			return
 		}
 
-		// 비동기로 큐에 제출 (큐가 가득 차면 블록될 수 있음)
-		go func() {
-			// 서버 종료 시 JobQueue가 닫힐 수 있으므로 panic 방지
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("[%s] ⚠️ 작업 큐가 닫혀서 제출 실패: %v", jobID, r)
-				}
-			}()
-			
-			cfg.JobQueue <- job
-			log.Printf("[%s] 작업이 큐에 제출되었습니다.", jobID)
-		}()
+		// 3. 즉시 응답 (ACK) - 3초 룰 준수 (v1.5: 취소 버튼 포함)
+		ackText := jobAckText(cfg, payload.UserName, jobRecord)
+		c.JSON(http.StatusOK, gin.H{
+			"response_type": "ephemeral",
+			"text":          ackText,
+			"blocks":        worker.MessageBlocks(ackText, jobID, worker.ActionCancelJob),
+		})
 	}
 }
 
+// SyncWaitMargin은 동기 모드 API 요청이 작업 시간 제한보다 더 기다리는 시간입니다 (v1.5: 큐 대기, 프로세스 종료, 결과 저장)
+const SyncWaitMargin = time.Minute
+
 // HandleAPICursor godoc
 // @Summary      일반 API를 통한 Cursor Agent 실행 (v1.1)
-// @Description  JSON 형식으로 cursor-agent를 실행합니다. Slack 인증이 필요 없습니다.
+// @Description  JSON 형식으로 cursor-agent를 실행합니다. Slack 서명 대신 API 키(v1.5: run 권한)로 인증합니다.
 // @Description  v1.1: 자연어 프롬프트 방식 (파일명을 프롬프트에 직접 포함)
 // @Description  async=false: 동기 실행 (결과를 즉시 반환)
 // @Description  async=true: 비동기 실행 (job_id만 반환, 결과는 별도 조회 필요 - 원 단계에서 구현)
@@ -189,7 +321,12 @@ func HandleSlashCursor(cfg *Config) gin.HandlerFunc {
 // @Param        request  body      APICursorRequest  true  "Cursor 실행 요청"
 // @Success      200      {object}  APICursorResponse "실행 성공"
 // @Failure      400      {object}  ErrorResponse     "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse     "인증 실패"
+// @Failure      403      {object}  ErrorResponse     "권한 없음"
+// @Failure      429      {object}  ErrorResponse     "요청 한도 초과"
 // @Failure      500      {object}  ErrorResponse     "서버 오류"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
 // @Router       /api/cursor [post]
 func HandleAPICursor(cfg *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -201,61 +338,66 @@ func HandleAPICursor(cfg *Config) gin.HandlerFunc {
 		}
 
 		jobID := uuid.NewString()
-		log.Printf("[%s] API 요청: prompt='%s', async=%v", jobID, req.Prompt, req.Async)
+		log.Printf("[%s] API 요청: prompt='%s', async=%v", jobID, cfg.Redactor.String(req.Prompt), req.Async)
 
-		// 프로젝트 경로 확인 (v1.2)
-		projectPath, isSet := cfg.GetProjectPath()
-		if !isSet {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error: "프로젝트 경로가 설정되지 않았습니다. POST /api/config/project-path로 경로를 먼저 설정해주세요.",
-			})
+		// 프로젝트 경로 확인 (v1.2, v1.5: @이름 또는 project 필드로 프로젝트 지정)
+		spec, err := worker.ParsePrompt(req.Prompt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 			return
 		}
-
-		// v1.3: DB에 작업 저장
-		jobRecord := &database.JobRecord{
-			ID:          jobID,
-			Prompt:      req.Prompt,
-			ProjectPath: projectPath,
-			Status:      database.JobStatusPending,
-			CreatedAt:   time.Now(),
+		if req.Project != "" {
+			spec.Project = strings.ToLower(strings.TrimPrefix(req.Project, "@"))
+		}
+		if req.PR {
+			spec.OpenPR = true
+		}
+		if req.Agent != "" {
+			spec.Agent = strings.ToLower(req.Agent)
 		}
-		if err := cfg.DB.CreateJob(jobRecord); err != nil {
-			log.Printf("[%s] DB 작업 생성 실패: %v", jobID, err)
+		if req.Model != "" {
+			spec.Model = req.Model
+		}
+		if req.Timeout != "" {
+			if spec.Timeout, err = worker.ParseTimeout(req.Timeout); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+				return
+			}
+		}
+		if req.ReadOnly {
+			spec.ReadOnly = true
+		}
+		if err := spec.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		target, err := resolveJobTarget(cfg, spec, "", "")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
 		}
 
+		// v1.3: DB에 작업 저장
 		// v1.4: Worker Pool을 통해 작업 제출
 		// API는 항상 비동기로 처리 (동시 실행 제어를 위해)
 		// 동기 모드 요청도 Worker Pool을 통해 처리하되, 결과는 DB에서 조회해야 함
-		
-		// SlackCommandPayload 형식으로 변환 (API 요청용)
-		slackPayload := types.SlackCommandPayload{
-			Text:        req.Prompt,
-			UserName:    "api-user",
-			UserID:      "api",
-			ResponseURL: "", // API는 response_url이 없음
-		}
-
-		// Job 생성 및 큐에 제출 (ConfigFull wrapper 생성)
-		job := worker.Job{
-			ID:         jobID,
-			Payload:    slackPayload,
-			ReceivedAt: time.Now(),
-			Config:     cfg.ToWorkerConfig(),
-		}
-
-		// 비동기로 큐에 제출
-		go func() {
-			// 서버 종료 시 JobQueue가 닫힐 수 있으므로 panic 방지
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("[%s] ⚠️ 작업 큐가 닫혀서 제출 실패: %v", jobID, r)
-				}
-			}()
-			
-			cfg.JobQueue <- job
-			log.Printf("[%s] API 작업이 큐에 제출되었습니다.", jobID)
-		}()
+		// v1.5: 작업 큐가 job_records 기반이므로 저장과 제출이 하나의 단계로 처리됨
+		// v1.5: 요청한 API 키를 작업에 기록
+		jobRecord := apiJobBase(c)
+		jobRecord.ID = jobID
+		jobRecord.Prompt = spec.Prompt
+		jobRecord.ProjectName = target.ProjectName
+		jobRecord.ProjectPath = target.ProjectPath
+		jobRecord.OpenPR = target.openPR(spec)
+		jobRecord.RequireApproval = target.requireApproval(spec)
+		jobRecord.Agent = target.Agent
+		setRunOptions(cfg, jobRecord, target, spec)
+		jobRecord.CreatedAt = time.Now()
+		if err := enqueueJob(c, cfg, jobRecord); err != nil {
+			log.Printf("[%s] 작업 큐 등록 실패: %v", jobID, err)
+			c.JSON(enqueueErrorStatus(c, err), ErrorResponse{Error: "작업 등록 실패: " + err.Error()})
+			return
+		}
 
 		// 비동기 모드: job_id만 즉시 반환
 		if req.Async {
@@ -267,10 +409,10 @@ func HandleAPICursor(cfg *Config) gin.HandlerFunc {
 			return
 		}
 
-		// 동기 모드: 작업 완료까지 대기 (최대 15분)
+		// 동기 모드: 작업 완료까지 대기 (v1.5: 최대 작업 시간 제한 + SyncWaitMargin)
 		// 주의: 이 방식은 HTTP 연결을 오래 유지하므로 권장하지 않지만,
 		// 기존 API 호환성을 위해 유지
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), jobTimeout(cfg, jobRecord)+SyncWaitMargin)
 		defer cancel()
 
 		// DB에서 작업 완료 대기 (폴링)
@@ -309,6 +451,37 @@ func HandleAPICursor(cfg *Config) gin.HandlerFunc {
 					return
 				}
 
+				if jobRecord.Status == database.JobStatusCancelled {
+					c.JSON(http.StatusOK, APICursorResponse{
+						Status:  "cancelled",
+						Message: fmt.Sprintf("작업이 취소되었습니다 (요청자: %s)", jobRecord.CancelledBy),
+						Output:  jobRecord.Output,
+						JobID:   jobID,
+					})
+					return
+				}
+
+				// v1.5: 승인이 필요한 작업은 계획 작성 후 승인을 기다리지 않고 반환
+				if jobRecord.Status == database.JobStatusAwaitingApproval {
+					c.JSON(http.StatusAccepted, APICursorResponse{
+						Status:  "awaiting_approval",
+						Message: "실행 계획이 작성되어 승인을 기다리고 있습니다. 승인 후 GET /api/jobs/{id}로 결과를 조회하세요.",
+						Output:  jobRecord.Plan,
+						JobID:   jobID,
+					})
+					return
+				}
+
+				if jobRecord.Status == database.JobStatusRejected {
+					c.JSON(http.StatusOK, APICursorResponse{
+						Status:  "rejected",
+						Message: fmt.Sprintf("실행 계획이 거절되었습니다 (승인자: %s)", jobRecord.ReviewedBy),
+						Output:  jobRecord.Plan,
+						JobID:   jobID,
+					})
+					return
+				}
+
 				if jobRecord.Status == database.JobStatusFailed {
 					c.JSON(http.StatusInternalServerError, APICursorResponse{
 						Status:  "error",
@@ -337,6 +510,10 @@ func HandleAPICursor(cfg *Config) gin.HandlerFunc {
 // @Tags         config
 // @Produce      json
 // @Success      200  {object}  ProjectPathResponse  "프로젝트 경로 정보"
+// @Failure      401  {object}  ErrorResponse        "인증 실패"
+// @Failure      403  {object}  ErrorResponse        "권한 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
 // @Router       /api/config/project-path [get]
 func HandleGetProjectPath(cfg *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -363,12 +540,18 @@ func HandleGetProjectPath(cfg *Config) gin.HandlerFunc {
 // @Summary      프로젝트 경로 설정 (v1.2)
 // @Description  cursor-agent가 실행될 프로젝트 경로를 설정합니다.
 // @Description  이 경로는 런타임에 동적으로 변경 가능합니다.
+// @Description  v1.5: 심볼릭 링크와 `..`를 해석한 실제 경로가 존재하는 git 저장소이고 허용된 디렉토리(ALLOWED_PROJECT_ROOTS) 아래에 있어야 합니다.
+// @Description  v1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).
 // @Tags         config
 // @Accept       json
 // @Produce      json
 // @Param        request  body      ProjectPathRequest   true  "프로젝트 경로"
 // @Success      200      {object}  ProjectPathResponse  "경로 설정 성공"
 // @Failure      400      {object}  ErrorResponse        "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse        "인증 실패"
+// @Failure      403      {object}  ErrorResponse        "권한 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
 // @Router       /api/config/project-path [post]
 func HandleSetProjectPath(cfg *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -379,18 +562,21 @@ func HandleSetProjectPath(cfg *Config) gin.HandlerFunc {
 			return
 		}
 
-		// 경로 유효성 검사 (간단히 비어있지 않은지만 확인)
-		if strings.TrimSpace(req.Path) == "" {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "프로젝트 경로는 비어있을 수 없습니다."})
+		// 경로 유효성 검사 (v1.5: 허용된 디렉토리, 존재 여부, git 저장소)
+		path, err := cfg.ValidateProjectPath(req.Path)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 			return
 		}
 
 		// 경로 설정
-		cfg.SetProjectPath(req.Path)
-		log.Printf("프로젝트 경로가 설정되었습니다: %s", req.Path)
+		before, _ := cfg.GetProjectPath()
+		cfg.SetProjectPath(path)
+		recordAudit(cfg, c, database.AuditEvent{Action: database.AuditSetPath, Before: before, After: path})
+		log.Printf("[%s] 프로젝트 경로가 설정되었습니다: %s", apiActor(c), path)
 
 		c.JSON(http.StatusOK, ProjectPathResponse{
-			Path:    req.Path,
+			Path:    path,
 			IsSet:   true,
 			Message: "프로젝트 경로가 성공적으로 설정되었습니다.",
 		})
@@ -404,7 +590,11 @@ func HandleSetProjectPath(cfg *Config) gin.HandlerFunc {
 // @Produce      json
 // @Param        id   path      string  true  "Job ID"
 // @Success      200  {object}  database.JobRecord  "작업 결과"
+// @Failure      401  {object}  ErrorResponse       "인증 실패"
+// @Failure      403  {object}  ErrorResponse       "권한 없음"
 // @Failure      404  {object}  ErrorResponse       "작업을 찾을 수 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
 // @Router       /api/jobs/{id} [get]
 func HandleGetJob(cfg *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -416,15 +606,232 @@ func HandleGetJob(cfg *Config) gin.HandlerFunc {
 			return
 		}
 
-		if job == nil {
+		// v1.5: API 키에 프로젝트 허용 목록이 있으면 목록 밖의 작업은 찾을 수 없는 작업으로 처리
+		if job == nil || !apiCanViewJob(c, job) {
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "작업을 찾을 수 없습니다."})
 			return
 		}
 
+		recordAudit(cfg, c, database.AuditEvent{Action: database.AuditJobView, Target: job.ID})
 		c.JSON(http.StatusOK, job)
 	}
 }
 
+// 작업 취소 관련 에러 (v1.5)
+var (
+	errJobNotFound       = errors.New("작업을 찾을 수 없습니다")
+	errJobNotCancellable = errors.New("이미 종료된 작업은 취소할 수 없습니다")
+)
+
+// cancelJob은 대기 중인 작업을 큐에서 제외하거나 실행 중인 작업의 프로세스 그룹을 종료합니다 (v1.5)
+// Slack 명령어와 API가 공통으로 사용합니다. userID는 권한 확인에, cancelledBy는 취소 요청자 기록에 사용합니다.
+// 프로젝트 허용 목록 때문에 볼 수 없는 작업은 존재 여부를 드러내지 않도록 찾을 수 없는 작업으로 처리합니다.
+func cancelJob(cfg *Config, jobID string, userID string, cancelledBy string) (*database.JobRecord, error) {
+	job, err := cfg.DB.GetJob(jobID)
+	if err != nil || job == nil {
+		return nil, errJobNotFound
+	}
+	if !canViewJob(cfg, userID, job) {
+		return nil, errJobNotFound
+	}
+
+	if job.Status != database.JobStatusPending && job.Status != database.JobStatusRunning && job.Status != database.JobStatusAwaitingApproval {
+		return job, errJobNotCancellable
+	}
+
+	// 1. DB 상태를 먼저 cancelled로 변경 (대기 중인 작업은 Worker가 꺼낼 때 건너뜀)
+	cancelled, err := cfg.DB.CancelJob(job.ID, cancelledBy)
+	if err != nil {
+		return nil, fmt.Errorf("작업 취소 실패: %w", err)
+	}
+	if !cancelled {
+		// 그 사이에 작업이 종료됨 (다시 조회하지 못하면 조회한 작업으로 응답)
+		if refreshed, err := cfg.DB.GetJob(job.ID); err == nil && refreshed != nil {
+			job = refreshed
+		}
+		return job, errJobNotCancellable
+	}
+
+	// 2. 실행 중인 작업이면 프로세스 그룹 종료
+	// 조회 후 UPDATE 전에 Worker가 작업을 가져갔을 수 있으므로 조회한 상태와 관계없이 항상 호출
+	// (실행 목록에 없는 작업이면 아무것도 하지 않고, 등록 직전이면 Run이 등록 후 취소 상태를 다시 확인함)
+	if cfg.Executor != nil {
+		if !cfg.Executor.Cancel(job.ID, cancelledBy) && job.Status == database.JobStatusRunning {
+			log.Printf("[%s] 실행 중인 프로세스를 찾지 못했습니다 (이미 종료되었을 수 있음)", job.ID)
+		}
+	}
+
+	log.Printf("[%s] 작업 취소됨 (요청자: %s, 이전 상태: %s)", job.ID, cancelledBy, job.Status)
+
+	updated, err := cfg.DB.GetJob(job.ID)
+	if err != nil || updated == nil {
+		return job, nil
+	}
+	return updated, nil
+}
+
+// HandleCancelJob godoc
+// @Summary      작업 취소 (v1.5)
+// @Description  대기 중인 작업은 큐에서 제외하고, 실행 중인 작업은 프로세스 그룹을 종료합니다.
+// @Description  취소된 작업은 cancelled 상태와 취소 요청자/시간이 기록됩니다.
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  database.JobRecord  "취소된 작업"
+// @Failure      401  {object}  ErrorResponse       "인증 실패"
+// @Failure      403  {object}  ErrorResponse       "권한 없음"
+// @Failure      404  {object}  ErrorResponse       "작업을 찾을 수 없음"
+// @Failure      409  {object}  ErrorResponse       "이미 종료된 작업"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/jobs/{id} [delete]
+func HandleCancelJob(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiHidesJob(c, cfg, c.Param("id")) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: errJobNotFound.Error()})
+			return
+		}
+		job, err := cancelJob(cfg, c.Param("id"), apiUserID, apiActor(c))
+		if err == nil {
+			recordAudit(cfg, c, database.AuditEvent{Action: database.AuditJobCancel, Target: job.ID})
+		}
+		switch {
+		case errors.Is(err, errJobNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, errJobNotCancellable):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: fmt.Sprintf("%s (상태: %s)", err.Error(), job.Status)})
+		case err != nil:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusOK, job)
+		}
+	}
+}
+
+// JobStreamEvent는 작업 스트리밍(SSE) 이벤트의 데이터 구조체입니다 (v1.5)
+type JobStreamEvent struct {
+	JobID  string             `json:"job_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status database.JobStatus `json:"status" example:"running"`
+	Offset int                `json:"offset" example:"128"`
+	Text   string             `json:"text,omitempty" example:"main.go를 수정하고 있습니다..."`
+	Error  string             `json:"error,omitempty"`
+}
+
+// streamPollInterval은 SSE 스트리밍 시 DB를 확인하는 주기입니다.
+const streamPollInterval = 500 * time.Millisecond
+
+// HandleStreamJob godoc
+// @Summary      작업 출력 실시간 스트리밍 (v1.5)
+// @Description  Server-Sent Events로 실행 중인 작업의 출력을 실시간으로 전송합니다.
+// @Description  이벤트 종류: status(상태 변경), output(새로 추가된 출력), reset(출력 재전송), done(작업 종료)
+// @Description  재연결 시 offset 쿼리(또는 Last-Event-ID 헤더)로 이미 받은 출력 길이를 전달하면 이어서 받을 수 있습니다.
+// @Tags         jobs
+// @Produce      text/event-stream
+// @Param        id      path      string  true   "Job ID"
+// @Param        offset  query     int     false  "이미 수신한 출력 길이 (기본값: 0)"
+// @Success      200     {object}  JobStreamEvent  "SSE 이벤트 스트림"
+// @Failure      401     {object}  ErrorResponse   "인증 실패"
+// @Failure      403     {object}  ErrorResponse   "권한 없음"
+// @Failure      404     {object}  ErrorResponse   "작업을 찾을 수 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/jobs/{id}/stream [get]
+func HandleStreamJob(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jobID := c.Param("id")
+
+		job, err := cfg.DB.GetJob(jobID)
+		if err != nil || job == nil || !apiCanViewJob(c, job) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "작업을 찾을 수 없습니다."})
+			return
+		}
+		recordAudit(cfg, c, database.AuditEvent{Action: database.AuditJobView, Target: job.ID})
+
+		offset := 0
+		if o := c.Query("offset"); o != "" {
+			if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+				offset = parsed
+			}
+		} else if o := c.GetHeader("Last-Event-ID"); o != "" {
+			if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+				offset = parsed
+			}
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no") // 프록시(nginx 등) 버퍼링 방지
+
+		// 이미 보낸 출력 (재연결 시 offset까지는 보냈다고 간주)
+		// offset이 출력보다 길면 처음부터 다시 전송하도록 reset을 보냄
+		resync := offset > len(job.Output)
+		sent := ""
+		if !resync {
+			sent = job.Output[:textutil.RuneStart(job.Output, offset)]
+		}
+
+		var lastStatus database.JobStatus
+		ticker := time.NewTicker(streamPollInterval)
+		defer ticker.Stop()
+
+		for {
+			// 1. 상태 변경 전송
+			if job.Status != lastStatus {
+				lastStatus = job.Status
+				c.Render(-1, sse.Event{
+					Event: "status",
+					Data:  JobStreamEvent{JobID: job.ID, Status: job.Status, Offset: len(sent)},
+				})
+			}
+
+			// 2. 새 출력 전송
+			// 완료 시 부분 출력이 최종 결과로 바뀌는 등 보낸 출력으로 시작하지 않으면 처음부터 다시 전송
+			if resync || !strings.HasPrefix(job.Output, sent) {
+				resync = false
+				sent = ""
+				c.Render(-1, sse.Event{
+					Event: "reset",
+					Data:  JobStreamEvent{JobID: job.ID, Status: job.Status},
+				})
+			}
+			if len(job.Output) > len(sent) {
+				text := job.Output[len(sent):]
+				sent = job.Output
+				c.Render(-1, sse.Event{
+					Id:    strconv.Itoa(len(sent)),
+					Event: "output",
+					Data:  JobStreamEvent{JobID: job.ID, Status: job.Status, Offset: len(sent), Text: text},
+				})
+			}
+			c.Writer.Flush()
+
+			// 3. 작업이 끝났으면 종료
+			if job.Status == database.JobStatusCompleted || job.Status == database.JobStatusFailed || job.Status == database.JobStatusCancelled || job.Status == database.JobStatusRejected {
+				c.Render(-1, sse.Event{
+					Event: "done",
+					Data:  JobStreamEvent{JobID: job.ID, Status: job.Status, Offset: len(sent), Error: job.Error},
+				})
+				c.Writer.Flush()
+				return
+			}
+
+			select {
+			case <-c.Request.Context().Done():
+				// 클라이언트 연결 종료
+				return
+			case <-ticker.C:
+			}
+
+			job, err = cfg.DB.GetJob(jobID)
+			if err != nil || job == nil {
+				log.Printf("[%s] 스트리밍 중 작업 조회 실패: %v", jobID, err)
+				return
+			}
+		}
+	}
+}
+
 // JobListQuery는 작업 목록 조회 쿼리 파라미터입니다
 type JobListQuery struct {
 	Limit  int                `form:"limit" example:"10"`
@@ -435,13 +842,18 @@ type JobListQuery struct {
 // HandleListJobs godoc
 // @Summary      작업 목록 조회 (v1.3)
 // @Description  작업 목록을 조회합니다. 상태별 필터링과 페이지네이션을 지원합니다.
+// @Description  v1.5: API 키에 프로젝트 허용 목록이 있으면 허용된 프로젝트의 작업만 반환하며, limit/offset도 반환되는 작업 기준입니다.
 // @Tags         jobs
 // @Produce      json
 // @Param        limit   query     int     false  "조회할 개수 (기본값: 10)"
 // @Param        offset  query     int     false  "건너뛸 개수 (기본값: 0)"
-// @Param        status  query     string  false  "작업 상태 필터 (pending/running/completed/failed)"
+// @Param        status  query     string  false  "작업 상태 필터 (pending/running/awaiting_approval/completed/failed/cancelled/rejected)"
 // @Success      200     {array}   database.JobRecord  "작업 목록"
 // @Failure      400     {object}  ErrorResponse       "잘못된 요청"
+// @Failure      401     {object}  ErrorResponse       "인증 실패"
+// @Failure      403     {object}  ErrorResponse       "권한 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
 // @Router       /api/jobs [get]
 func HandleListJobs(cfg *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -462,20 +874,51 @@ func HandleListJobs(cfg *Config) gin.HandlerFunc {
 
 		status := database.JobStatus(c.Query("status"))
 
-		jobs, err := cfg.DB.ListJobs(limit, offset, status)
+		// v1.5: API 키에 프로젝트 허용 목록이 있으면 목록 밖의 작업은 제외 (offset도 보이는 작업 기준)
+		jobs, err := listVisibleJobs(cfg, limit, offset, status, func(job *database.JobRecord) bool {
+			return apiCanViewJob(c, job)
+		})
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "작업 목록 조회 실패: " + err.Error()})
 			return
 		}
 
-		if jobs == nil {
-			jobs = []*database.JobRecord{}
-		}
-
 		c.JSON(http.StatusOK, jobs)
 	}
 }
 
+// listVisibleJobsBatch는 listVisibleJobs가 한 번에 조회하는 작업 수입니다.
+const listVisibleJobsBatch = 100
+
+// listVisibleJobs는 최근 작업 중 visible이 true인 작업을 offset개 건너뛰고 최대 limit개 반환합니다 (v1.5)
+// LIMIT/OFFSET으로 조회한 뒤 걸러내면 보이지 않는 작업만큼 페이지가 짧아지므로, limit개를 모을 때까지 이어서 조회합니다.
+func listVisibleJobs(cfg *Config, limit int, offset int, status database.JobStatus, visible func(*database.JobRecord) bool) ([]*database.JobRecord, error) {
+	jobs := []*database.JobRecord{}
+	skipped := 0
+	for from := 0; ; from += listVisibleJobsBatch {
+		batch, err := cfg.DB.ListJobs(listVisibleJobsBatch, from, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range batch {
+			if !visible(job) {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			jobs = append(jobs, job)
+			if len(jobs) == limit {
+				return jobs, nil
+			}
+		}
+		if len(batch) < listVisibleJobsBatch {
+			return jobs, nil
+		}
+	}
+}
+
 // Slack 명령어 핸들러 함수들
 
 // handleHelpCommand shows available commands
@@ -483,17 +926,39 @@ func handleHelpCommand(c *gin.Context) {
 	helpText := "📚 *Cursor AI 사용 가이드*\n\n" +
 		"*🎯 코드 작업 요청:*\n" +
 		"`/cursor \"프롬프트\"`\n" +
-		"예: `/cursor \"main.go의 버그를 수정해줘\"`\n\n" +
+		"`/cursor @프로젝트 \"프롬프트\"`\n" +
+		"`/cursor \"프롬프트\" --pr` - 완료 후 변경 사항으로 PR 생성\n" +
+		"`/cursor --agent=<이름> \"프롬프트\"` - 다른 에이전트 백엔드로 실행 (기본값: cursor)\n" +
+		"`/cursor --model <모델> --timeout 10m --readonly \"프롬프트\"` - 모델(허용 목록), 시간 제한, 읽기 전용 실행 선택\n" +
+		"예: `/cursor @backend \"main.go의 버그를 수정해줘\"`\n\n" +
 		"*🔧 설정 명령어:*\n" +
-		"• `/cursor set-path <경로>` - 프로젝트 경로 설정\n" +
-		"• `/cursor path` - 현재 프로젝트 경로 확인\n\n" +
+		"• `/cursor set-path <경로>` - 기본 프로젝트 경로 설정 (@ 미지정 시 사용)\n" +
+		"• `/cursor path` - 현재 기본 프로젝트 경로 확인\n" +
+		"• `/cursor project add <이름> <경로>` - 이름 있는 프로젝트 등록\n" +
+		"• `/cursor project remove <이름>` - 프로젝트 삭제\n" +
+		"• `/cursor project list` - 등록된 프로젝트 목록\n" +
+		"• `/cursor project set <이름> pr on|off` - 프로젝트 작업마다 자동 PR 생성\n" +
+		"• `/cursor project set <이름> approval on|off` - 파일 수정 전 실행 계획 승인 필요\n" +
+		"• `/cursor project set <이름> reviewers <@사용자>...` - 프로젝트 승인자 지정 (사용자 그룹 가능, `none`으로 해제)\n" +
+		"• `/cursor project set <이름> agent <에이전트>` - 프로젝트 작업을 실행할 에이전트 지정 (`default`로 해제)\n" +
+		"• `/cursor project set <이름> timeout <시간>` - 프로젝트 작업 시간 제한 (예: `30m`, `default`로 해제)\n" +
+		"• `/cursor bind <경로|@프로젝트>` - 이 채널의 기본 프로젝트 지정 (`unbind`로 해제)\n\n" +
+		"*🔐 권한 관리 (admin):*\n" +
+		"• `/cursor admin roles` - 역할 목록 (viewer: 조회, operator: 작업 실행, admin: 설정 변경)\n" +
+		"• `/cursor admin grant <@사용자|@그룹> <역할>` - 역할 부여 (`revoke`로 해제)\n" +
+		"• `/cursor admin allow <@프로젝트> <@사용자|@그룹>...` - 프로젝트에서 작업할 수 있는 사용자 제한 (`none`으로 해제)\n\n" +
 		"*📋 작업 조회:*\n" +
 		"• `/cursor list` - 최근 작업 목록 보기 (최근 10개)\n" +
-		"• `/cursor show <job-id>` - 특정 작업 결과 상세 보기\n\n" +
+		"• `/cursor show <job-id>` - 특정 작업 결과 상세 보기\n" +
+		"• `/cursor cancel <job-id>` - 대기 중이거나 실행 중인 작업 취소\n" +
+		"• `/cursor revert <job-id>` - 완료된 작업의 변경 사항을 실행 전 상태로 되돌리기\n" +
+		"• `/cursor reply <job-id> \"프롬프트\"` - 작업의 cursor-agent 대화를 이어서 후속 요청\n" +
+		"• `/cursor approve <job-id>` - 승인 대기 중인 작업의 실행 계획 승인 (승인자만)\n" +
+		"• `/cursor reject <job-id> [사유]` - 실행 계획 거절 (승인자만)\n\n" +
 		"*❓ 도움말:*\n" +
 		"• `/cursor help` - 이 도움말 표시\n\n" +
 		"💡 *사용 팁:*\n" +
-		"1. 처음 사용 시 `set-path`로 프로젝트 경로 설정\n" +
+		"1. 처음 사용 시 `project add`로 프로젝트 등록 (또는 `set-path`로 기본 경로 설정)\n" +
 		"2. 자연어로 편하게 요청하세요\n" +
 		"3. 작업 ID는 `list` 명령어로 확인 가능"
 
@@ -514,7 +979,10 @@ func handleListCommand(c *gin.Context, cfg *Config, userID string) {
 	}
 
 	// Get user's recent jobs (최근 10개)
-	jobs, err := cfg.DB.ListJobs(10, 0, "")
+	// v1.5: 허용 목록이 있는 프로젝트의 작업은 허용된 사용자에게만 표시
+	jobs, err := listVisibleJobs(cfg, 10, 0, "", func(job *database.JobRecord) bool {
+		return canViewJob(cfg, userID, job)
+	})
 	if err != nil {
 		log.Printf("작업 목록 조회 실패: %v", err)
 		c.JSON(http.StatusOK, gin.H{
@@ -538,31 +1006,34 @@ func handleListCommand(c *gin.Context, cfg *Config, userID string) {
 
 	for _, job := range jobs {
 		// Status emoji
-		var statusEmoji string
-		switch job.Status {
-		case "completed":
-			statusEmoji = "✅"
-		case "failed":
-			statusEmoji = "❌"
-		case "running":
-			statusEmoji = "⏳"
-		case "pending":
-			statusEmoji = "🕐"
-		default:
-			statusEmoji = "❓"
-		}
+		statusEmoji := jobStatusEmoji(job.Status)
 
 		// Time ago
 		timeAgo := timeAgoString(job.CreatedAt)
 		
 		// Truncate prompt if too long
-		prompt := job.Prompt
+		prompt := cfg.Redactor.String(job.Prompt)
 		if len(prompt) > 50 {
-			prompt = prompt[:47] + "..."
+			prompt = textutil.Truncate(prompt, 47) + "..."
+		}
+
+		// v1.5: 이름 있는 프로젝트 표시
+		project := ""
+		if job.ProjectName != "" {
+			project = fmt.Sprintf(" `@%s`", job.ProjectName)
+		}
+
+		// v1.5: 되돌린 작업 / 이어서 실행한 작업 표시
+		reverted := ""
+		if job.RevertedBy != "" {
+			reverted = " ↩️"
+		}
+		if job.Kind == database.JobKindContinue {
+			reverted += fmt.Sprintf(" 💬 `%s`에 이어서", job.ParentJobID[:8])
 		}
 
-		response.WriteString(fmt.Sprintf("%s `%s` - \"%s\" (%s)\n", 
-			statusEmoji, job.ID[:8], prompt, timeAgo))
+		response.WriteString(fmt.Sprintf("%s `%s`%s - \"%s\" (%s)%s\n", 
+			statusEmoji, job.ID[:8], project, prompt, timeAgo, reverted))
 	}
 
 	response.WriteString("\n💡 *결과 확인:* `/cursor show <job-id>`")
@@ -573,8 +1044,30 @@ func handleListCommand(c *gin.Context, cfg *Config, userID string) {
 	})
 }
 
+// jobStatusEmoji는 작업 상태를 목록 표시용 이모지로 변환합니다.
+func jobStatusEmoji(status database.JobStatus) string {
+	switch status {
+	case "completed":
+		return "✅"
+	case "failed":
+		return "❌"
+	case "running":
+		return "⏳"
+	case "pending":
+		return "🕐"
+	case "cancelled":
+		return "🛑"
+	case "awaiting_approval":
+		return "📝"
+	case "rejected":
+		return "🚫"
+	default:
+		return "❓"
+	}
+}
+
 // handleShowCommand shows job details
-func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
+func handleShowCommand(c *gin.Context, cfg *Config, jobID string, userID string) {
 	if cfg.DB == nil {
 		c.JSON(http.StatusOK, gin.H{
 			"response_type": "ephemeral",
@@ -603,6 +1096,16 @@ func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
 		return
 	}
 
+	// v1.5: 허용 목록이 있는 프로젝트의 작업은 허용된 사용자만 조회
+	if !canViewJob(cfg, userID, job) {
+		c.JSON(http.StatusOK, gin.H{
+			"response_type": "ephemeral",
+			"text":          fmt.Sprintf("🔒 `@%s` 프로젝트의 작업은 조회할 수 없습니다.", job.ProjectName),
+		})
+		return
+	}
+	recordAudit(cfg, c, database.AuditEvent{ActorID: userID, Action: database.AuditJobView, Target: job.ID})
+
 	// Status emoji and text
 	var statusEmoji, statusText string
 	switch job.Status {
@@ -618,6 +1121,15 @@ func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
 	case "pending":
 		statusEmoji = "🕐"
 		statusText = "대기 중"
+	case "cancelled":
+		statusEmoji = "🛑"
+		statusText = "취소됨"
+	case "awaiting_approval":
+		statusEmoji = "📝"
+		statusText = "승인 대기 중"
+	case "rejected":
+		statusEmoji = "🚫"
+		statusText = "거절됨"
 	default:
 		statusEmoji = "❓"
 		statusText = "알 수 없음"
@@ -626,8 +1138,80 @@ func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
 	// Build response
 	var response strings.Builder
 	response.WriteString(fmt.Sprintf("📦 *작업 결과* (ID: `%s`)\n\n", job.ID[:8]))
-	response.WriteString(fmt.Sprintf("*프롬프트:* \"%s\"\n", job.Prompt))
+	response.WriteString(fmt.Sprintf("*프롬프트:* \"%s\"\n", cfg.Redactor.String(job.Prompt)))
 	response.WriteString(fmt.Sprintf("*상태:* %s %s\n", statusEmoji, statusText))
+	if job.ProjectName != "" || job.ProjectPath != "" {
+		response.WriteString(fmt.Sprintf("*프로젝트:* %s\n", formatProject(job.ProjectName, job.ProjectPath)))
+	}
+	if job.Agent != "" {
+		// v1.5: 에이전트 백엔드
+		response.WriteString(fmt.Sprintf("*에이전트:* `%s`\n", job.Agent))
+	}
+	if job.Model != "" {
+		response.WriteString(fmt.Sprintf("*모델:* `%s`\n", job.Model))
+	}
+	if job.TimeoutSeconds > 0 {
+		response.WriteString(fmt.Sprintf("*시간 제한:* %s\n", worker.FormatDuration(jobTimeout(cfg, job))))
+	}
+	if job.ReadOnly {
+		response.WriteString("*읽기 전용:* 예 (파일을 수정하지 않음)\n")
+	}
+	if job.Branch != "" {
+		// v1.5: worktree 격리 모드 / PR 브랜치
+		response.WriteString(fmt.Sprintf("*브랜치:* `%s`\n", job.Branch))
+	}
+	if job.WorktreePath != "" {
+		response.WriteString(fmt.Sprintf("*Worktree:* `%s`\n", job.WorktreePath))
+	}
+	if job.Kind == database.JobKindRevert {
+		// v1.5: 되돌리기 작업
+		response.WriteString(fmt.Sprintf("*되돌린 작업:* `%s`\n", job.ParentJobID[:8]))
+	} else if job.Kind == database.JobKindContinue {
+		// v1.5: 이어서 실행한 작업은 아래 대화 흐름에 표시
+	} else if job.ParentJobID != "" {
+		// v1.5: 다시 실행한 작업
+		response.WriteString(fmt.Sprintf("*원본 작업:* `%s`\n", job.ParentJobID[:8]))
+	}
+	if job.RevertedBy != "" {
+		response.WriteString(fmt.Sprintf("*되돌림:* `%s` 작업으로 되돌려짐\n", job.RevertedBy[:8]))
+	}
+	if job.PRURL != "" {
+		response.WriteString(fmt.Sprintf("*PR:* %s\n", job.PRURL))
+	}
+	if job.ReviewedBy != "" {
+		// v1.5: 실행 계획 승인/거절
+		review := "승인"
+		if job.Status == database.JobStatusRejected {
+			review = "거절"
+		}
+		response.WriteString(fmt.Sprintf("*%s:* %s", review, formatActor(job.ReviewedBy)))
+		if job.ReviewedAt != nil {
+			response.WriteString(fmt.Sprintf(" (%s)", job.ReviewedAt.Format("2006-01-02 15:04:05")))
+		}
+		response.WriteString("\n")
+		if job.ReviewNote != "" {
+			response.WriteString(fmt.Sprintf("*거절 사유:* %s\n", job.ReviewNote))
+		}
+	}
+	if job.CheckpointAfter != "" {
+		// v1.5: 실행 전후 스냅샷 기준 변경 파일
+		response.WriteString(fmt.Sprintf("*변경된 파일:* %d개\n", len(job.ChangedFiles)))
+		for i, f := range job.ChangedFiles {
+			if i == maxShownChangedFiles {
+				response.WriteString(fmt.Sprintf("  … 외 %d개\n", len(job.ChangedFiles)-maxShownChangedFiles))
+				break
+			}
+			response.WriteString(fmt.Sprintf("  • `%s` (%s)\n", f.Path, f.Status))
+		}
+	}
+	if conversation := conversationText(cfg, job); conversation != "" {
+		// v1.5: 이어서 실행한 작업 흐름
+		response.WriteString(conversation)
+	}
+	if job.RedactionCount > 0 {
+		// v1.5: 출력/diff/계획에서 가린 비밀 값
+		response.WriteString(fmt.Sprintf("*가린 비밀 값:* %d개\n", job.RedactionCount))
+	}
 	response.WriteString(fmt.Sprintf("*생성 시간:* %s\n", job.CreatedAt.Format("2006-01-02 15:04:05")))
 	
 	// v1.4.1: 올바른 소요 시간 계산 (completed_at - started_at)
@@ -650,13 +1234,30 @@ func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
 	if job.Status == "completed" && job.Output != "" {
 		output := job.Output
 		if len(output) > 1000 {
-			output = output[:997] + "..."
+			output = textutil.Truncate(output, 997) + "..."
 		}
 		// 마크다운 렌더링을 위해 코드블록 제거
 		response.WriteString(fmt.Sprintf("\n📝 *출력:*\n%s", output))
 	} else if job.Status == "failed" && job.Error != "" {
 		// 에러는 코드블록 유지 (에러 메시지는 일반 텍스트)
 		response.WriteString(fmt.Sprintf("\n❌ *오류:*\n```\n%s\n```", job.Error))
+	} else if (job.Status == "awaiting_approval" || job.Status == "rejected") && job.Plan != "" {
+		// v1.5: 승인 요청 시 제시한 실행 계획
+		plan := job.Plan
+		if len(plan) > 1000 {
+			plan = textutil.Truncate(plan, 997) + "..."
+		}
+		response.WriteString(fmt.Sprintf("\n🗒️ *실행 계획:*\n%s", plan))
+		if job.Status == "awaiting_approval" {
+			response.WriteString(fmt.Sprintf("\n\n💡 승인: `/cursor approve %s` · 거절: `/cursor reject %s [사유]`", job.ID[:8], job.ID[:8]))
+		}
+	} else if job.Status == "cancelled" {
+		// v1.5: 취소 정보
+		response.WriteString(fmt.Sprintf("*취소:* %s", formatActor(job.CancelledBy)))
+		if job.CancelledAt != nil {
+			response.WriteString(fmt.Sprintf(" (%s)", job.CancelledAt.Format("2006-01-02 15:04:05")))
+		}
+		response.WriteString("\n")
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -665,11 +1266,110 @@ func handleShowCommand(c *gin.Context, cfg *Config, jobID string) {
 	})
 }
 
+// maxShownChangedFiles는 `/cursor show`에 표시하는 변경 파일 최대 개수입니다.
+const maxShownChangedFiles = 20
+
+// handleCancelCommand cancels a pending or running job (v1.5)
+func handleCancelCommand(c *gin.Context, cfg *Config, jobID string, userID string) {
+	job, err := cancelJob(cfg, jobID, userID, userID)
+	if err == nil {
+		recordAudit(cfg, c, database.AuditEvent{ActorID: userID, Action: database.AuditJobCancel, Target: job.ID})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          cancelResultText(cfg, jobID, job, err, userID),
+	})
+}
+
+// cancelResultText는 취소 요청 결과를 Slack 메시지로 변환합니다 (v1.5: 명령어/버튼 공용)
+func cancelResultText(cfg *Config, jobID string, job *database.JobRecord, err error, userID string) string {
+	switch {
+	case errors.Is(err, errJobNotFound):
+		return fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`\n\n💡 `/cursor list` 명령어로 최근 작업 목록을 확인하세요.", jobID)
+	case errors.Is(err, errJobNotCancellable):
+		return fmt.Sprintf("⚠️ 이미 종료된 작업은 취소할 수 없습니다: `%s` (상태: %s)", job.ID[:8], job.Status)
+	case err != nil:
+		log.Printf("작업 취소 실패 (%s): %v", jobID, err)
+		return "❌ 작업을 취소하는 중 오류가 발생했습니다."
+	default:
+		log.Printf("[%s] Slack을 통해 작업 취소: %s", userID, job.ID)
+		return fmt.Sprintf("🛑 작업이 취소되었습니다: `%s`\n> %s", job.ID[:8], cfg.Redactor.String(job.Prompt))
+	}
+}
+
+// jobAckText는 작업 접수 메시지입니다 (v1.5: 슬래시 명령어/멘션 공용)
+func jobAckText(cfg *Config, requester string, record *database.JobRecord) string {
+	text := fmt.Sprintf("⏳ %s님의 요청을 접수했습니다. 작업을 처리 중입니다...\n📁 대상: %s\n💡 최대 대기시간: %s\n🛑 취소하려면: `/cursor cancel %s`",
+		requester, formatProject(record.ProjectName, record.ProjectPath)+prNote(record.OpenPR), worker.FormatDuration(jobTimeout(cfg, record)), record.ID[:8])
+	if record.Agent != "" && record.Agent != worker.DefaultAgentName {
+		text += fmt.Sprintf("\n🤖 에이전트: `%s`", record.Agent)
+	}
+	if record.Model != "" {
+		text += fmt.Sprintf("\n🧠 모델: `%s`", record.Model)
+	}
+	if record.ReadOnly {
+		text += "\n👀 읽기 전용으로 실행합니다 (파일을 수정하지 않음)"
+	}
+	if record.RequireApproval {
+		text += "\n📝 이 프로젝트는 승인이 필요합니다. 먼저 실행 계획을 작성하고, 승인 후 파일을 수정합니다."
+	}
+	return text
+}
+
+// jobTimeout은 작업의 실행 시간 제한입니다 (v1.5: 요청 시점에 기록한 값, 기록되지 않은 이전 작업은 JOB_TIMEOUT)
+func jobTimeout(cfg *Config, record *database.JobRecord) time.Duration {
+	if record.TimeoutSeconds > 0 {
+		return time.Duration(record.TimeoutSeconds) * time.Second
+	}
+	return cfg.JobTimeout
+}
+
+// formatActor는 작업 요청자/취소자를 Slack 표시 형식으로 변환합니다.
+// Slack user_id는 멘션으로, 그 외(api 등)는 그대로 표시합니다.
+func formatActor(actor string) string {
+	if strings.HasPrefix(actor, "U") || strings.HasPrefix(actor, "W") {
+		return fmt.Sprintf("<@%s>", actor)
+	}
+	return fmt.Sprintf("`%s`", actor)
+}
+
+// prNote는 PR 생성 예정인 작업의 접수 메시지에 붙일 문구입니다 (v1.5)
+func prNote(openPR bool) string {
+	if openPR {
+		return " (완료 후 PR 생성)"
+	}
+	return ""
+}
+
+// formatProject는 작업 대상 프로젝트를 Slack 표시 형식으로 변환합니다 (v1.5)
+func formatProject(name string, path string) string {
+	if name != "" {
+		return fmt.Sprintf("`@%s` (`%s`)", name, path)
+	}
+	return fmt.Sprintf("`%s`", path)
+}
+
 // handlePathCommand shows current project path
-func handlePathCommand(c *gin.Context, cfg *Config) {
+// v1.5: 채널에 바인딩된 프로젝트가 있으면 함께 표시합니다.
+func handlePathCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload) {
 	path, isSet := cfg.GetProjectPath()
+
+	var bindingText string
+	if payload.ChannelID != "" {
+		if binding, err := cfg.DB.GetChannelBinding(payload.TeamID, payload.ChannelID); err == nil && binding != nil {
+			bindingText = fmt.Sprintf("📌 *이 채널의 프로젝트* (우선 적용)\n%s\n\n", formatProject(binding.ProjectName, binding.ProjectPath))
+		}
+	}
 	
 	if !isSet || path == "" {
+		if bindingText != "" {
+			c.JSON(http.StatusOK, gin.H{
+				"response_type": "ephemeral",
+				"text":          bindingText + "ℹ️ 기본 프로젝트 경로는 설정되지 않았습니다.",
+			})
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{
 			"response_type": "ephemeral",
 			"text":          "❌ 프로젝트 경로가 설정되지 않았습니다.\n\n💡 설정하기: `/cursor set-path /path/to/project`",
@@ -679,7 +1379,7 @@ func handlePathCommand(c *gin.Context, cfg *Config) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"response_type": "ephemeral",
-		"text":          fmt.Sprintf("📁 *현재 프로젝트 경로*\n`%s`\n\n💡 변경하기: `/cursor set-path <새경로>`", path),
+		"text":          bindingText + fmt.Sprintf("📁 *현재 프로젝트 경로*\n`%s`\n\n💡 변경하기: `/cursor set-path <새경로>`", path),
 	})
 }
 
@@ -728,7 +1428,20 @@ func HandleSlackOptions(cfg *Config) gin.HandlerFunc {
 			{Text: "list - 최근 작업 목록", Value: "list"},
 			{Text: "path - 현재 경로 확인", Value: "path"},
 			{Text: "set-path <경로> - 프로젝트 경로 설정", Value: "set-path "},
+			{Text: "project list - 등록된 프로젝트 목록", Value: "project list"},
+			{Text: "project add <이름> <경로> - 프로젝트 등록", Value: "project add "},
+			{Text: "project remove <이름> - 프로젝트 삭제", Value: "project remove "},
+			{Text: "bind <경로|@프로젝트> - 채널 기본 프로젝트 지정", Value: "bind "},
+			{Text: "unbind - 채널 바인딩 해제", Value: "unbind"},
+			{Text: "admin roles - 역할 목록", Value: "admin roles"},
+			{Text: "admin grant <@사용자> <역할> - 역할 부여", Value: "admin grant "},
+			{Text: "admin allow <@프로젝트> <@사용자>... - 프로젝트 허용 목록", Value: "admin allow "},
 			{Text: "show <job-id> - 작업 결과 보기", Value: "show "},
+			{Text: "cancel <job-id> - 작업 취소", Value: "cancel "},
+			{Text: "revert <job-id> - 작업 변경 사항 되돌리기", Value: "revert "},
+			{Text: "reply <job-id> \"프롬프트\" - 작업에 이어서 요청", Value: "reply "},
+			{Text: "approve <job-id> - 실행 계획 승인", Value: "approve "},
+			{Text: "reject <job-id> [사유] - 실행 계획 거절", Value: "reject "},
 		}
 
 		c.JSON(http.StatusOK, SlackOptionsResponse{Options: options})
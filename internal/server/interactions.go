@@ -0,0 +1,268 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// 다시 실행 관련 에러 (v1.5)
+var (
+	errJobNotRetryable = errors.New("아직 종료되지 않은 작업은 다시 실행할 수 없습니다")
+	errRetryTarget     = errors.New("원본 작업의 프로젝트에서 다시 실행할 수 없습니다")
+)
+
+// retryJob은 종료된 작업을 같은 프롬프트/대상/옵션으로 다시 큐에 등록합니다 (v1.5)
+// 새 작업의 parent_job_id에 원본 작업 ID를 기록합니다. 요청 정보는 base에서 가져옵니다.
+//
+// 원본 작업 이후 디렉토리가 삭제되었거나 ALLOWED_PROJECT_ROOTS, 모델 허용 목록, JOB_TIMEOUT_MAX가
+// 바뀌었을 수 있으므로 새 요청과 같은 검증(ValidateProjectPath, checkRunOptions)을 다시 거칩니다.
+// 비밀 값을 가린 프롬프트는 원문이 남아 있지 않으므로 다시 실행하지 않습니다 (worker.ErrPromptUnavailable).
+func retryJob(c *gin.Context, cfg *Config, original *database.JobRecord, base *database.JobRecord) (*database.JobRecord, error) {
+	if original.Status == database.JobStatusPending || original.Status == database.JobStatusRunning ||
+		original.Status == database.JobStatusAwaitingApproval {
+		return nil, errJobNotRetryable
+	}
+	if original.PromptRedacted {
+		return nil, worker.ErrPromptUnavailable
+	}
+
+	path, err := cfg.ValidateProjectPath(original.ProjectPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errRetryTarget, err)
+	}
+	agent, err := cfg.Agents.Get(original.Agent)
+	if err != nil {
+		return nil, err
+	}
+	spec := worker.PromptSpec{
+		Model:    original.Model,
+		Timeout:  time.Duration(original.TimeoutSeconds) * time.Second,
+		ReadOnly: original.ReadOnly,
+	}
+	if err := checkRunOptions(cfg, agent, spec); err != nil {
+		return nil, err
+	}
+
+	record := base
+	record.ID = uuid.NewString()
+	record.Kind = database.JobKindAgent
+	record.ParentJobID = original.ID
+	record.Prompt = original.Prompt
+	record.ProjectName = original.ProjectName
+	record.ProjectPath = path
+	record.OpenPR = original.OpenPR
+	record.RequireApproval = original.RequireApproval
+	record.Agent = agent.Name()
+	record.Model = original.Model
+	record.TimeoutSeconds = original.TimeoutSeconds
+	record.ReadOnly = original.ReadOnly
+	record.CreatedAt = time.Now()
+
+	// 승인 정책은 현재 프로젝트 설정을 따름 (원본 이후 승인이 필요해진 경우 포함, 읽기 전용 작업 제외)
+	if original.ProjectName != "" && !original.ReadOnly {
+		if project, err := cfg.DB.GetProject(original.ProjectName); err == nil && project != nil {
+			record.RequireApproval = project.RequireApproval
+		}
+	}
+	if err := checkApprovalAgent(agent, record.RequireApproval); err != nil {
+		return nil, &runOptionError{err: err}
+	}
+
+	if err := enqueueJob(c, cfg, record); err != nil {
+		return nil, err
+	}
+	log.Printf("[%s] 작업 %s 다시 실행 요청 (요청자: %s)", record.ID, original.ID, record.UserID)
+	return record, nil
+}
+
+// interactionJobBase는 버튼을 누른 사용자/채널 정보로 새 작업 레코드의 기본값을 만듭니다.
+// 결과는 인터랙션의 response_url로 전송됩니다.
+func interactionJobBase(payload types.SlackInteractionPayload) *database.JobRecord {
+	record := &database.JobRecord{
+		UserID:      payload.User.ID,
+		UserName:    payload.User.Username,
+		ResponseURL: payload.ResponseURL,
+		ChannelID:   payload.Channel.ID,
+		ChannelName: payload.Channel.Name,
+		TeamID:      payload.Team.ID,
+	}
+	if payload.Enterprise != nil {
+		record.EnterpriseID = payload.Enterprise.ID
+	}
+	return record
+}
+
+// HandleSlackInteractions godoc
+// @Summary      Slack 인터랙션 (버튼) 처리 (v1.5)
+// @Description  작업 메시지의 버튼(block_actions)을 처리합니다: 취소, 다시 실행, 전체 출력, 되돌리기, 승인, 거절.
+// @Description  Slack은 3초 안에 200 응답을 기대하므로 결과는 인터랙션의 response_url로 전송합니다.
+// @Tags         slack
+// @Accept       application/x-www-form-urlencoded
+// @Param        payload  formData  string  true  "block_actions payload JSON"
+// @Success      200  "접수됨"
+// @Failure      400  {object}  ErrorResponse  "잘못된 요청"
+// @Failure      401  {object}  ErrorResponse  "인증 실패"
+// @Security     SlackSignature
+// @Security     SlackTimestamp
+// @Router       /slack/interactions [post]
+func HandleSlackInteractions(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var payload types.SlackInteractionPayload
+		if err := json.Unmarshal([]byte(c.PostForm("payload")), &payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interaction payload"})
+			return
+		}
+
+		if payload.Type == "block_actions" {
+			for _, action := range payload.Actions {
+				handleJobAction(c, cfg, payload, action)
+			}
+		} else {
+			log.Printf("처리하지 않는 Slack 인터랙션 타입: %s", payload.Type)
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+// handleJobAction은 작업 버튼 하나를 처리하고 결과를 response_url로 전송합니다 (v1.5)
+func handleJobAction(c *gin.Context, cfg *Config, payload types.SlackInteractionPayload, action types.SlackBlockAction) {
+	jobID := action.Value
+	userID := payload.User.ID
+	log.Printf("[%s] Slack 버튼 클릭: %s (작업: %s)", userID, action.ActionID, jobID)
+
+	var reply *types.SlackDelayedResponse
+	switch action.ActionID {
+	case worker.ActionCancelJob:
+		// v1.5: 다시 실행/되돌리기는 enqueueJob에서, 승인/거절은 승인자 목록으로 권한을 확인
+		if err := requireRole(cfg, userID, database.RoleOperator); err != nil {
+			text, _ := rejectionText(err)
+			reply = ephemeralReply(text)
+			break
+		}
+		job, err := cancelJob(cfg, jobID, userID, userID)
+		if err == nil {
+			recordAudit(cfg, c, database.AuditEvent{ActorID: userID, ActorName: payload.User.Username, Action: database.AuditJobCancel, Target: job.ID})
+		}
+		reply = ephemeralReply(cancelResultText(cfg, jobID, job, err, userID))
+
+	case worker.ActionRevertJob:
+		record, target, err := enqueueRevert(c, cfg, jobID, interactionJobBase(payload))
+		reply = ephemeralReply(revertResultText(jobID, record, target, err))
+
+	case worker.ActionRetryJob:
+		reply = retryReply(c, cfg, payload, jobID)
+
+	case worker.ActionApproveJob, worker.ActionRejectJob:
+		approve := action.ActionID == worker.ActionApproveJob
+		job, err := reviewJob(cfg, jobID, userID, approve, "", payload.ResponseURL)
+		if err == nil {
+			recordAudit(cfg, c, reviewAuditEvent(userID, payload.User.Username, job, approve, ""))
+		}
+		reply = reviewResult(cfg, jobID, job, err, approve)
+
+	case worker.ActionShowOutput:
+		if err := requireRole(cfg, userID, database.RoleViewer); err != nil {
+			text, _ := rejectionText(err)
+			reply = ephemeralReply(text)
+			break
+		}
+		job, err := cfg.DB.GetJob(jobID)
+		if err != nil || job == nil {
+			reply = ephemeralReply(fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`", jobID))
+			break
+		}
+		if !canViewJob(cfg, userID, job) {
+			reply = ephemeralReply(fmt.Sprintf("🔒 `@%s` 프로젝트의 작업은 조회할 수 없습니다.", job.ProjectName))
+			break
+		}
+		recordAudit(cfg, c, database.AuditEvent{ActorID: userID, ActorName: payload.User.Username, Action: database.AuditJobView, Target: job.ID})
+		if cfg.Executor != nil {
+			go cfg.Executor.SendJobOutput(payload.ResponseURL, job)
+		}
+
+	default:
+		log.Printf("[%s] 알 수 없는 action_id: %s", userID, action.ActionID)
+	}
+
+	if reply != nil && cfg.Executor != nil && payload.ResponseURL != "" {
+		go func() {
+			if err := cfg.Executor.PostDelayedResponse(payload.ResponseURL, *reply); err != nil {
+				log.Printf("[%s] 버튼 응답 전송 실패: %v", userID, err)
+			}
+		}()
+	}
+}
+
+// retryReply는 다시 실행 버튼을 처리합니다. revert 작업은 같은 대상 작업을 다시 되돌리고,
+// continue 작업은 같은 원본 작업에 다시 이어서 실행합니다.
+func retryReply(c *gin.Context, cfg *Config, payload types.SlackInteractionPayload, jobID string) *types.SlackDelayedResponse {
+	original, err := cfg.DB.GetJob(jobID)
+	if err != nil || original == nil {
+		return ephemeralReply(fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`", jobID))
+	}
+	if original.Kind == database.JobKindRevert {
+		record, target, err := enqueueRevert(c, cfg, original.ParentJobID, interactionJobBase(payload))
+		return ephemeralReply(revertResultText(original.ParentJobID, record, target, err))
+	}
+	if original.Kind == database.JobKindContinue {
+		// 이어서 실행한 작업은 같은 원본 세션에서 같은 프롬프트로 다시 이어서 실행
+		if original.PromptRedacted {
+			return ephemeralReply("❌ " + worker.ErrPromptUnavailable.Error())
+		}
+		spec := worker.PromptSpec{
+			Prompt:   original.Prompt,
+			OpenPR:   original.OpenPR,
+			Model:    original.Model,
+			Timeout:  time.Duration(original.TimeoutSeconds) * time.Second,
+			ReadOnly: original.ReadOnly,
+		}
+		record, parent, err := enqueueContinue(c, cfg, original.ParentJobID, spec, interactionJobBase(payload))
+		if err != nil {
+			return ephemeralReply(continueErrorText(original.ParentJobID, parent, err))
+		}
+		text := continueAckText(record, parent)
+		reply := ephemeralReply(text)
+		reply.Blocks = worker.MessageBlocks(text, record.ID, worker.ActionCancelJob)
+		return reply
+	}
+
+	record, err := retryJob(c, cfg, original, interactionJobBase(payload))
+	if text, rejected := rejectionText(err); rejected {
+		return ephemeralReply(text)
+	}
+	switch {
+	case errors.Is(err, errJobNotRetryable):
+		return ephemeralReply(fmt.Sprintf("⚠️ %s: `%s` (상태: %s)", err.Error(), original.ID[:8], original.Status))
+	case errors.Is(err, errRetryTarget), errors.Is(err, worker.ErrUnknownAgent), errors.Is(err, worker.ErrPromptUnavailable),
+		errors.As(err, new(*runOptionError)):
+		return ephemeralReply("❌ " + err.Error())
+	case err != nil:
+		log.Printf("다시 실행 요청 실패 (%s): %v", jobID, err)
+		return ephemeralReply("❌ 작업을 등록하는 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요.")
+	}
+
+	text := fmt.Sprintf("🔁 작업 `%s`을(를) 다시 실행합니다... (ID: `%s`)\n📁 대상: %s%s\n> %s",
+		original.ID[:8], record.ID[:8], formatProject(record.ProjectName, record.ProjectPath), prNote(record.OpenPR), cfg.Redactor.String(record.Prompt))
+	if record.RequireApproval {
+		text += "\n📝 승인이 필요한 프로젝트입니다. 실행 계획을 먼저 작성합니다."
+	}
+	reply := ephemeralReply(text)
+	reply.Blocks = worker.MessageBlocks(text, record.ID, worker.ActionCancelJob)
+	return reply
+}
+
+// ephemeralReply는 버튼을 누른 사용자에게만 보이는 응답 메시지를 만듭니다.
+func ephemeralReply(text string) *types.SlackDelayedResponse {
+	return &types.SlackDelayedResponse{Text: text, ResponseType: "ephemeral"}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+func TestRetryJobRevalidates(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+
+	removed := filepath.Join(t.TempDir(), "removed")
+	if out, err := exec.Command("git", "init", "-q", removed).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if err := os.RemoveAll(removed); err != nil {
+		t.Fatal(err)
+	}
+
+	// readonly_args가 없어 읽기 전용 실행을 보장할 수 없는 백엔드
+	agent, err := worker.NewTemplateAgent("aider", worker.AgentTemplate{Command: "aider", Args: []string{"--message", "{{prompt}}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Agents.Register(agent); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(job *database.JobRecord)
+		wantErr func(err error) bool
+	}{
+		{"same options", func(job *database.JobRecord) {}, func(err error) bool { return err == nil }},
+		{"removed project", func(job *database.JobRecord) { job.ProjectPath = removed }, func(err error) bool { return errors.Is(err, errRetryTarget) }},
+		{"model no longer allowed", func(job *database.JobRecord) { job.Model = "gpt-5" }, func(err error) bool { return errors.As(err, new(*runOptionError)) }},
+		{"readonly without readonly_args", func(job *database.JobRecord) { job.Agent = "aider"; job.ReadOnly = true }, func(err error) bool { return errors.As(err, new(*runOptionError)) }},
+		{"timeout above max", func(job *database.JobRecord) { job.TimeoutSeconds = int(2 * cfg.MaxJobTimeout / time.Second) }, func(err error) bool { return errors.As(err, new(*runOptionError)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := &database.JobRecord{
+				ID:             "11111111-0000-0000-0000-000000000000",
+				Prompt:         "README 정리해줘",
+				ProjectPath:    repo,
+				Status:         database.JobStatusCompleted,
+				TimeoutSeconds: int(cfg.JobTimeout / time.Second),
+			}
+			tt.edit(original)
+
+			record, err := retryJob(nil, cfg, original, &database.JobRecord{UserID: "U1", ChannelID: "C1"})
+			if !tt.wantErr(err) {
+				t.Fatalf("retryJob error = %v", err)
+			}
+			if err != nil {
+				if record != nil {
+					t.Errorf("실패한 다시 실행이 작업을 반환했습니다: %+v", record)
+				}
+				return
+			}
+			job, err := cfg.DB.GetJob(record.ID)
+			if err != nil || job == nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			resolved, _ := filepath.EvalSymlinks(repo)
+			if job.ParentJobID != original.ID || job.ProjectPath != resolved || job.Prompt != original.Prompt {
+				t.Errorf("다시 실행한 작업 = %+v", job)
+			}
+		})
+	}
+}
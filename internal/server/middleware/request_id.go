@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDMiddleware는 요청에 고유 ID를 부여하고 X-Request-ID 응답 헤더로 돌려줍니다 (v1.5)
+// 감사 로그와 서버 로그에서 같은 요청을 찾을 수 있도록 /api 요청에 사용합니다.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := uuid.NewString()
+		c.Set(RequestIDKey, id)
+		c.Header("X-Request-ID", id)
+		c.Next()
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+)
+
+// ResolveProjectRoots는 ALLOWED_PROJECT_ROOTS에 지정한 디렉토리를 실제 경로로 변환합니다 (v1.5)
+// 심볼릭 링크를 해석해 두어야 프로젝트 경로와 같은 기준으로 비교할 수 있습니다.
+func ResolveProjectRoots(roots []string) ([]string, error) {
+	resolved := make([]string, 0, len(roots))
+	for _, root := range roots {
+		path, err := resolvePath(root)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("디렉토리가 아닙니다: %s", root)
+		}
+		resolved = append(resolved, path)
+	}
+	return resolved, nil
+}
+
+// ValidateProjectPath는 작업을 실행할 프로젝트 경로를 검증하고 실제 경로를 반환합니다 (v1.5)
+//
+// `~`와 `..`, 심볼릭 링크를 해석한 뒤 디렉토리가 존재하는지, 허용된 루트(ALLOWED_PROJECT_ROOTS) 아래에 있는지,
+// git 저장소인지 확인합니다. 저장하는 경로는 해석된 실제 경로이므로 나중에 링크가 바뀌어도 루트 밖을 가리키지 않습니다.
+// 에이전트와 스냅샷/복원은 저장소 전체를 다루므로, 하위 디렉토리를 등록해도 저장소 최상위가 허용된 루트 밖이면 거부합니다.
+func (c *Config) ValidateProjectPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("프로젝트 경로는 비어있을 수 없습니다.")
+	}
+
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("프로젝트 경로를 확인할 수 없습니다: `%s`", path)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("디렉토리가 아닙니다: `%s`", path)
+	}
+	if !underProjectRoots(c.AllowedProjectRoots, resolved) {
+		return "", fmt.Errorf("허용된 디렉토리 밖의 경로입니다: `%s`\n허용된 디렉토리: %s", resolved, formatRoots(c.AllowedProjectRoots))
+	}
+	top, err := git.TopLevel(resolved)
+	if err != nil {
+		return "", fmt.Errorf("git 저장소가 아닙니다: `%s`", resolved)
+	}
+	if real, err := filepath.EvalSymlinks(top); err == nil {
+		top = real
+	}
+	if !underProjectRoots(c.AllowedProjectRoots, top) {
+		return "", fmt.Errorf("git 저장소(`%s`)가 허용된 디렉토리 밖에 있습니다: `%s`\n허용된 디렉토리: %s", top, resolved, formatRoots(c.AllowedProjectRoots))
+	}
+	return resolved, nil
+}
+
+// resolvePath는 `~`를 홈 디렉토리로 바꾸고 `..`와 심볼릭 링크를 해석한 절대 경로를 반환합니다.
+// 상대 경로는 서버의 작업 디렉토리에 따라 달라지므로 허용하지 않습니다.
+func resolvePath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("홈 디렉토리를 확인할 수 없습니다: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("절대 경로를 입력해주세요: `%s`", path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("존재하지 않는 경로입니다: `%s`", path)
+		}
+		return "", fmt.Errorf("경로를 확인할 수 없습니다: `%s` (%v)", path, err)
+	}
+	return resolved, nil
+}
+
+// underProjectRoots는 path가 허용된 루트 중 하나이거나 그 아래에 있는지 반환합니다.
+// 루트가 없으면 모든 경로를 허용합니다.
+func underProjectRoots(roots []string, path string) bool {
+	if len(roots) == 0 {
+		return true
+	}
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRoots는 허용된 루트 목록을 Slack 메시지로 표시합니다.
+func formatRoots(roots []string) string {
+	quoted := make([]string, 0, len(roots))
+	for _, root := range roots {
+		quoted = append(quoted, "`"+root+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateProjectPath(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(base, "repos")
+	outer := filepath.Join(base, "outer") // 허용된 루트를 담고 있는 git 저장소
+	for _, dir := range []string{
+		filepath.Join(root, "app"), filepath.Join(root, "plain"), filepath.Join(base, "repos-evil", "app"),
+		filepath.Join(outer, "nested", "sub"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, repo := range []string{filepath.Join(root, "app"), filepath.Join(base, "repos-evil", "app"), outer} {
+		if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+			t.Skipf("git init 실패: %v\n%s", err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "file.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(base, "repos-evil", "app"), filepath.Join(root, "link")); err != nil {
+		t.Skipf("심볼릭 링크를 만들 수 없습니다: %v", err)
+	}
+	t.Setenv("HOME", root)
+
+	cfg := &Config{AllowedProjectRoots: []string{root, filepath.Join(outer, "nested")}}
+	tests := []struct {
+		name    string
+		path    string
+		want    string // 성공 시 반환 경로
+		wantErr string // 실패 시 오류 메시지 일부
+	}{
+		{"repository", filepath.Join(root, "app"), filepath.Join(root, "app"), ""},
+		{"dot dot inside root", filepath.Join(root, "plain", "..", "app"), filepath.Join(root, "app"), ""},
+		{"home", "~/app", filepath.Join(root, "app"), ""},
+		{"dot dot escape", filepath.Join(root, "app", "..", "..", "repos-evil", "app"), "", "허용된 디렉토리 밖"},
+		{"symlink escape", filepath.Join(root, "link"), "", "허용된 디렉토리 밖"},
+		{"sibling prefix", filepath.Join(base, "repos-evil", "app"), "", "허용된 디렉토리 밖"},
+		{"not a directory", filepath.Join(root, "file.txt"), "", "디렉토리가 아닙니다"},
+		{"not a git repository", filepath.Join(root, "plain"), "", "git 저장소가 아닙니다"},
+		{"repository outside root", filepath.Join(outer, "nested", "sub"), "", "git 저장소(`" + outer + "`)가 허용된 디렉토리 밖"},
+		{"missing", filepath.Join(root, "missing"), "", "존재하지 않는 경로"},
+		{"relative", "repos/app", "", "절대 경로"},
+		{"empty", " ", "", "비어있을 수 없습니다"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ValidateProjectPath(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateProjectPath(%q) = %q, %v; want error %q", tt.path, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ValidateProjectPath(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,731 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// ProjectRequest는 프로젝트 등록 요청 구조체입니다 (v1.5)
+type ProjectRequest struct {
+	Name   string `json:"name" example:"backend" binding:"required"`
+	Path   string `json:"path" example:"/srv/repos/backend" binding:"required"`
+	AutoPR bool   `json:"auto_pr" example:"false"` // 모든 작업 완료 후 PR 생성
+
+	// v1.5: 파일 수정 전 실행 계획 승인
+	RequireApproval bool     `json:"require_approval" example:"false"`
+	Reviewers       []string `json:"reviewers" example:"U1234567890"` // 승인 가능한 Slack user_id 또는 사용자 그룹 ID (비어 있으면 APPROVAL_REVIEWERS)
+
+	// v1.5: 작업을 실행할 에이전트 백엔드 (비어 있으면 기본 백엔드)
+	Agent string `json:"agent" example:"cursor"`
+
+	// v1.5: 작업 시간 제한 (Go duration 형식, 비어 있으면 JOB_TIMEOUT, JOB_TIMEOUT_MAX 이하)
+	Timeout string `json:"timeout" example:"30m"`
+}
+
+// ProjectListResponse는 프로젝트 목록 응답 구조체입니다 (v1.5)
+type ProjectListResponse struct {
+	Projects    []*database.Project `json:"projects"`
+	DefaultPath string              `json:"default_path,omitempty" example:"/Users/username/projects/my-project"`
+}
+
+// jobTarget은 작업 요청 시점에 결정된 실행 대상입니다 (v1.5)
+type jobTarget struct {
+	ProjectName string            // 등록된 프로젝트 이름 (경로를 직접 사용하면 빈 값)
+	ProjectPath string            // 실행 경로
+	Project     *database.Project // 등록된 프로젝트 설정 (경로를 직접 사용하면 nil)
+	Agent       string            // v1.5: 실행할 에이전트 백엔드 (--agent 옵션 > 프로젝트 설정 > 기본 백엔드)
+}
+
+// resolveJobTarget은 작업 요청 시점에 실행 대상 프로젝트를 결정합니다 (v1.5)
+//
+// 우선순위: @이름으로 지정한 프로젝트 > 요청한 채널에 바인딩된 프로젝트 > 전역 기본 경로.
+// 결정된 경로는 작업에 기록되므로, 이후 set-path나 bind로 변경해도
+// 이미 대기 중인 작업의 대상은 바뀌지 않습니다.
+// 결정된 경로는 다시 검증하여 ALLOWED_PROJECT_ROOTS를 설정하기 전에 등록된 경로나 삭제된 디렉토리를 걸러냅니다.
+// 에이전트 백엔드도 이 시점에 결정하여 기록하므로, 이후 기본 백엔드를 바꿔도 대기 중인 작업의 백엔드는 바뀌지 않습니다.
+// 요청 옵션(--model, --timeout)은 결정된 백엔드의 허용 목록과 서버 최대값(JOB_TIMEOUT_MAX)으로 확인합니다.
+func resolveJobTarget(cfg *Config, spec worker.PromptSpec, teamID string, channelID string) (*jobTarget, error) {
+	target, err := findJobTarget(cfg, spec, teamID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := cfg.ValidateProjectPath(target.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	target.ProjectPath = path
+
+	name := spec.Agent
+	if name == "" && target.Project != nil {
+		name = target.Project.Agent
+	}
+	agent, err := cfg.Agents.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRunOptions(cfg, agent, spec); err != nil {
+		return nil, err
+	}
+	if err := checkApprovalAgent(agent, target.requireApproval(spec)); err != nil {
+		return nil, &runOptionError{err: err}
+	}
+	target.Agent = agent.Name()
+	return target, nil
+}
+
+// runOptionError는 요청 옵션(--model, --timeout)이 서버 설정에서 허용되지 않을 때의 오류입니다 (v1.5)
+type runOptionError struct {
+	err error
+}
+
+func (e *runOptionError) Error() string { return e.err.Error() }
+func (e *runOptionError) Unwrap() error { return e.err }
+
+// checkRunOptions는 요청 옵션을 에이전트의 모델 허용 목록, 읽기 전용 지원 여부와 최대 시간 제한으로 확인합니다 (v1.5)
+func checkRunOptions(cfg *Config, agent worker.Agent, spec worker.PromptSpec) error {
+	if err := worker.CheckModel(agent, spec.Model); err != nil {
+		return &runOptionError{err: err}
+	}
+	if err := worker.CheckReadOnly(agent, spec.ReadOnly); err != nil {
+		return &runOptionError{err: err}
+	}
+	if err := checkTimeout(cfg, spec.Timeout); err != nil {
+		return &runOptionError{err: err}
+	}
+	return nil
+}
+
+// checkApprovalAgent는 승인이 필요한 작업의 계획 단계를 에이전트가 읽기 전용으로 실행할 수 있는지 확인합니다 (v1.5)
+// 읽기 전용을 지원하지 않는 백엔드는 계획 단계에서 파일을 수정할 수 있고, 스냅샷 복원은 git이 추적하는 파일만 되돌립니다.
+func checkApprovalAgent(agent worker.Agent, requireApproval bool) error {
+	if err := worker.CheckReadOnly(agent, requireApproval); err != nil {
+		return fmt.Errorf("승인이 필요한 프로젝트에서는 실행 계획을 읽기 전용으로 작성해야 합니다: %w", err)
+	}
+	return nil
+}
+
+// checkTimeout은 프로젝트/요청별 시간 제한이 서버 최대값(JOB_TIMEOUT_MAX) 이하인지 확인합니다 (v1.5)
+func checkTimeout(cfg *Config, timeout time.Duration) error {
+	if timeout > cfg.MaxJobTimeout {
+		return fmt.Errorf("시간 제한은 최대 %s까지 지정할 수 있습니다", worker.FormatDuration(cfg.MaxJobTimeout))
+	}
+	return nil
+}
+
+// setRunOptions는 요청 옵션(--model, --readonly)과 결정된 시간 제한을 작업 레코드에 기록합니다 (v1.5)
+// 시간 제한도 요청 시점에 기록하므로, 이후 프로젝트 설정이나 JOB_TIMEOUT을 바꿔도 대기 중인 작업에는 영향이 없습니다.
+func setRunOptions(cfg *Config, record *database.JobRecord, target *jobTarget, spec worker.PromptSpec) {
+	record.Model = spec.Model
+	record.TimeoutSeconds = int(target.timeout(cfg, spec) / time.Second)
+	record.ReadOnly = spec.ReadOnly
+}
+
+// findJobTarget은 우선순위에 따라 실행 대상을 찾습니다 (경로 검증은 resolveJobTarget).
+// 채널 바인딩이나 기본 경로가 등록된 프로젝트 경로이면 그 프로젝트의 작업으로 취급합니다 (targetForPath).
+func findJobTarget(cfg *Config, spec worker.PromptSpec, teamID string, channelID string) (*jobTarget, error) {
+	if spec.Project != "" {
+		return lookupProject(cfg, spec.Project)
+	}
+
+	if channelID != "" {
+		binding, err := cfg.DB.GetChannelBinding(teamID, channelID)
+		if err != nil {
+			return nil, err
+		}
+		if binding != nil {
+			if binding.ProjectName != "" {
+				return lookupProject(cfg, binding.ProjectName)
+			}
+			return targetForPath(cfg, binding.ProjectPath)
+		}
+	}
+
+	path, isSet := cfg.GetProjectPath()
+	if !isSet {
+		return nil, errors.New("프로젝트 경로가 설정되지 않았습니다. 기본 경로를 설정하거나 `@프로젝트`로 대상을 지정해주세요.")
+	}
+	return targetForPath(cfg, path)
+}
+
+// targetForPath는 경로로 지정한 실행 대상을 반환합니다 (v1.5)
+// 경로가 등록된 프로젝트의 경로이거나 그 하위 디렉토리이면 (여럿이면 가장 가까운) 프로젝트의 작업으로 취급하여,
+// 경로로 지정해도 프로젝트의 승인 정책, 승인자, 역할과 API 키 허용 목록을 우회할 수 없게 합니다.
+func targetForPath(cfg *Config, path string) (*jobTarget, error) {
+	projects, err := cfg.DB.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	var match *database.Project
+	for _, p := range projects {
+		rel, err := filepath.Rel(p.Path, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if match == nil || len(p.Path) > len(match.Path) {
+			match = p
+		}
+	}
+	if match == nil {
+		return &jobTarget{ProjectPath: path}, nil
+	}
+	return &jobTarget{ProjectName: match.Name, ProjectPath: path, Project: match}, nil
+}
+
+// lookupProject는 등록된 프로젝트를 실행 대상으로 반환합니다.
+func lookupProject(cfg *Config, name string) (*jobTarget, error) {
+	project, err := cfg.DB.GetProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("등록되지 않은 프로젝트입니다: `%s`", name)
+	}
+	return &jobTarget{ProjectName: project.Name, ProjectPath: project.Path, Project: project}, nil
+}
+
+// targetErrorText는 실행 대상 결정 실패를 Slack 메시지로 변환합니다.
+func targetErrorText(err error) string {
+	var optErr *runOptionError
+	if errors.Is(err, worker.ErrUnknownAgent) || errors.As(err, &optErr) {
+		return "❌ " + err.Error()
+	}
+	return "❌ " + err.Error() + "\n\n💡 `/cursor project list`로 등록된 프로젝트를 확인하세요."
+}
+
+// openPR은 작업 완료 후 PR을 생성할지 결정합니다 (--pr 옵션 또는 프로젝트 auto_pr 설정).
+// v1.5: 읽기 전용 작업은 변경 사항이 없으므로 PR을 만들지 않습니다.
+func (t *jobTarget) openPR(spec worker.PromptSpec) bool {
+	if spec.ReadOnly {
+		return false
+	}
+	return spec.OpenPR || (t.Project != nil && t.Project.AutoPR)
+}
+
+// timeout은 작업 시간 제한을 결정합니다 (v1.5: --timeout 옵션 > 프로젝트 설정 > JOB_TIMEOUT).
+// 최대값을 줄인 뒤에도 기존 프로젝트 설정이 최대값을 넘지 않도록 JOB_TIMEOUT_MAX로 제한합니다.
+func (t *jobTarget) timeout(cfg *Config, spec worker.PromptSpec) time.Duration {
+	if spec.Timeout > 0 {
+		return spec.Timeout
+	}
+	if t.Project != nil && t.Project.TimeoutSeconds > 0 {
+		return min(time.Duration(t.Project.TimeoutSeconds)*time.Second, cfg.MaxJobTimeout)
+	}
+	return cfg.JobTimeout
+}
+
+// requireApproval은 파일 수정 전 실행 계획 승인이 필요한지 결정합니다 (v1.5: 프로젝트 설정).
+// 읽기 전용 작업은 파일을 수정하지 않으므로 승인 없이 실행합니다 (읽기 전용을 지원하지 않는 백엔드는 checkRunOptions에서 거부).
+func (t *jobTarget) requireApproval(spec worker.PromptSpec) bool {
+	return !spec.ReadOnly && t.Project != nil && t.Project.RequireApproval
+}
+
+// addProject는 프로젝트를 검증하고 등록합니다. Slack 명령어와 API가 공통으로 사용합니다.
+func addProject(c *gin.Context, cfg *Config, name string, path string, autoPR bool, requireApproval bool, reviewers []string, agent string, timeout time.Duration, createdBy string) (*database.Project, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+	if err := worker.ValidateProjectName(name); err != nil {
+		return nil, err
+	}
+	agent = strings.ToLower(strings.TrimSpace(agent))
+	backend, err := cfg.Agents.Get(agent)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkApprovalAgent(backend, requireApproval); err != nil {
+		return nil, err
+	}
+	if err := checkTimeout(cfg, timeout); err != nil {
+		return nil, err
+	}
+
+	path, err = cfg.ValidateProjectPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &database.Project{
+		Name:      name,
+		Path:      path,
+		AutoPR:    autoPR,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+
+		RequireApproval: requireApproval,
+		Reviewers:       reviewers,
+		Agent:           agent,
+		TimeoutSeconds:  int(timeout / time.Second),
+	}
+	if err := cfg.DB.CreateProject(project); err != nil {
+		return nil, err
+	}
+	log.Printf("[%s] 프로젝트 등록: %s → %s", createdBy, name, path)
+	recordAudit(cfg, c, database.AuditEvent{ActorID: createdBy, Action: database.AuditProjectAdd, Target: "@" + name, After: path})
+	return project, nil
+}
+
+// removeProject는 프로젝트를 삭제합니다. 등록되지 않은 프로젝트이면 false를 반환합니다 (v1.5: Slack 명령어/API 공용).
+func removeProject(c *gin.Context, cfg *Config, name string, removedBy string) (bool, error) {
+	project, err := cfg.DB.GetProject(name)
+	if err != nil || project == nil {
+		return false, err
+	}
+	removed, err := cfg.DB.DeleteProject(name)
+	if err != nil || !removed {
+		return false, err
+	}
+	log.Printf("[%s] 프로젝트 삭제: %s", removedBy, name)
+	recordAudit(cfg, c, database.AuditEvent{ActorID: removedBy, Action: database.AuditProjectRemove, Target: "@" + name, Before: project.Path})
+	return true, nil
+}
+
+// handleProjectCommand는 `/cursor project add|remove|list` 명령어를 처리합니다 (v1.5)
+func handleProjectCommand(c *gin.Context, cfg *Config, args []string, userID string) {
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	var text string
+	switch sub {
+	case "list", "ls":
+		text = projectListText(cfg)
+
+	case "add":
+		if len(args) < 3 {
+			text = "❌ 이름과 경로를 입력해주세요.\n사용법: `/cursor project add <이름> <경로>`"
+			break
+		}
+		project, err := addProject(c, cfg, args[1], strings.Join(args[2:], " "), false, false, nil, "", 0, userID)
+		if err != nil {
+			text = "❌ " + err.Error()
+			break
+		}
+		text = fmt.Sprintf("✅ 프로젝트가 등록되었습니다: `%s` → `%s`\n\n이제 `/cursor @%s \"프롬프트\"` 명령어를 사용할 수 있습니다.",
+			project.Name, project.Path, project.Name)
+
+	case "remove", "rm", "delete":
+		if len(args) < 2 {
+			text = "❌ 프로젝트 이름을 입력해주세요.\n사용법: `/cursor project remove <이름>`"
+			break
+		}
+		name := strings.ToLower(strings.TrimPrefix(args[1], "@"))
+		removed, err := removeProject(c, cfg, name, userID)
+		switch {
+		case err != nil:
+			log.Printf("프로젝트 삭제 실패 (%s): %v", name, err)
+			text = "❌ 프로젝트를 삭제하는 중 오류가 발생했습니다."
+		case !removed:
+			text = fmt.Sprintf("❌ 등록되지 않은 프로젝트입니다: `%s`", name)
+		default:
+			text = fmt.Sprintf("🗑️ 프로젝트가 삭제되었습니다: `%s`", name)
+		}
+
+	case "set":
+		// v1.5: /cursor project set <이름> pr|approval on|off, /cursor project set <이름> reviewers <@사용자>...|none, /cursor project set <이름> agent <에이전트>|default, /cursor project set <이름> timeout <시간>|default
+		if len(args) < 4 {
+			text = projectSetUsage
+			break
+		}
+		text = projectSetText(c, cfg, strings.ToLower(strings.TrimPrefix(args[1], "@")), args[2], args[3:], userID)
+
+	default:
+		text = "❌ 알 수 없는 명령어입니다.\n사용법: `/cursor project add <이름> <경로>` | `/cursor project remove <이름>` | `/cursor project list` | `/cursor project set <이름> pr|approval on|off` | `/cursor project set <이름> reviewers <@사용자>...` | `/cursor project set <이름> agent <에이전트>|default` | `/cursor project set <이름> timeout <시간>|default`"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          text,
+	})
+}
+
+// projectSetUsage는 `/cursor project set` 사용법입니다.
+const projectSetUsage = "❌ 사용법: `/cursor project set <이름> pr on|off` | `/cursor project set <이름> approval on|off` | `/cursor project set <이름> reviewers <@사용자>...|none` | `/cursor project set <이름> agent <에이전트>|default` | `/cursor project set <이름> timeout <시간>|default`"
+
+// projectSetText는 `/cursor project set <이름> <설정> <값>`을 처리하고 결과 메시지를 반환합니다 (v1.5)
+func projectSetText(c *gin.Context, cfg *Config, name string, key string, values []string, userID string) string {
+	var updated bool
+	var err error
+	var text string
+
+	// v1.5: 감사 로그에 남길 변경 전 값
+	before, err := cfg.DB.GetProject(name)
+	if err != nil {
+		log.Printf("프로젝트 조회 실패 (%s): %v", name, err)
+		return "❌ 프로젝트 설정을 변경하는 중 오류가 발생했습니다."
+	}
+	if before == nil {
+		return fmt.Sprintf("❌ 등록되지 않은 프로젝트입니다: `%s`", name)
+	}
+	event := database.AuditEvent{ActorID: userID, Action: database.AuditProjectSet, Target: fmt.Sprintf("@%s %s", name, key)}
+
+	switch key {
+	case "pr":
+		if values[0] != "on" && values[0] != "off" {
+			return projectSetUsage
+		}
+		autoPR := values[0] == "on"
+		updated, err = cfg.DB.SetProjectAutoPR(name, autoPR)
+		event.Before, event.After = onOff(before.AutoPR), onOff(autoPR)
+		if autoPR {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 모든 작업은 완료 후 PR을 생성합니다.", name)
+		} else {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트는 `--pr` 옵션을 지정한 작업만 PR을 생성합니다.", name)
+		}
+
+	case "approval":
+		if values[0] != "on" && values[0] != "off" {
+			return projectSetUsage
+		}
+		required := values[0] == "on"
+		if required {
+			// v1.5: 계획 단계를 읽기 전용으로 실행할 수 없는 에이전트면 승인 절차를 켤 수 없음
+			agent, aerr := cfg.Agents.Get(before.Agent)
+			if aerr == nil {
+				aerr = checkApprovalAgent(agent, true)
+			}
+			if aerr != nil {
+				return "❌ " + aerr.Error()
+			}
+		}
+		updated, err = cfg.DB.SetProjectApproval(name, required)
+		event.Before, event.After = onOff(before.RequireApproval), onOff(required)
+		if required {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업은 먼저 실행 계획을 작성하고, 승인 후 파일을 수정합니다.", name)
+		} else {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업은 승인 없이 바로 실행됩니다.", name)
+		}
+
+	case "agent":
+		// v1.5: default이면 서버 기본 백엔드 사용
+		agent := strings.ToLower(values[0])
+		if agent == "default" {
+			agent = ""
+		}
+		backend, aerr := cfg.Agents.Get(agent)
+		if aerr == nil {
+			aerr = checkApprovalAgent(backend, before.RequireApproval)
+		}
+		if aerr != nil {
+			return "❌ " + aerr.Error()
+		}
+		updated, err = cfg.DB.SetProjectAgent(name, agent)
+		event.Before, event.After = before.Agent, agent
+		if agent != "" {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업은 `%s` 에이전트로 실행합니다. (`--agent` 옵션이 우선합니다)", name, agent)
+		} else {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업은 기본 에이전트(`%s`)로 실행합니다.", name, cfg.Agents.Default())
+		}
+
+	case "timeout":
+		// v1.5: default이면 서버 기본값(JOB_TIMEOUT) 사용
+		var timeout time.Duration
+		if values[0] != "default" {
+			if timeout, err = worker.ParseTimeout(values[0]); err != nil {
+				return "❌ " + err.Error()
+			}
+			if err := checkTimeout(cfg, timeout); err != nil {
+				return "❌ " + err.Error()
+			}
+		}
+		updated, err = cfg.DB.SetProjectTimeout(name, int(timeout/time.Second))
+		event.Before, event.After = projectTimeoutValue(before.TimeoutSeconds), projectTimeoutValue(int(timeout/time.Second))
+		if timeout > 0 {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업 시간 제한: %s (`--timeout` 옵션이 우선합니다)", name, worker.FormatDuration(timeout))
+		} else {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 작업은 기본 시간 제한(%s)을 사용합니다.", name, worker.FormatDuration(cfg.JobTimeout))
+		}
+
+	case "reviewers":
+		var reviewers []string
+		if values[0] != "none" {
+			reviewers = parseReviewers(values)
+		}
+		updated, err = cfg.DB.SetProjectReviewers(name, reviewers)
+		event.Before, event.After = formatAuditList(before.Reviewers), formatAuditList(reviewers)
+		if len(reviewers) > 0 {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 승인자: %s", name, formatReviewers(reviewers))
+		} else {
+			text = fmt.Sprintf("✅ `@%s` 프로젝트의 승인자 지정을 해제했습니다. 기본 승인자(APPROVAL_REVIEWERS)를 사용합니다.", name)
+		}
+
+	default:
+		return projectSetUsage
+	}
+
+	switch {
+	case err != nil:
+		log.Printf("프로젝트 설정 변경 실패 (%s): %v", name, err)
+		return "❌ 프로젝트 설정을 변경하는 중 오류가 발생했습니다."
+	case !updated:
+		return fmt.Sprintf("❌ 등록되지 않은 프로젝트입니다: `%s`", name)
+	}
+	log.Printf("[%s] 프로젝트 설정 변경: %s %s → %s", userID, name, key, strings.Join(values, " "))
+	recordAudit(cfg, c, event)
+	return text
+}
+
+// projectTimeoutValue는 감사 로그에 기록할 프로젝트 시간 제한입니다 (0이면 빈 값).
+func projectTimeoutValue(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// handleBindCommand는 `/cursor bind <경로|@이름>` / `/cursor unbind` 명령어를 처리합니다 (v1.5)
+// 인자 없이 bind를 실행하면 현재 채널의 바인딩을 보여줍니다.
+func handleBindCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload, args []string, unbind bool) {
+	var text string
+	switch {
+	case payload.ChannelID == "":
+		text = "❌ 채널 정보가 없어 바인딩할 수 없습니다."
+
+	case unbind:
+		previous, _ := cfg.DB.GetChannelBinding(payload.TeamID, payload.ChannelID)
+		removed, err := cfg.DB.DeleteChannelBinding(payload.TeamID, payload.ChannelID)
+		switch {
+		case err != nil:
+			log.Printf("채널 바인딩 해제 실패 (%s): %v", payload.ChannelID, err)
+			text = "❌ 채널 바인딩을 해제하는 중 오류가 발생했습니다."
+		case !removed:
+			text = "ℹ️ 이 채널에는 바인딩된 프로젝트가 없습니다."
+		default:
+			log.Printf("[%s] 채널 바인딩 해제: %s", payload.UserID, payload.ChannelID)
+			recordAudit(cfg, c, database.AuditEvent{
+				ActorID: payload.UserID, ActorName: payload.UserName,
+				Action: database.AuditChannelUnbind, Target: payload.ChannelID, Before: bindingAuditValue(previous),
+			})
+			text = "✅ 채널 바인딩이 해제되었습니다. 이제 기본 프로젝트 경로를 사용합니다."
+		}
+
+	case len(args) == 0:
+		binding, err := cfg.DB.GetChannelBinding(payload.TeamID, payload.ChannelID)
+		switch {
+		case err != nil:
+			log.Printf("채널 바인딩 조회 실패 (%s): %v", payload.ChannelID, err)
+			text = "❌ 채널 바인딩을 조회하는 중 오류가 발생했습니다."
+		case binding == nil:
+			text = "ℹ️ 이 채널에는 바인딩된 프로젝트가 없습니다.\n\n💡 바인딩하기: `/cursor bind <경로>` 또는 `/cursor bind @프로젝트`"
+		default:
+			text = fmt.Sprintf("📌 이 채널의 프로젝트: %s\n(설정: %s, %s)",
+				formatProject(binding.ProjectName, binding.ProjectPath), formatActor(binding.BoundBy), binding.BoundAt.Format("2006-01-02 15:04:05"))
+		}
+
+	default:
+		binding := &database.ChannelBinding{
+			TeamID:      payload.TeamID,
+			ChannelID:   payload.ChannelID,
+			ChannelName: payload.ChannelName,
+			BoundBy:     payload.UserID,
+			BoundAt:     time.Now(),
+		}
+		target := strings.Join(args, " ")
+		if strings.HasPrefix(target, "@") {
+			t, err := lookupProject(cfg, strings.ToLower(target[1:]))
+			if err != nil {
+				text = "❌ " + err.Error() + "\n\n💡 `/cursor project list`로 등록된 프로젝트를 확인하세요."
+				break
+			}
+			binding.ProjectName = t.ProjectName
+			binding.ProjectPath = t.ProjectPath
+		} else {
+			path, err := cfg.ValidateProjectPath(target)
+			if err != nil {
+				text = "❌ " + err.Error()
+				break
+			}
+			binding.ProjectPath = path
+		}
+
+		previous, _ := cfg.DB.GetChannelBinding(payload.TeamID, payload.ChannelID)
+		if err := cfg.DB.SetChannelBinding(binding); err != nil {
+			log.Printf("채널 바인딩 저장 실패 (%s): %v", payload.ChannelID, err)
+			text = "❌ 채널 바인딩을 저장하는 중 오류가 발생했습니다."
+			break
+		}
+		log.Printf("[%s] 채널 바인딩: #%s (%s) → %s", payload.UserID, payload.ChannelName, payload.ChannelID, target)
+		recordAudit(cfg, c, database.AuditEvent{
+			ActorID: payload.UserID, ActorName: payload.UserName,
+			Action: database.AuditChannelBind, Target: payload.ChannelID, Before: bindingAuditValue(previous), After: bindingAuditValue(binding),
+		})
+		text = fmt.Sprintf("📌 이 채널의 요청은 이제 %s 에서 실행됩니다.\n(`@프로젝트`를 지정하면 해당 프로젝트가 우선합니다)",
+			formatProject(binding.ProjectName, binding.ProjectPath))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          text,
+	})
+}
+
+// bindingAuditValue는 채널 바인딩을 감사 이벤트의 변경 전후 값(@이름 또는 경로)으로 표시합니다.
+func bindingAuditValue(b *database.ChannelBinding) string {
+	switch {
+	case b == nil:
+		return ""
+	case b.ProjectName != "":
+		return "@" + b.ProjectName
+	default:
+		return b.ProjectPath
+	}
+}
+
+// projectListText는 등록된 프로젝트 목록을 Slack 메시지로 만듭니다.
+func projectListText(cfg *Config) string {
+	projects, err := cfg.DB.ListProjects()
+	if err != nil {
+		log.Printf("프로젝트 목록 조회 실패: %v", err)
+		return "❌ 프로젝트 목록을 가져오는 중 오류가 발생했습니다."
+	}
+
+	var response strings.Builder
+	response.WriteString("📁 *등록된 프로젝트*\n\n")
+	if len(projects) == 0 {
+		response.WriteString("아직 등록된 프로젝트가 없습니다.\n")
+	}
+	for _, p := range projects {
+		flags := ""
+		if p.AutoPR {
+			flags += " 🔀 자동 PR"
+		}
+		if p.RequireApproval {
+			flags += " 📝 승인 필요"
+			if len(p.Reviewers) > 0 {
+				flags += fmt.Sprintf(" (승인자: %s)", formatReviewers(p.Reviewers))
+			}
+		}
+		if len(p.AllowedUsers) > 0 {
+			flags += fmt.Sprintf(" 🔒 허용: %s", formatSubjects(p.AllowedUsers))
+		}
+		if p.Agent != "" {
+			flags += fmt.Sprintf(" 🤖 에이전트: `%s`", p.Agent)
+		}
+		if p.TimeoutSeconds > 0 {
+			flags += fmt.Sprintf(" ⏱️ 시간 제한: %s", worker.FormatDuration(time.Duration(p.TimeoutSeconds)*time.Second))
+		}
+		response.WriteString(fmt.Sprintf("• `@%s` → `%s`%s\n", p.Name, p.Path, flags))
+	}
+
+	if path, isSet := cfg.GetProjectPath(); isSet {
+		response.WriteString(fmt.Sprintf("\n*기본 경로* (@ 미지정 시): `%s`\n", path))
+	}
+	response.WriteString("\n💡 등록하기: `/cursor project add <이름> <경로>`")
+	return response.String()
+}
+
+// HandleListProjects godoc
+// @Summary      프로젝트 목록 조회 (v1.5)
+// @Description  등록된 이름 있는 프로젝트와 기본 경로를 조회합니다.
+// @Tags         config
+// @Produce      json
+// @Success      200  {object}  ProjectListResponse  "프로젝트 목록"
+// @Failure      401  {object}  ErrorResponse        "인증 실패"
+// @Failure      403  {object}  ErrorResponse        "권한 없음"
+// @Failure      500  {object}  ErrorResponse        "서버 오류"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/projects [get]
+func HandleListProjects(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projects, err := cfg.DB.ListProjects()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "프로젝트 목록 조회 실패: " + err.Error()})
+			return
+		}
+		if projects == nil {
+			projects = []*database.Project{}
+		}
+
+		path, _ := cfg.GetProjectPath()
+		c.JSON(http.StatusOK, ProjectListResponse{Projects: projects, DefaultPath: path})
+	}
+}
+
+// HandleCreateProject godoc
+// @Summary      프로젝트 등록 (v1.5)
+// @Description  이름으로 참조할 프로젝트를 등록합니다. 프롬프트 앞에 @이름을 붙여 대상 프로젝트를 지정할 수 있습니다.
+// @Description  경로는 존재하는 git 저장소여야 하며, ALLOWED_PROJECT_ROOTS를 설정하면 그 아래 디렉토리만 등록할 수 있습니다.
+// @Description  v1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).
+// @Tags         config
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ProjectRequest    true  "프로젝트 정보"
+// @Success      201      {object}  database.Project  "등록된 프로젝트"
+// @Failure      400      {object}  ErrorResponse     "잘못된 요청"
+// @Failure      401      {object}  ErrorResponse     "인증 실패"
+// @Failure      403      {object}  ErrorResponse     "권한 없음"
+// @Failure      409      {object}  ErrorResponse     "이미 등록된 이름"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/projects [post]
+func HandleCreateProject(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ProjectRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid JSON payload: " + err.Error()})
+			return
+		}
+
+		if existing, err := cfg.DB.GetProject(strings.ToLower(strings.TrimSpace(req.Name))); err == nil && existing != nil {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: "이미 등록된 프로젝트입니다: " + existing.Name})
+			return
+		}
+
+		var timeout time.Duration
+		if req.Timeout != "" {
+			var err error
+			if timeout, err = worker.ParseTimeout(req.Timeout); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+				return
+			}
+		}
+		project, err := addProject(c, cfg, req.Name, req.Path, req.AutoPR, req.RequireApproval, req.Reviewers, req.Agent, timeout, apiActor(c))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, project)
+	}
+}
+
+// HandleDeleteProject godoc
+// @Summary      프로젝트 삭제 (v1.5)
+// @Description  등록된 프로젝트를 삭제합니다. 이미 대기 중인 해당 프로젝트의 작업은 실행 시 실패합니다.
+// @Description  v1.5: 프로젝트 허용 목록이 있는 API 키로는 사용할 수 없습니다 (403).
+// @Tags         config
+// @Produce      json
+// @Param        name  path  string  true  "프로젝트 이름"
+// @Success      204   "삭제 성공"
+// @Failure      401   {object}  ErrorResponse  "인증 실패"
+// @Failure      403   {object}  ErrorResponse  "권한 없음"
+// @Failure      404   {object}  ErrorResponse  "프로젝트를 찾을 수 없음"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/projects/{name} [delete]
+func HandleDeleteProject(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := strings.ToLower(c.Param("name"))
+
+		removed, err := removeProject(c, cfg, name, apiActor(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "프로젝트 삭제 실패: " + err.Error()})
+			return
+		}
+		if !removed {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "프로젝트를 찾을 수 없습니다."})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
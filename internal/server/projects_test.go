@@ -0,0 +1,74 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+func TestApprovalRequiresReadOnlyAgent(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+
+	// readonly_args가 없어 계획 단계를 읽기 전용으로 실행할 수 없는 백엔드
+	agent, err := worker.NewTemplateAgent("writer", worker.AgentTemplate{Command: "writer", Args: []string{"{{prompt}}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Agents.Register(agent); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := addProject(nil, cfg, "reviewed", repo, false, true, nil, "writer", 0, "U1"); err == nil {
+		t.Error("addProject(approval, writer) error = nil, want 거부")
+	}
+	if _, err := addProject(nil, cfg, "plain", repo, false, false, nil, "writer", 0, "U1"); err != nil {
+		t.Fatalf("addProject(plain): %v", err)
+	}
+	if text := projectSetText(nil, cfg, "plain", "approval", []string{"on"}, "U1"); !strings.HasPrefix(text, "❌") {
+		t.Errorf("approval on = %q, want 거부", text)
+	}
+
+	if _, err := addProject(nil, cfg, "reviewed", repo, false, true, nil, "", 0, "U1"); err != nil {
+		t.Fatalf("addProject(reviewed): %v", err)
+	}
+	if text := projectSetText(nil, cfg, "reviewed", "agent", []string{"writer"}, "U1"); !strings.HasPrefix(text, "❌") {
+		t.Errorf("agent writer = %q, want 거부", text)
+	}
+	if _, err := resolveJobTarget(cfg, worker.PromptSpec{Project: "reviewed", Agent: "writer", Prompt: "수정해줘"}, "", ""); err == nil {
+		t.Error("resolveJobTarget(--agent writer) error = nil, want 거부")
+	}
+	if _, err := resolveJobTarget(cfg, worker.PromptSpec{Project: "reviewed", Prompt: "수정해줘"}, "", ""); err != nil {
+		t.Errorf("resolveJobTarget(cursor): %v", err)
+	}
+}
+
+func TestPathTargetUsesRegisteredProject(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	if err := os.MkdirAll(filepath.Join(repo, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := addProject(nil, cfg, "reviewed", repo, false, true, nil, "", 0, "U1"); err != nil {
+		t.Fatalf("addProject: %v", err)
+	}
+	// 경로만 바인딩한 채널 (프로젝트의 하위 디렉토리)
+	if err := cfg.DB.SetChannelBinding(&database.ChannelBinding{TeamID: "T1", ChannelID: "C2", ProjectPath: filepath.Join(repo, "sub"), BoundAt: time.Now()}); err != nil {
+		t.Fatalf("SetChannelBinding: %v", err)
+	}
+
+	for _, channelID := range []string{"C1", "C2"} { // 기본 경로, 경로 바인딩
+		target, err := resolveJobTarget(cfg, worker.PromptSpec{Prompt: "수정해줘"}, "T1", channelID)
+		if err != nil {
+			t.Fatalf("resolveJobTarget(%s): %v", channelID, err)
+		}
+		if target.ProjectName != "reviewed" || !target.requireApproval(worker.PromptSpec{}) {
+			t.Errorf("%s: target = %q (approval %v), want @reviewed 승인 필요", channelID, target.ProjectName, target.requireApproval(worker.PromptSpec{}))
+		}
+	}
+}
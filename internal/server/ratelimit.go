@@ -0,0 +1,168 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// RateLimits는 Slack 요청의 작업 등록 제한입니다 (v1.5)
+// 값이 0이면 해당 제한을 적용하지 않습니다. 사용량은 usage_counters 테이블에 저장되어 재시작 후에도 유지됩니다.
+type RateLimits struct {
+	JobsPerUserPerHour        int // 사용자별 시간당 등록할 수 있는 작업 수 (RATE_LIMIT_USER_HOURLY)
+	ConcurrentJobsPerUser     int // 사용자별 동시에 대기/실행/승인 대기 중일 수 있는 작업 수 (RATE_LIMIT_USER_CONCURRENT)
+	ChannelAgentMinutesPerDay int // 채널별 하루 cursor-agent 실행 시간(분) (RATE_LIMIT_CHANNEL_DAILY_MINUTES)
+}
+
+// quotaError는 제한을 넘어 작업 등록이 거부되었음을 나타냅니다 (v1.5)
+type quotaError struct {
+	Reason     string
+	RetryAfter time.Duration // 다시 요청할 수 있을 때까지 남은 시간 (알 수 없으면 0)
+}
+
+func (e *quotaError) Error() string {
+	return "요청 한도 초과: " + e.Reason
+}
+
+// enqueueJob은 요청자의 권한과 요청자/채널 제한을 확인한 뒤 작업을 큐에 등록합니다 (v1.5)
+// 권한이 없으면 *permissionError, 제한을 넘으면 *quotaError를 반환합니다.
+// 등록한 작업은 감사 로그에 기록합니다. c가 nil이면(Events API 비동기 처리) 요청 IP 없이 기록합니다.
+func enqueueJob(c *gin.Context, cfg *Config, record *database.JobRecord) error {
+	if err := authorizeJob(cfg, record); err != nil {
+		return err
+	}
+	if err := authorizeAPIKey(c, record); err != nil {
+		return err
+	}
+	charge, err := checkRateLimits(cfg, record, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := cfg.JobQueue.Enqueue(record); err != nil {
+		// 등록되지 않은 작업이 시간당 작업 수를 차지하지 않도록 되돌림
+		charge.refund(cfg, record.ID)
+		return fmt.Errorf("작업 큐 등록 실패: %w", err)
+	}
+	auditJob(cfg, c, record)
+	return nil
+}
+
+// usageCharge는 checkRateLimits가 늘린 시간당 작업 수입니다 (v1.5)
+type usageCharge struct {
+	userID string
+	period string
+}
+
+// refund는 늘린 시간당 작업 수를 되돌립니다. 늘리지 않았으면(nil) 아무것도 하지 않습니다.
+func (u *usageCharge) refund(cfg *Config, jobID string) {
+	if u == nil {
+		return
+	}
+	if err := cfg.DB.AddUsage(database.UsageUserJobs, u.userID, u.period, -1); err != nil {
+		log.Printf("[%s] 시간당 작업 수 되돌리기 실패: %v", jobID, err)
+	}
+}
+
+// checkRateLimits는 작업을 등록해도 되는지 확인하고, 통과하면 시간당 작업 수를 1 늘립니다.
+// 늘린 사용량을 반환하므로, 이후 작업 등록에 실패하면 refund로 되돌려야 합니다.
+// 시간당 작업 수는 다른 제한을 모두 통과한 뒤 마지막에 늘리므로 거부된 요청은 사용량을 차지하지 않습니다.
+//
+// API 요청과 revert 작업(cursor-agent를 실행하지 않음)은 제한하지 않습니다.
+// 카운터 조회에 실패하면 요청을 막지 않고 로그만 남깁니다.
+func checkRateLimits(cfg *Config, record *database.JobRecord, now time.Time) (*usageCharge, error) {
+	limits := cfg.RateLimits
+	if record.UserID == "" || record.UserID == apiUserID || record.Kind == database.JobKindRevert {
+		return nil, nil
+	}
+
+	if limit := limits.ConcurrentJobsPerUser; limit > 0 {
+		active, err := cfg.DB.CountActiveJobs(record.UserID)
+		if err != nil {
+			log.Printf("[%s] 진행 중인 작업 수 조회 실패: %v", record.ID, err)
+		} else if active >= limit {
+			return nil, &quotaError{Reason: fmt.Sprintf("동시에 진행할 수 있는 작업은 최대 %d개입니다 (현재 %d개 대기/실행 중)", limit, active)}
+		}
+	}
+
+	if limit := limits.ChannelAgentMinutesPerDay; limit > 0 && record.ChannelID != "" {
+		used, err := cfg.DB.GetUsage(database.UsageChannelAgentSeconds, record.ChannelID, database.DayPeriod(now))
+		if err != nil {
+			log.Printf("[%s] 채널 사용량 조회 실패: %v", record.ID, err)
+		} else if used >= int64(limit)*60 {
+			return nil, &quotaError{
+				Reason:     fmt.Sprintf("이 채널의 오늘 cursor-agent 실행 시간 %d분을 모두 사용했습니다 (사용: %d분)", limit, used/60),
+				RetryAfter: time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Sub(now),
+			}
+		}
+	}
+
+	if limit := limits.JobsPerUserPerHour; limit > 0 {
+		period := database.HourPeriod(now)
+		ok, count, err := cfg.DB.TryAddUsage(database.UsageUserJobs, record.UserID, period, 1, int64(limit))
+		if err != nil {
+			log.Printf("[%s] 시간당 작업 수 기록 실패: %v", record.ID, err)
+		} else if !ok {
+			return nil, &quotaError{
+				Reason:     fmt.Sprintf("시간당 최대 %d개 작업을 요청할 수 있습니다 (이번 시간: %d개)", limit, count),
+				RetryAfter: time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location()).Sub(now),
+			}
+		} else {
+			return &usageCharge{userID: record.UserID, period: period}, nil
+		}
+	}
+	return nil, nil
+}
+
+// enqueueErrorStatus는 enqueueJob 에러를 API 응답 상태 코드로 변환합니다 (v1.5)
+// 한도 초과는 429(다시 요청할 수 있는 시간을 알면 Retry-After 헤더 포함), 권한 없음은 403,
+// 그 밖의 에러(DB, 큐 저장 실패)는 500입니다.
+func enqueueErrorStatus(c *gin.Context, err error) int {
+	var qe *quotaError
+	if errors.As(err, &qe) {
+		if qe.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(qe.RetryAfter.Seconds()))))
+		}
+		return http.StatusTooManyRequests
+	}
+	if errors.As(err, new(*permissionError)) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// quotaErrorText는 err가 *quotaError이면 Slack 거부 메시지를 반환합니다.
+func quotaErrorText(err error) (string, bool) {
+	var qe *quotaError
+	if !errors.As(err, &qe) {
+		return "", false
+	}
+	text := "⏳ *요청 한도를 초과했습니다*\n> " + qe.Reason
+	if qe.RetryAfter > 0 {
+		text += fmt.Sprintf("\n💡 %s 후에 다시 요청할 수 있습니다.", formatWait(qe.RetryAfter))
+	} else {
+		text += "\n💡 진행 중인 작업이 끝난 뒤 다시 요청하거나 `/cursor cancel <job-id>`로 취소하세요."
+	}
+	return text, true
+}
+
+// formatWait는 대기 시간을 "1시간 5분", "3분" 형식으로 표시합니다 (1분 미만은 1분).
+func formatWait(d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("%d분", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d시간", minutes/60)
+	}
+	return fmt.Sprintf("%d시간 %d분", minutes/60, minutes%60)
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+func TestEnqueueJobRefundsHourlyUsageOnFailure(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	cfg.RateLimits.JobsPerUserPerHour = 2
+	repo, _ := cfg.GetProjectPath()
+
+	newRecord := func(id string) *database.JobRecord {
+		return &database.JobRecord{ID: id, Prompt: "README 정리해줘", ProjectPath: repo, UserID: "U1", ChannelID: "C1", CreatedAt: time.Now()}
+	}
+	usage := func() int64 {
+		t.Helper()
+		used, err := cfg.DB.GetUsage(database.UsageUserJobs, "U1", database.HourPeriod(time.Now()))
+		if err != nil {
+			t.Fatalf("GetUsage: %v", err)
+		}
+		return used
+	}
+
+	if err := enqueueJob(nil, cfg, newRecord("11111111-0000-0000-0000-000000000000")); err != nil {
+		t.Fatalf("enqueueJob: %v", err)
+	}
+	if got := usage(); got != 1 {
+		t.Fatalf("usage = %d, want 1", got)
+	}
+
+	// 같은 ID로 등록하면 큐 저장에 실패하므로 늘린 사용량을 되돌려야 함
+	err := enqueueJob(nil, cfg, newRecord("11111111-0000-0000-0000-000000000000"))
+	if err == nil {
+		t.Fatal("중복 ID 등록: want error")
+	}
+	var qe *quotaError
+	if errors.As(err, &qe) {
+		t.Fatalf("error = %v, want 큐 등록 실패", err)
+	}
+	if got := usage(); got != 1 {
+		t.Errorf("등록 실패 후 usage = %d, want 1", got)
+	}
+
+	// 되돌린 사용량으로 한도 안에서 한 번 더 등록할 수 있음
+	if err := enqueueJob(nil, cfg, newRecord("22222222-0000-0000-0000-000000000000")); err != nil {
+		t.Fatalf("enqueueJob: %v", err)
+	}
+	err = enqueueJob(nil, cfg, newRecord("33333333-0000-0000-0000-000000000000"))
+	if !errors.As(err, &qe) {
+		t.Errorf("한도 초과 error = %v, want quotaError", err)
+	}
+	if got := usage(); got != 2 {
+		t.Errorf("한도 초과 후 usage = %d, want 2", got)
+	}
+}
+
+func TestEnqueueErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{"hourly quota", &quotaError{Reason: "시간당", RetryAfter: 90*time.Second + 500*time.Millisecond}, http.StatusTooManyRequests, "91"},
+		{"concurrent quota", &quotaError{Reason: "동시 작업"}, http.StatusTooManyRequests, ""},
+		{"permission", fmt.Errorf("거부: %w", &permissionError{Required: database.RoleOperator}), http.StatusForbidden, ""},
+		{"queue failure", fmt.Errorf("작업 큐 등록 실패: %w", errors.New("database is locked")), http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			if got := enqueueErrorStatus(c, tt.err); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// userGroupCacheTTL은 Slack 사용자 그룹 구성원 조회 결과를 재사용하는 기간입니다.
+const userGroupCacheTTL = 5 * time.Minute
+
+// RBAC는 역할 기반 접근 제어 설정입니다 (v1.5)
+//
+// Admins가 비어 있으면 접근 제어를 적용하지 않고 모든 요청을 admin으로 취급합니다 (이전 버전과 같은 동작).
+// 역할은 Slack user_id 또는 user group ID에 부여하며(`/cursor admin grant`), 사용자의 역할은
+// DefaultRole, 본인에게 부여된 역할, 속한 사용자 그룹에 부여된 역할 중 가장 높은 역할입니다.
+type RBAC struct {
+	Admins      []string      // 항상 admin인 Slack user_id / user group ID (RBAC_ADMINS, 설정하면 접근 제어 활성화)
+	DefaultRole database.Role // 역할이 부여되지 않은 Slack 사용자의 역할 (RBAC_DEFAULT_ROLE, 기본값: 없음)
+}
+
+// Enabled는 접근 제어가 활성화되어 있는지 반환합니다.
+func (r RBAC) Enabled() bool {
+	return len(r.Admins) > 0
+}
+
+// permissionError는 역할 또는 프로젝트 허용 목록 때문에 요청이 거부되었음을 나타냅니다 (v1.5)
+type permissionError struct {
+	Required database.Role // 필요한 최소 역할
+	Current  database.Role // 요청자의 역할
+	Project  string        // 허용 목록에 없어 거부된 프로젝트 (역할이 부족하면 빈 값)
+	APIKey   string        // 프로젝트 허용 목록 때문에 거부된 API 키 이름 (Slack 요청이면 빈 값)
+}
+
+func (e *permissionError) Error() string {
+	if e.APIKey != "" {
+		if e.Project == "" {
+			return fmt.Sprintf("API 키 `%s`는 등록된 프로젝트(@이름)의 작업만 실행할 수 있습니다", e.APIKey)
+		}
+		return fmt.Sprintf("API 키 `%s`는 @%s 프로젝트에서 작업을 실행할 수 없습니다", e.APIKey, e.Project)
+	}
+	if e.Project != "" {
+		return fmt.Sprintf("@%s 프로젝트에서 작업을 실행할 권한이 없습니다", e.Project)
+	}
+	return fmt.Sprintf("%s 이상의 역할이 필요합니다 (현재 역할: %s)", e.Required, roleName(e.Current))
+}
+
+// roleName은 역할을 표시용 이름으로 변환합니다.
+func roleName(role database.Role) string {
+	if role == database.RoleNone {
+		return "없음"
+	}
+	return string(role)
+}
+
+// userRole은 요청자의 역할을 결정합니다.
+// API 요청(apiUserID)은 API 키의 권한 범위(requireScope)와 프로젝트 허용 목록(apiCanViewJob, authorizeAPIKey)으로 확인하므로 admin으로 취급합니다.
+func userRole(cfg *Config, userID string) database.Role {
+	if !cfg.RBAC.Enabled() || userID == apiUserID {
+		return database.RoleAdmin
+	}
+	if userID == "" {
+		return database.RoleNone
+	}
+	if contains(cfg.RBAC.Admins, userID) {
+		return database.RoleAdmin
+	}
+	for _, subject := range cfg.RBAC.Admins {
+		if isUserGroup(subject) && cfg.groups.isMember(cfg, subject, userID) {
+			return database.RoleAdmin
+		}
+	}
+
+	role := cfg.RBAC.DefaultRole
+	assignments, err := cfg.DB.ListRoles()
+	if err != nil {
+		log.Printf("역할 조회 실패 (%s): %v", userID, err)
+		return role
+	}
+	for _, a := range assignments {
+		if a.Role.Rank() <= role.Rank() {
+			continue
+		}
+		if a.Subject == userID || (isUserGroup(a.Subject) && cfg.groups.isMember(cfg, a.Subject, userID)) {
+			role = a.Role
+		}
+	}
+	return role
+}
+
+// requireRole은 요청자의 역할이 required 이상인지 확인합니다. 부족하면 *permissionError를 반환합니다.
+func requireRole(cfg *Config, userID string, required database.Role) error {
+	if required == database.RoleNone {
+		return nil
+	}
+	if role := userRole(cfg, userID); role.Rank() < required.Rank() {
+		return &permissionError{Required: required, Current: role}
+	}
+	return nil
+}
+
+// authorizeJob은 요청자가 작업을 실행할 수 있는지 확인합니다 (v1.5)
+// operator 이상이어야 하며, 프로젝트에 허용 목록이 있으면 admin이거나 목록의 사용자/그룹에 속해야 합니다.
+func authorizeJob(cfg *Config, record *database.JobRecord) error {
+	if !cfg.RBAC.Enabled() {
+		return nil
+	}
+	role := userRole(cfg, record.UserID)
+	if role.Rank() < database.RoleOperator.Rank() {
+		return &permissionError{Required: database.RoleOperator, Current: role}
+	}
+	allowed, err := projectAllowed(cfg, record.UserID, role, record.ProjectName)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &permissionError{Required: database.RoleOperator, Current: role, Project: record.ProjectName}
+	}
+	return nil
+}
+
+// canViewJob은 Slack 사용자가 작업의 프롬프트와 결과를 볼 수 있는지 반환합니다 (v1.5)
+// 허용 목록이 있는 프로젝트의 작업은 admin, 목록의 사용자/그룹, 작업 요청자만 볼 수 있습니다.
+func canViewJob(cfg *Config, userID string, job *database.JobRecord) bool {
+	if !cfg.RBAC.Enabled() || job.UserID == userID {
+		return true
+	}
+	allowed, err := projectAllowed(cfg, userID, userRole(cfg, userID), job.ProjectName)
+	if err != nil {
+		log.Printf("[%s] 프로젝트 허용 목록 조회 실패: %v", job.ID, err)
+		return false
+	}
+	return allowed
+}
+
+// projectAllowed는 프로젝트 허용 목록에 요청자 또는 요청자가 속한 사용자 그룹이 있는지 반환합니다.
+// 이름 없는 프로젝트, 허용 목록이 없는 프로젝트, admin은 항상 허용합니다.
+func projectAllowed(cfg *Config, userID string, role database.Role, projectName string) (bool, error) {
+	if projectName == "" || role == database.RoleAdmin {
+		return true, nil
+	}
+	project, err := cfg.DB.GetProject(projectName)
+	if err != nil {
+		return false, err
+	}
+	if project == nil || len(project.AllowedUsers) == 0 {
+		return true, nil
+	}
+	for _, subject := range project.AllowedUsers {
+		if subject == userID || (isUserGroup(subject) && cfg.groups.isMember(cfg, subject, userID)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// commandRole은 `/cursor` 하위 명령어에 필요한 최소 역할입니다 (v1.5)
+// 작업을 등록하는 명령(프롬프트, reply, revert)은 enqueueJob에서 역할과 프로젝트 허용 목록을 확인하고,
+// 승인/거절은 승인자 목록으로 확인합니다.
+func commandRole(command string, args []string) database.Role {
+	switch command {
+	case "list", "jobs", "show", "result", "path", "get-path":
+		return database.RoleViewer
+	case "cancel", "stop":
+		return database.RoleOperator
+	case "set-path", "unbind", "admin":
+		return database.RoleAdmin
+	case "bind":
+		if len(args) == 0 {
+			return database.RoleViewer
+		}
+		return database.RoleAdmin
+	case "project", "projects":
+		if len(args) == 0 || args[0] == "list" || args[0] == "ls" {
+			return database.RoleViewer
+		}
+		return database.RoleAdmin
+	default:
+		return database.RoleNone
+	}
+}
+
+// rejectionText는 err가 권한 부족 또는 요청 한도 초과이면 요청자에게 보여줄 거부 메시지를 반환합니다 (v1.5)
+func rejectionText(err error) (string, bool) {
+	var pe *permissionError
+	if errors.As(err, &pe) {
+		text := "🔒 *권한이 없습니다*\n> " + pe.Error()
+		if pe.Project != "" {
+			text += "\n💡 프로젝트 허용 목록은 관리자가 `/cursor admin allow`로 관리합니다."
+		} else {
+			text += "\n💡 역할은 관리자가 `/cursor admin grant`로 부여합니다."
+		}
+		return text, true
+	}
+	return quotaErrorText(err)
+}
+
+// isUserGroup은 대상이 Slack 사용자 그룹 ID(S...)인지 반환합니다.
+func isUserGroup(subject string) bool {
+	return strings.HasPrefix(subject, "S")
+}
+
+// userGroupCache는 Slack 사용자 그룹 구성원 조회 결과를 캐시합니다 (v1.5)
+type userGroupCache struct {
+	mu      sync.Mutex
+	entries map[string]userGroupEntry
+}
+
+type userGroupEntry struct {
+	members   []string
+	fetchedAt time.Time
+}
+
+// isMember는 userID가 사용자 그룹에 속하는지 반환합니다.
+// 봇 토큰이 없거나 조회에 실패하면 이전 조회 결과를 사용하고, 결과가 없으면 구성원이 아닌 것으로 처리합니다.
+func (g *userGroupCache) isMember(cfg *Config, groupID string, userID string) bool {
+	g.mu.Lock()
+	entry, ok := g.entries[groupID]
+	g.mu.Unlock()
+
+	if !ok || time.Since(entry.fetchedAt) > userGroupCacheTTL {
+		if cfg.Slack == nil {
+			log.Printf("SLACK_BOT_TOKEN이 없어 사용자 그룹 %s의 구성원을 확인할 수 없습니다", groupID)
+			return false
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		members, err := cfg.Slack.UserGroupMembers(ctx, groupID)
+		cancel()
+		if err != nil {
+			log.Printf("사용자 그룹 %s 구성원 조회 실패: %v", groupID, err)
+		} else {
+			entry = userGroupEntry{members: members, fetchedAt: time.Now()}
+		}
+		// 실패해도 만료 시각을 갱신하여 요청마다 다시 조회하지 않음
+		entry.fetchedAt = time.Now()
+		g.mu.Lock()
+		if g.entries == nil {
+			g.entries = make(map[string]userGroupEntry)
+		}
+		g.entries[groupID] = entry
+		g.mu.Unlock()
+	}
+	return contains(entry.members, userID)
+}
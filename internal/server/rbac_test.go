@@ -0,0 +1,181 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+func TestCancelJobRespectsProjectAllowList(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	cfg.RBAC = RBAC{Admins: []string{"UADMIN"}, DefaultRole: database.RoleOperator}
+	if err := cfg.DB.CreateProject(&database.Project{Name: "backend", Path: repo, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if _, err := cfg.DB.SetProjectAllowedUsers("backend", []string{"U2"}); err != nil {
+		t.Fatalf("SetProjectAllowedUsers: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		// 허용 목록에 없는 operator에게는 작업이 있다는 사실도 드러내지 않음
+		{"operator outside allow-list", "U1", errJobNotFound},
+		{"allowed operator", "U2", nil},
+		{"admin", "UADMIN", nil},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &database.JobRecord{
+				ID:          string(rune('a'+i)) + "1111111-0000-0000-0000-000000000000",
+				Prompt:      "README 정리해줘",
+				ProjectName: "backend",
+				ProjectPath: repo,
+				UserID:      "U3",
+				CreatedAt:   time.Now(),
+			}
+			if err := cfg.JobQueue.Enqueue(record); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+
+			_, err := cancelJob(cfg, record.ID, tt.userID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("cancelJob error = %v, want %v", err, tt.wantErr)
+			}
+			job, _ := cfg.DB.GetJob(record.ID)
+			if wantStatus := database.JobStatusCancelled; tt.wantErr != nil {
+				wantStatus = database.JobStatusPending
+				if job.Status != wantStatus {
+					t.Errorf("status = %s, want %s", job.Status, wantStatus)
+				}
+			} else if job.Status != wantStatus || job.CancelledBy != tt.userID {
+				t.Errorf("status = %s, cancelled_by = %q", job.Status, job.CancelledBy)
+			}
+		})
+	}
+}
+
+func TestAPIKeyProjectAllowList(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	for _, name := range []string{"backend", "frontend"} {
+		if err := cfg.DB.CreateProject(&database.Project{Name: name, Path: repo, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+	key := &database.APIKey{ID: "key-1", Name: "ci-bot", Scopes: []string{database.ScopeRun, database.ScopeRead}, Projects: []string{"backend"}}
+
+	hidden := &database.JobRecord{
+		ID:          "c1111111-0000-0000-0000-000000000000",
+		Prompt:      "README 정리해줘",
+		ProjectName: "frontend",
+		ProjectPath: repo,
+		UserID:      "U1",
+		CreatedAt:   time.Now(),
+	}
+	if err := cfg.JobQueue.Enqueue(hidden); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	apiContext := func(id string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/jobs/"+id, nil)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Set(apiKeyContextKey, key)
+		return c, w
+	}
+
+	// 허용 목록 밖의 작업은 조회, 스트리밍, 취소 모두 찾을 수 없는 작업으로 응답
+	for name, handler := range map[string]gin.HandlerFunc{
+		"get":    HandleGetJob(cfg),
+		"stream": HandleStreamJob(cfg),
+		"cancel": HandleCancelJob(cfg),
+	} {
+		c, w := apiContext(hidden.ID)
+		handler(c)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s status = %d, want 404", name, w.Code)
+		}
+	}
+	if job, _ := cfg.DB.GetJob(hidden.ID); job.Status != database.JobStatusPending {
+		t.Errorf("status = %s, want pending", job.Status)
+	}
+
+	c, w := apiContext("")
+	HandleListJobs(cfg)(c)
+	var jobs []*database.JobRecord
+	if err := json.Unmarshal(w.Body.Bytes(), &jobs); err != nil || len(jobs) != 0 {
+		t.Errorf("list = %s, want 빈 목록", w.Body.String())
+	}
+
+	c, _ = apiContext("")
+	for _, tt := range []struct {
+		project string
+		wantErr bool
+	}{{"backend", false}, {"frontend", true}, {"", true}} {
+		record := apiJobBase(c)
+		record.ID = "d" + tt.project + "-0000-0000-0000-000000000000"
+		record.Prompt = "README 정리해줘"
+		record.ProjectName = tt.project
+		record.ProjectPath = repo
+		record.CreatedAt = time.Now()
+		err := enqueueJob(c, cfg, record)
+		if got := errors.As(err, new(*permissionError)); got != tt.wantErr {
+			t.Errorf("enqueueJob(%q) error = %v, want permission error %v", tt.project, err, tt.wantErr)
+		}
+	}
+}
+
+func TestListJobsFillsPagesWithVisibleJobs(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	repo, _ := cfg.GetProjectPath()
+	key := &database.APIKey{ID: "key-1", Name: "ci-bot", Scopes: []string{database.ScopeRead}, Projects: []string{"backend"}}
+
+	// 최근 작업 대부분이 허용 목록 밖의 프로젝트인 경우 (한 번에 조회하는 수보다 많음)
+	start := time.Now()
+	for i := 0; i < listVisibleJobsBatch+20; i++ {
+		project := "frontend"
+		if i%40 == 0 {
+			project = "backend"
+		}
+		job := &database.JobRecord{ID: fmt.Sprintf("%08d-0000-0000-0000-000000000000", i), Prompt: "README 정리해줘", ProjectName: project,
+			ProjectPath: repo, UserID: "U1", Status: database.JobStatusCompleted, CreatedAt: start.Add(time.Duration(i) * time.Second)}
+		if err := cfg.DB.CreateJob(job); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  []string
+	}{
+		{"limit=2", []string{"00000080", "00000040"}},
+		{"limit=2&offset=2", []string{"00000000"}},
+	} {
+		c, w := apiTestContext(key, http.MethodGet, "/api/jobs?"+tt.query, "")
+		HandleListJobs(cfg)(c)
+		var jobs []*database.JobRecord
+		if err := json.Unmarshal(w.Body.Bytes(), &jobs); err != nil {
+			t.Fatalf("%s: %v (%s)", tt.query, err, w.Body.String())
+		}
+		var got []string
+		for _, job := range jobs {
+			got = append(got, job.ID[:8])
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: jobs = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// errJobNotRevertable은 되돌릴 수 없는 작업에 대한 요청 오류입니다 (v1.5)
+var errJobNotRevertable = errors.New("되돌릴 수 없는 작업입니다")
+
+// RevertJobResponse는 되돌리기 요청 접수 응답입니다 (v1.5)
+type RevertJobResponse struct {
+	JobID       string `json:"job_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentJobID string `json:"parent_job_id" example:"3f2c1a9e-0b7d-4c1e-9a55-2d6f0e8b1c44"`
+	Status      string `json:"status" example:"pending"`
+}
+
+// enqueueRevert는 대상 작업을 되돌리는 revert 작업을 큐에 등록합니다 (v1.5)
+// 요청 정보(요청자, 채널, response_url)는 base에서 가져옵니다. Slack 명령어와 API가 공통으로 사용합니다.
+func enqueueRevert(c *gin.Context, cfg *Config, targetID string, base *database.JobRecord) (*database.JobRecord, *database.JobRecord, error) {
+	target, err := cfg.DB.GetJob(targetID)
+	if err != nil || target == nil {
+		return nil, nil, errJobNotFound
+	}
+	if err := worker.CheckRevertable(target); err != nil {
+		return nil, target, fmt.Errorf("%w: %v", errJobNotRevertable, err)
+	}
+
+	record := base
+	if record.ID == "" {
+		record.ID = uuid.NewString()
+	}
+	record.Kind = database.JobKindRevert
+	record.ParentJobID = target.ID
+	record.Prompt = fmt.Sprintf("revert %s", target.ID[:8])
+	record.ProjectName = target.ProjectName
+	record.ProjectPath = target.ProjectPath
+	record.CreatedAt = time.Now()
+
+	// v1.5: 역할/프로젝트 허용 목록 확인 (revert 작업은 요청 한도에서 제외)
+	if err := enqueueJob(c, cfg, record); err != nil {
+		return nil, target, err
+	}
+	log.Printf("[%s] 작업 %s 되돌리기 요청 (요청자: %s)", record.ID, target.ID, record.UserID)
+	return record, target, nil
+}
+
+// handleRevertCommand는 `/cursor revert <job-id>`를 처리합니다 (v1.5)
+func handleRevertCommand(c *gin.Context, cfg *Config, payload types.SlackCommandPayload, jobID string) {
+	record, target, err := enqueueRevert(c, cfg, jobID, &database.JobRecord{
+		UserID:       payload.UserID,
+		UserName:     payload.UserName,
+		ResponseURL:  payload.ResponseURL,
+		ChannelID:    payload.ChannelID,
+		ChannelName:  payload.ChannelName,
+		TeamID:       payload.TeamID,
+		EnterpriseID: payload.EnterpriseID,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"response_type": "ephemeral",
+		"text":          revertResultText(jobID, record, target, err),
+	})
+}
+
+// revertResultText는 되돌리기 요청 결과를 Slack 메시지로 변환합니다 (v1.5: 명령어/버튼 공용)
+func revertResultText(jobID string, record *database.JobRecord, target *database.JobRecord, err error) string {
+	if text, rejected := rejectionText(err); rejected {
+		return text
+	}
+	switch {
+	case errors.Is(err, errJobNotFound):
+		return fmt.Sprintf("❌ 작업을 찾을 수 없습니다: `%s`\n\n💡 `/cursor list` 명령어로 최근 작업 목록을 확인하세요.", jobID)
+	case errors.Is(err, errJobNotRevertable):
+		return fmt.Sprintf("⚠️ `%s` %s", target.ID[:8], err.Error())
+	case err != nil:
+		log.Printf("되돌리기 요청 실패 (%s): %v", jobID, err)
+		return "❌ 되돌리기 작업을 등록하는 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요."
+	default:
+		return fmt.Sprintf("↩️ 작업 `%s`의 변경 사항을 되돌리는 중입니다... (ID: `%s`)\n📁 대상: %s\n> %s",
+			target.ID[:8], record.ID[:8], formatProject(target.ProjectName, target.ProjectPath), target.Prompt)
+	}
+}
+
+// HandleRevertJob godoc
+// @Summary      작업 되돌리기 (v1.5)
+// @Description  작업의 실행 전 스냅샷으로 변경 파일을 복원하는 revert 작업을 큐에 등록합니다.
+// @Description  revert 작업은 별도 작업 레코드(kind=revert, parent_job_id)로 기록되며, 이후 작업이 같은 파일을 변경했거나 완료 후 파일이 직접 수정된 경우 실패합니다.
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string             true  "Job ID"
+// @Success      202  {object}  RevertJobResponse  "되돌리기 작업 접수"
+// @Failure      401  {object}  ErrorResponse      "인증 실패"
+// @Failure      403  {object}  ErrorResponse      "권한 없음"
+// @Failure      404  {object}  ErrorResponse      "작업을 찾을 수 없음"
+// @Failure      409  {object}  ErrorResponse      "되돌릴 수 없는 작업"
+// @Failure      429  {object}  ErrorResponse      "요청 한도 초과"
+// @Security     BearerAuth
+// @Security     APIKeyHeader
+// @Router       /api/jobs/{id}/revert [post]
+func HandleRevertJob(cfg *Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiHidesJob(c, cfg, c.Param("id")) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: errJobNotFound.Error()})
+			return
+		}
+		record, _, err := enqueueRevert(c, cfg, c.Param("id"), apiJobBase(c))
+		switch {
+		case errors.Is(err, errJobNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, errJobNotRevertable):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		case err != nil:
+			c.JSON(enqueueErrorStatus(c, err), ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusAccepted, RevertJobResponse{
+				JobID:       record.ID,
+				ParentJobID: record.ParentJobID,
+				Status:      string(database.JobStatusPending),
+			})
+		}
+	}
+}
@@ -3,10 +3,13 @@ package server
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/redact"
 	"github.com/kakaovx/cursor-slack-server/internal/server/middleware"
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
 	"github.com/kakaovx/cursor-slack-server/internal/worker"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -17,15 +20,29 @@ import (
 // v1.2: ProjectPath를 동적으로 관리 (런타임 설정/변경 가능)
 // v1.3: SQLite DB 추가 (작업 결과 저장)
 // v1.4: Worker Pool 추가 (동시 실행 제어)
+// v1.5: Executor 추가 (작업 취소), JobQueue를 DB 기반 영속 큐로 변경
 type Config struct {
 	SigningSecret          string
 	projectPath            string           // private: 동적 설정
 	Port                   string
-	CursorCLIPath          string
+	Agents                 *worker.AgentRegistry // v1.5: 에이전트 백엔드 (cursor-agent + AGENTS_CONFIG, 기존 CursorCLIPath 대체)
 	AllowedResponseDomains []string         // SSRF 방어용 허용 도메인 목록
 	DB                     *database.DB     // SQLite 데이터베이스
 	Dispatcher             *worker.Dispatcher // Worker Pool 디스패처
-	JobQueue               chan worker.Job    // 작업 큐
+	JobQueue               *worker.Queue      // 작업 큐 (v1.5: job_records 기반 영속 큐)
+	Executor               *worker.TaskExecutor // 작업 실행기 (v1.5: 실행 중인 작업 취소)
+	ApprovalReviewers      []string         // v1.5: 기본 승인자 Slack user_id (프로젝트에 승인자가 없을 때, APPROVAL_REVIEWERS)
+	Slack                  *slack.Client    // v1.5: Slack Web API 클라이언트 (SLACK_BOT_TOKEN, 없으면 멘션/DM 요청을 처리하지 않음)
+	RateLimits             RateLimits       // v1.5: 사용자/채널별 작업 등록 제한 (0이면 제한 없음)
+	RBAC                   RBAC             // v1.5: 역할 기반 접근 제어 (RBAC_ADMINS가 없으면 비활성)
+	APIAuthDisabled        bool             // v1.5: /api 요청의 API 키 인증을 끔 (API_AUTH=off, 로컬 개발용)
+	AllowedProjectRoots    []string         // v1.5: 프로젝트 경로로 허용하는 디렉토리 (ALLOWED_PROJECT_ROOTS, 비어 있으면 제한 없음)
+	Redactor               *redact.Redactor // v1.5: Slack 메시지와 감사 로그에 표시하는 프롬프트의 비밀 값 가리기 (nil이면 가리지 않음)
+	JobTimeout             time.Duration    // v1.5: 작업 시간 제한 기본값 (JOB_TIMEOUT, 프로젝트/요청별로 변경 가능)
+	MaxJobTimeout          time.Duration    // v1.5: 프로젝트/요청별로 지정할 수 있는 최대 시간 제한 (JOB_TIMEOUT_MAX)
+	JobKillGrace           time.Duration    // v1.5: 시간 초과/취소 시 SIGTERM 후 SIGKILL까지 기다리는 시간 (JOB_KILL_GRACE)
+	groups                 userGroupCache   // v1.5: Slack 사용자 그룹 구성원 캐시
+	events                 eventDeduper     // v1.5: Events API 재전송 중복 제거
 	mu                     sync.RWMutex
 }
 
@@ -49,9 +66,11 @@ func (c *Config) GetProjectPath() (string, bool) {
 // ToWorkerConfig는 Config를 worker.ConfigFull로 변환합니다.
 func (c *Config) ToWorkerConfig() *worker.ConfigFull {
 	return &worker.ConfigFull{
-		CursorCLIPath: c.CursorCLIPath,
-		DB:            c.DB,
-		Config:        c,
+		Agents:     c.Agents,
+		JobTimeout: c.JobTimeout,
+		KillGrace:  c.JobKillGrace,
+		DB:         c.DB,
+		Config:     c,
 	}
 }
 
@@ -73,27 +92,63 @@ func SetupRouter(cfg *Config) *gin.Engine {
 		
 		// Options API for autocomplete
 		slackApi.POST("/cursor/options", HandleSlackOptions(cfg))
+
+		// v1.5: 메시지 버튼 (취소, 다시 실행, 전체 출력, 되돌리기)
+		slackApi.POST("/interactions", HandleSlackInteractions(cfg))
+
+		// v1.5: Events API (@멘션, DM) - 결과는 요청한 스레드에 게시
+		slackApi.POST("/events", HandleSlackEvents(cfg))
 	}
 
-	// 일반 API 엔드포인트 그룹 (인증 불필요 - 테스트/개발용)
+	// 일반 API 엔드포인트 그룹
+	// v1.5: 요청 ID 부여(감사 로그), API 키 인증 (Authorization: Bearer 또는 X-API-Key) 및 엔드포인트별 권한 범위 확인
 	api := r.Group("/api")
+	api.Use(middleware.RequestIDMiddleware(), apiKeyAuth(cfg))
 	{
+		read := requireScope(cfg, database.ScopeRead)
+		run := requireScope(cfg, database.ScopeRun)
+		configure := requireScope(cfg, database.ScopeConfigure)
+		allProjects := requireAllProjects() // 프로젝트 허용 목록이 있는 키 거부
+
 		// Cursor 실행 API
-		api.POST("/cursor", HandleAPICursor(cfg))
+		api.POST("/cursor", run, HandleAPICursor(cfg))
 
 		// 설정 API (v1.2: 동적 프로젝트 경로 관리)
 		config := api.Group("/config")
 		{
-			config.GET("/project-path", HandleGetProjectPath(cfg))
-			config.POST("/project-path", HandleSetProjectPath(cfg))
+			config.GET("/project-path", read, HandleGetProjectPath(cfg))
+			config.POST("/project-path", configure, allProjects, HandleSetProjectPath(cfg))
+		}
+
+		// 프로젝트 API (v1.5: 이름 있는 프로젝트)
+		projects := api.Group("/projects")
+		{
+			projects.GET("", read, HandleListProjects(cfg))
+			projects.POST("", configure, allProjects, HandleCreateProject(cfg))
+			projects.DELETE("/:name", configure, allProjects, HandleDeleteProject(cfg))
 		}
 
 		// 작업 관리 API (v1.3: 작업 결과 조회)
 		jobs := api.Group("/jobs")
 		{
-			jobs.GET("/:id", HandleGetJob(cfg))
-			jobs.GET("", HandleListJobs(cfg))
+			jobs.GET("/:id", read, HandleGetJob(cfg))
+			jobs.GET("/:id/stream", read, HandleStreamJob(cfg)) // v1.5: 실시간 출력 스트리밍 (SSE)
+			jobs.DELETE("/:id", run, HandleCancelJob(cfg))     // v1.5: 작업 취소
+			jobs.POST("/:id/revert", run, HandleRevertJob(cfg)) // v1.5: 작업 되돌리기
+			jobs.POST("/:id/continue", run, HandleContinueJob(cfg)) // v1.5: 세션 이어서 실행
+			jobs.GET("", read, HandleListJobs(cfg))
 		}
+
+		// API 키 관리 (v1.5)
+		keys := api.Group("/keys")
+		{
+			keys.GET("", configure, HandleListAPIKeys(cfg))
+			keys.POST("", configure, HandleCreateAPIKey(cfg))
+			keys.DELETE("/:id", configure, HandleRevokeAPIKey(cfg))
+		}
+
+		// 감사 로그 조회/내보내기 (v1.5)
+		api.GET("/audit", configure, allProjects, HandleListAudit(cfg))
 	}
 
 	// Health check 엔드포인트
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
+	"github.com/kakaovx/cursor-slack-server/internal/slack/slacktest"
+	"github.com/kakaovx/cursor-slack-server/internal/worker"
+)
+
+// newTestConfig는 임시 DB와 git 저장소, 가짜 Slack 서버를 사용하는 서버 설정을 만듭니다.
+// 프로젝트 경로는 임시 git 저장소로 설정됩니다 (cfg.GetProjectPath).
+func newTestConfig(t *testing.T) (*Config, *slacktest.Server) {
+	t.Helper()
+	dir := t.TempDir()
+
+	repo := filepath.Join(dir, "repo")
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Skipf("git init 실패: %v\n%s", err, out)
+	}
+
+	db, err := database.NewDB(filepath.Join(dir, "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := slack.NewClient(slack.Config{Token: srv.Token, BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("slack.NewClient: %v", err)
+	}
+
+	cfg := &Config{
+		Agents:        worker.NewAgentRegistry(worker.NewCursorAgent("cursor-agent", nil)),
+		DB:            db,
+		JobQueue:      worker.NewQueue(db),
+		Slack:         client,
+		JobTimeout:    worker.DefaultJobTimeout,
+		MaxJobTimeout: worker.DefaultMaxJobTimeout,
+	}
+	cfg.SetProjectPath(repo)
+	return cfg, srv
+}
+
+// listTestJobs는 등록된 작업을 최신 순으로 반환합니다.
+func listTestJobs(t *testing.T, cfg *Config) []*database.JobRecord {
+	t.Helper()
+	jobs, err := cfg.DB.ListJobs(100, 0, "")
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	return jobs
+}
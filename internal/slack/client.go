@@ -0,0 +1,235 @@
+// Package slack은 봇 토큰으로 Slack Web API를 호출하는 클라이언트입니다 (v1.5)
+//
+// 슬래시 명령어의 response_url 대신 채널/스레드에 직접 메시지를 게시할 때 사용합니다.
+// API 기본 URL과 http.Client를 주입받으므로 slacktest 패키지의 가짜 서버로 대체하여 테스트할 수 있습니다.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// DefaultBaseURL은 Slack Web API 기본 주소입니다.
+const DefaultBaseURL = "https://slack.com/api"
+
+// Config는 Slack Web API 클라이언트 설정입니다.
+type Config struct {
+	Token      string       // 봇 토큰 (xoxb-...)
+	BaseURL    string       // API 기본 URL (생략 시 DefaultBaseURL)
+	HTTPClient *http.Client // 생략 시 30초 타임아웃 클라이언트 사용
+}
+
+// Client는 Slack Web API 클라이언트입니다.
+type Client struct {
+	token   string
+	baseURL string
+	http    *http.Client
+}
+
+// Message는 chat.postMessage로 게시할 메시지입니다.
+// Blocks가 있으면 Slack은 Blocks를 표시하고 Text는 알림/대체 텍스트로 사용합니다.
+type Message struct {
+	Channel  string             `json:"channel"`
+	Text     string             `json:"text"`
+	Blocks   []types.SlackBlock `json:"blocks,omitempty"`
+	ThreadTS string             `json:"thread_ts,omitempty"` // 스레드 답글로 게시할 부모 메시지 ts
+}
+
+// PostedMessage는 게시된 메시지의 위치입니다.
+type PostedMessage struct {
+	Channel string
+	TS      string
+}
+
+// NewClient는 Slack Web API 클라이언트를 생성합니다.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("Slack 봇 토큰이 필요합니다")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	base := strings.TrimRight(cfg.BaseURL, "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	return &Client{token: cfg.Token, baseURL: base, http: cfg.HTTPClient}, nil
+}
+
+// PostMessage는 chat.postMessage로 메시지를 게시합니다.
+// https://api.slack.com/methods/chat.postMessage
+func (c *Client) PostMessage(ctx context.Context, msg Message) (*PostedMessage, error) {
+	var resp struct {
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+	if err := c.call(ctx, "chat.postMessage", msg, &resp); err != nil {
+		return nil, err
+	}
+	return &PostedMessage{Channel: resp.Channel, TS: resp.TS}, nil
+}
+
+// UpdateMessage는 chat.update로 게시된 메시지의 내용을 바꿉니다.
+// https://api.slack.com/methods/chat.update
+func (c *Client) UpdateMessage(ctx context.Context, channel string, ts string, text string, blocks []types.SlackBlock) error {
+	body := struct {
+		Channel string             `json:"channel"`
+		TS      string             `json:"ts"`
+		Text    string             `json:"text"`
+		Blocks  []types.SlackBlock `json:"blocks"`
+	}{Channel: channel, TS: ts, Text: text, Blocks: blocks}
+	if body.Blocks == nil {
+		// blocks를 생략하면 이전 블록이 그대로 남으므로 빈 배열로 지움
+		body.Blocks = []types.SlackBlock{}
+	}
+	return c.call(ctx, "chat.update", body, nil)
+}
+
+// UserGroupMembers는 사용자 그룹(S...)에 속한 user_id 목록을 조회합니다 (권한: usergroups:read).
+// https://api.slack.com/methods/usergroups.users.list
+func (c *Client) UserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	var resp struct {
+		Users []string `json:"users"`
+	}
+	if err := c.callForm(ctx, "usergroups.users.list", url.Values{"usergroup": {groupID}}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// FileUpload는 업로드할 파일입니다. 채널/스레드에 파일(스니펫)로 공유됩니다.
+type FileUpload struct {
+	Channel        string
+	ThreadTS       string // 스레드에 공유할 부모 메시지 ts
+	Filename       string
+	Title          string
+	Content        []byte
+	SnippetType    string // 스니펫 구문 강조 종류 (예: diff, markdown, 생략 가능)
+	InitialComment string // 파일과 함께 게시할 메시지
+}
+
+// UploadFile은 파일을 업로드하고 채널/스레드에 공유합니다. 업로드된 파일 ID를 반환합니다.
+//
+// files.getUploadURLExternal로 업로드 URL을 받아 내용을 전송한 뒤 files.completeUploadExternal로 공유합니다.
+// https://api.slack.com/messaging/files#uploading_files
+func (c *Client) UploadFile(ctx context.Context, f FileUpload) (string, error) {
+	params := url.Values{}
+	params.Set("filename", f.Filename)
+	params.Set("length", strconv.Itoa(len(f.Content)))
+	if f.SnippetType != "" {
+		params.Set("snippet_type", f.SnippetType)
+	}
+	var upload struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := c.callForm(ctx, "files.getUploadURLExternal", params, &upload); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.UploadURL, bytes.NewReader(f.Content))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Slack 파일 업로드 실패: %w", err)
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Slack 파일 업로드 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	title := f.Title
+	if title == "" {
+		title = f.Filename
+	}
+	complete := struct {
+		Files          []map[string]string `json:"files"`
+		ChannelID      string              `json:"channel_id,omitempty"`
+		ThreadTS       string              `json:"thread_ts,omitempty"`
+		InitialComment string              `json:"initial_comment,omitempty"`
+	}{
+		Files:          []map[string]string{{"id": upload.FileID, "title": title}},
+		ChannelID:      f.Channel,
+		ThreadTS:       f.ThreadTS,
+		InitialComment: f.InitialComment,
+	}
+	if err := c.call(ctx, "files.completeUploadExternal", complete, nil); err != nil {
+		return "", err
+	}
+	return upload.FileID, nil
+}
+
+// apiResponse는 모든 Web API 응답에 공통인 필드입니다.
+type apiResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Warning string `json:"warning"`
+}
+
+// call은 Web API 메서드를 JSON 본문으로 호출하고 응답을 out에 디코딩합니다.
+// HTTP 2xx가 아니거나 응답의 ok가 false이면 에러를 반환합니다.
+func (c *Client) call(ctx context.Context, method string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, "application/json; charset=utf-8", payload, out)
+}
+
+// callForm은 JSON 본문을 받지 않는 Web API 메서드(files.getUploadURLExternal 등)를 폼 인코딩으로 호출합니다.
+func (c *Client) callForm(ctx context.Context, method string, params url.Values, out interface{}) error {
+	return c.do(ctx, method, "application/x-www-form-urlencoded", []byte(params.Encode()), out)
+}
+
+func (c *Client) do(ctx context.Context, method string, contentType string, payload []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/"+method, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("Slack %s 요청 실패: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(respBody))
+		if len(msg) > 500 {
+			msg = textutil.Truncate(msg, 500) + "..."
+		}
+		return fmt.Errorf("Slack %s 오류 (HTTP %d): %s", method, resp.StatusCode, msg)
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("Slack %s 응답 파싱 실패: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("Slack %s 오류: %s", method, result.Error)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("Slack %s 응답 파싱 실패: %w", method, err)
+		}
+	}
+	return nil
+}
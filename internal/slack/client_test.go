@@ -0,0 +1,167 @@
+package slack
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kakaovx/cursor-slack-server/internal/slack/slacktest"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+func newTestClient(t *testing.T) (*Client, *slacktest.Server) {
+	t.Helper()
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := NewClient(Config{Token: srv.Token, BaseURL: srv.URL + "/"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, srv
+}
+
+func TestNewClientRequiresToken(t *testing.T) {
+	if _, err := NewClient(Config{}); err == nil {
+		t.Error("NewClient without token: want error")
+	}
+}
+
+func TestPostMessage(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	posted, err := client.PostMessage(context.Background(), Message{
+		Channel:  "C1",
+		Text:     "작업을 접수했습니다",
+		Blocks:   []types.SlackBlock{{Type: "section", Text: &types.SlackTextObject{Type: "mrkdwn", Text: "작업을 접수했습니다"}}},
+		ThreadTS: "1699999999.000100",
+	})
+	if err != nil {
+		t.Fatalf("PostMessage: %v", err)
+	}
+	if posted.Channel != "C1" || posted.TS == "" {
+		t.Errorf("PostedMessage = %+v, want channel C1 and ts", posted)
+	}
+
+	msgs := srv.Messages("chat.postMessage")
+	if len(msgs) != 1 {
+		t.Fatalf("chat.postMessage calls = %d, want 1", len(msgs))
+	}
+	msg := msgs[0]
+	if msg["channel"] != "C1" || msg["text"] != "작업을 접수했습니다" || msg["thread_ts"] != "1699999999.000100" {
+		t.Errorf("chat.postMessage body = %v", msg)
+	}
+	if blocks, _ := msg["blocks"].([]interface{}); len(blocks) != 1 {
+		t.Errorf("blocks = %v, want 1 block", msg["blocks"])
+	}
+}
+
+func TestPostMessageOmitsEmptyThread(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	if _, err := client.PostMessage(context.Background(), Message{Channel: "C1", Text: "hi"}); err != nil {
+		t.Fatalf("PostMessage: %v", err)
+	}
+	msg := srv.Messages("chat.postMessage")[0]
+	if _, ok := msg["thread_ts"]; ok {
+		t.Errorf("thread_ts should be omitted: %v", msg)
+	}
+	if _, ok := msg["blocks"]; ok {
+		t.Errorf("blocks should be omitted: %v", msg)
+	}
+}
+
+func TestUpdateMessageClearsBlocks(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	if err := client.UpdateMessage(context.Background(), "C1", "1700000000.000001", "완료", nil); err != nil {
+		t.Fatalf("UpdateMessage: %v", err)
+	}
+	msgs := srv.Messages("chat.update")
+	if len(msgs) != 1 {
+		t.Fatalf("chat.update calls = %d, want 1", len(msgs))
+	}
+	msg := msgs[0]
+	if msg["channel"] != "C1" || msg["ts"] != "1700000000.000001" || msg["text"] != "완료" {
+		t.Errorf("chat.update body = %v", msg)
+	}
+	// blocks를 생략하면 이전 버튼이 남으므로 빈 배열을 보내야 함
+	blocks, ok := msg["blocks"].([]interface{})
+	if !ok || len(blocks) != 0 {
+		t.Errorf("blocks = %#v, want empty array", msg["blocks"])
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	content := []byte("diff --git a/main.go b/main.go\n+// 변경\n")
+	id, err := client.UploadFile(context.Background(), FileUpload{
+		Channel:        "C1",
+		ThreadTS:       "1699999999.000100",
+		Filename:       "job.diff",
+		Content:        content,
+		SnippetType:    "diff",
+		InitialComment: "변경 사항",
+	})
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+
+	files := srv.Files()
+	if len(files) != 1 {
+		t.Fatalf("files = %d, want 1", len(files))
+	}
+	f := files[0]
+	if f.ID != id || f.Filename != "job.diff" || string(f.Content) != string(content) || !f.Shared {
+		t.Errorf("file = %+v (id %s)", f, id)
+	}
+
+	get := srv.Messages("files.getUploadURLExternal")[0]
+	if get["filename"] != "job.diff" || get["length"] != strconv.Itoa(len(content)) || get["snippet_type"] != "diff" {
+		t.Errorf("files.getUploadURLExternal body = %v", get)
+	}
+	complete := srv.Messages("files.completeUploadExternal")[0]
+	if complete["channel_id"] != "C1" || complete["thread_ts"] != "1699999999.000100" || complete["initial_comment"] != "변경 사항" {
+		t.Errorf("files.completeUploadExternal body = %v", complete)
+	}
+	// 제목을 생략하면 파일 이름을 사용
+	entries, _ := complete["files"].([]interface{})
+	if len(entries) != 1 || entries[0].(map[string]interface{})["title"] != "job.diff" {
+		t.Errorf("files = %v, want title job.diff", complete["files"])
+	}
+}
+
+func TestUserGroupMembers(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.SetUserGroup("S1", "U1", "U2")
+
+	members, err := client.UserGroupMembers(context.Background(), "S1")
+	if err != nil {
+		t.Fatalf("UserGroupMembers: %v", err)
+	}
+	if strings.Join(members, ",") != "U1,U2" {
+		t.Errorf("members = %v, want [U1 U2]", members)
+	}
+
+	if _, err := client.UserGroupMembers(context.Background(), "S404"); err == nil || !strings.Contains(err.Error(), "no_such_subteam") {
+		t.Errorf("unknown group error = %v, want no_such_subteam", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	client, err := NewClient(Config{Token: "xoxb-wrong", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.PostMessage(context.Background(), Message{Channel: "C1", Text: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "chat.postMessage") || !strings.Contains(err.Error(), "invalid_auth") {
+		t.Errorf("error = %v, want chat.postMessage invalid_auth", err)
+	}
+	if calls := srv.Calls(); len(calls) != 0 {
+		t.Errorf("unauthenticated calls should not be recorded: %v", calls)
+	}
+}
@@ -0,0 +1,228 @@
+// Package slacktest는 테스트용 가짜 Slack Web API 서버입니다 (v1.5)
+//
+// slack.Config.BaseURL에 Server.URL을 지정하면 실제 Slack 대신 이 서버가 요청을 받아 기록합니다.
+//
+//	srv := slacktest.NewServer()
+//	defer srv.Close()
+//	client, _ := slack.NewClient(slack.Config{Token: srv.Token, BaseURL: srv.URL})
+package slacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// DefaultToken은 가짜 서버가 허용하는 봇 토큰입니다.
+const DefaultToken = "xoxb-slacktest"
+
+// Call은 가짜 서버가 받은 Web API 호출입니다.
+type Call struct {
+	Method string                 // Web API 메서드 (예: chat.postMessage)
+	Body   map[string]interface{} // 요청 본문 (JSON 또는 폼 필드)
+}
+
+// File은 가짜 서버에 업로드된 파일입니다.
+type File struct {
+	ID       string
+	Filename string
+	Content  []byte
+	Shared   bool // files.completeUploadExternal로 공유되었는지 여부
+}
+
+// Server는 Web API 호출을 기록하는 가짜 Slack 서버입니다.
+type Server struct {
+	URL   string // Web API 기본 URL (slack.Config.BaseURL)
+	Token string // 허용하는 봇 토큰
+
+	srv    *httptest.Server
+	mu     sync.Mutex
+	calls  []Call
+	files  []*File
+	groups map[string][]string
+	seq    int
+}
+
+// NewServer는 가짜 Slack Web API 서버를 시작합니다. 사용 후 Close를 호출해야 합니다.
+func NewServer() *Server {
+	s := &Server{Token: DefaultToken}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close는 서버를 종료합니다.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Calls는 지금까지 받은 호출을 순서대로 반환합니다.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// Messages는 method로 받은 호출의 본문만 순서대로 반환합니다.
+func (s *Server) Messages(method string) []map[string]interface{} {
+	var bodies []map[string]interface{}
+	for _, call := range s.Calls() {
+		if call.Method == method {
+			bodies = append(bodies, call.Body)
+		}
+	}
+	return bodies
+}
+
+// SetUserGroup은 usergroups.users.list가 반환할 사용자 그룹 구성원을 지정합니다.
+func (s *Server) SetUserGroup(groupID string, members ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.groups == nil {
+		s.groups = make(map[string][]string)
+	}
+	s.groups[groupID] = members
+}
+
+// Files는 지금까지 업로드된 파일을 순서대로 반환합니다.
+func (s *Server) Files() []File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make([]File, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, *f)
+	}
+	return files
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, uploadPath) {
+		// 업로드 URL은 서명된 URL이므로 봇 토큰 없이 호출됨
+		s.handleUpload(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_auth"})
+		return
+	}
+
+	body, err := decodeBody(r)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_arguments"})
+		return
+	}
+
+	method := r.URL.Path[1:]
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: method, Body: body})
+	s.seq++
+	seq := s.seq
+	ts := fmt.Sprintf("1700000000.%06d", seq)
+	s.mu.Unlock()
+
+	switch method {
+	case "chat.postMessage":
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channel": body["channel"], "ts": ts})
+	case "chat.update":
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channel": body["channel"], "ts": body["ts"]})
+	case "usergroups.users.list":
+		s.mu.Lock()
+		members, ok := s.groups[fmt.Sprint(body["usergroup"])]
+		s.mu.Unlock()
+		if !ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "no_such_subteam"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "users": members})
+	case "files.getUploadURLExternal":
+		id := fmt.Sprintf("F%08d", seq)
+		filename, _ := body["filename"].(string)
+		s.mu.Lock()
+		s.files = append(s.files, &File{ID: id, Filename: filename})
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "upload_url": s.URL + uploadPath + id, "file_id": id})
+	case "files.completeUploadExternal":
+		files, _ := body["files"].([]interface{})
+		if len(files) == 0 {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_arguments"})
+			return
+		}
+		s.mu.Lock()
+		for _, f := range files {
+			entry, _ := f.(map[string]interface{})
+			if file := s.findFile(fmt.Sprint(entry["id"])); file != nil {
+				file.Shared = true
+			}
+		}
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "files": files})
+	default:
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "unknown_method"})
+	}
+}
+
+// uploadPath는 files.getUploadURLExternal이 반환하는 업로드 URL의 경로입니다.
+const uploadPath = "/upload/"
+
+// handleUpload는 업로드 URL로 전송된 파일 내용을 기록합니다.
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	file := s.findFile(strings.TrimPrefix(r.URL.Path, uploadPath))
+	if file != nil {
+		file.Content = content
+	}
+	s.mu.Unlock()
+	if file == nil {
+		http.NotFound(w, r)
+		return
+	}
+	fmt.Fprintf(w, "OK - %d", len(content))
+}
+
+// findFile은 id로 업로드된 파일을 찾습니다. s.mu를 잡은 상태에서 호출해야 합니다.
+func (s *Server) findFile(id string) *File {
+	for _, f := range s.files {
+		if f.ID == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// decodeBody는 JSON 또는 폼 인코딩 요청 본문을 map으로 읽습니다.
+func decodeBody(r *http.Request) (map[string]interface{}, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		body := make(map[string]interface{}, len(r.PostForm))
+		for key := range r.PostForm {
+			value := r.PostForm.Get(key)
+			// files 같은 필드는 폼에서도 JSON 문자열로 전달됨
+			var decoded interface{}
+			if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &decoded) == nil {
+				body[key] = decoded
+			} else {
+				body[key] = value
+			}
+		}
+		return body, nil
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,24 @@
+// Package textutil은 작업 출력, diff, 프롬프트를 Slack 메시지, API 응답, DB 크기 제한에 맞게 자르는 유틸리티입니다 (v1.5)
+//
+// 제한은 바이트 단위이며, 한글 등 여러 바이트 문자 중간에서 자르면 JSON/Slack에 깨진 문자(U+FFFD)가
+// 표시되므로 항상 UTF-8 문자 경계에서 자릅니다.
+package textutil
+
+import "unicode/utf8"
+
+// RuneStart는 i를 s의 UTF-8 문자 경계로 내립니다. i가 s 길이 이상이면 그대로 반환합니다.
+func RuneStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+// Truncate는 s를 최대 n 바이트로 자릅니다. 문자 중간이면 문자 시작 위치까지 물러납니다.
+// s가 n 바이트 이하면 그대로 반환합니다.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:RuneStart(s, max(n, 0))]
+}
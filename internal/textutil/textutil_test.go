@@ -0,0 +1,40 @@
+package textutil
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{"short", "README", 10, "README"},
+		{"exact", "README", 6, "README"},
+		{"ascii", "README 정리", 6, "README"},
+		// "한"은 3바이트이므로 4바이트 제한에서는 첫 글자만 남음
+		{"inside rune", "한글", 4, "한"},
+		{"rune boundary", "한글", 3, "한"},
+		{"before first rune", "한글", 2, ""},
+		{"zero", "한글", 0, ""},
+		{"negative", "abc", -1, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("%s: Truncate(%q, %d) = %q, want %q", tt.name, tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRuneStart(t *testing.T) {
+	s := "a한b"
+	for i, want := range []int{0, 1, 1, 1, 4, 5, 6} {
+		if got := RuneStart(s, i); got != want {
+			t.Errorf("RuneStart(%q, %d) = %d, want %d", s, i, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package types
+
+// v1.5: Slack Block Kit 메시지 구조체
+// https://api.slack.com/reference/block-kit/blocks
+
+// SlackBlock은 Block Kit 레이아웃 블록입니다 (header, section, context, divider, rich_text, actions).
+type SlackBlock struct {
+	Type     string           `json:"type"`
+	BlockID  string           `json:"block_id,omitempty"`
+	Text     *SlackTextObject `json:"text,omitempty"`     // header, section
+	Elements []interface{}    `json:"elements,omitempty"` // context/rich_text: SlackBlockElement, actions: SlackButton
+}
+
+// SlackTextObject는 plain_text 또는 mrkdwn 텍스트 객체입니다.
+type SlackTextObject struct {
+	Type  string `json:"type"` // "plain_text" 또는 "mrkdwn"
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// SlackBlockElement는 context / rich_text 블록의 하위 요소입니다.
+//
+// context: mrkdwn / plain_text
+// rich_text: rich_text_section, rich_text_list, rich_text_preformatted, rich_text_quote
+// rich_text 하위: text, link
+type SlackBlockElement struct {
+	Type     string              `json:"type"`
+	Text     string              `json:"text,omitempty"`
+	URL      string              `json:"url,omitempty"`      // link
+	Style    interface{}         `json:"style,omitempty"`    // text/link: *SlackTextStyle, rich_text_list: "bullet" 또는 "ordered"
+	Indent   int                 `json:"indent,omitempty"`   // rich_text_list
+	Elements []SlackBlockElement `json:"elements,omitempty"` // rich_text_* 컨테이너
+}
+
+// SlackTextStyle은 rich_text의 text/link 요소 스타일입니다.
+type SlackTextStyle struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+	Strike bool `json:"strike,omitempty"`
+	Code   bool `json:"code,omitempty"`
+}
+
+// SlackButton은 actions 블록의 버튼 요소입니다.
+type SlackButton struct {
+	Type     string             `json:"type"` // "button"
+	Text     SlackTextObject    `json:"text"`
+	ActionID string             `json:"action_id"`
+	Value    string             `json:"value,omitempty"`
+	Style    string             `json:"style,omitempty"` // "primary" 또는 "danger"
+	Confirm  *SlackConfirmation `json:"confirm,omitempty"`
+}
+
+// SlackConfirmation은 버튼 클릭 시 표시하는 확인 대화상자입니다.
+type SlackConfirmation struct {
+	Title   SlackTextObject `json:"title"`
+	Text    SlackTextObject `json:"text"`
+	Confirm SlackTextObject `json:"confirm"`
+	Deny    SlackTextObject `json:"deny"`
+	Style   string          `json:"style,omitempty"`
+}
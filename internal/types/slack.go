@@ -7,11 +7,78 @@ type SlackCommandPayload struct {
 	UserID      string `form:"user_id" example:"U1234567890"`
 	ResponseURL string `form:"response_url" example:"https://hooks.slack.com/commands/1234567890/1234567890/abcdefghijklmnopqrstuvwxyz"`
 	TriggerID   string `form:"trigger_id" example:"1234567890.1234567890.abcdefghijklmnopqrstuvwxyz"`
+
+	// v1.5: 명령어가 실행된 위치 (채널별 프로젝트 바인딩에 사용)
+	ChannelID    string `form:"channel_id" example:"C1234567890"`
+	ChannelName  string `form:"channel_name" example:"backend"`
+	TeamID       string `form:"team_id" example:"T1234567890"`
+	EnterpriseID string `form:"enterprise_id" example:"E1234567890"`
 }
 
 // SlackDelayedResponse는 Slack 지연 응답용 JSON 구조체입니다.
+// v1.5: Blocks가 있으면 Slack은 Blocks를 표시하고 Text는 알림/대체 텍스트로 사용합니다.
 type SlackDelayedResponse struct {
-	Text         string `json:"text" example:"✅ Cursor AI 작업 완료"`
-	ResponseType string `json:"response_type" example:"in_channel"` // "in_channel" 또는 "ephemeral"
+	Text         string       `json:"text" example:"✅ Cursor AI 작업 완료"`
+	ResponseType string       `json:"response_type" example:"in_channel"` // "in_channel" 또는 "ephemeral"
+	Blocks       []SlackBlock `json:"blocks,omitempty"`
 }
 
+
+// SlackInteractionPayload는 인터랙티브 컴포넌트(버튼 등) 요청의 payload JSON입니다 (v1.5)
+// Slack은 application/x-www-form-urlencoded 본문의 payload 필드로 전송합니다.
+type SlackInteractionPayload struct {
+	Type        string `json:"type"` // "block_actions"
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url"`
+	User        struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+		TeamID   string `json:"team_id"`
+	} `json:"user"`
+	Team struct {
+		ID     string `json:"id"`
+		Domain string `json:"domain"`
+	} `json:"team"`
+	Enterprise *struct {
+		ID string `json:"id"`
+	} `json:"enterprise"`
+	Channel struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"channel"`
+	Actions []SlackBlockAction `json:"actions"`
+}
+
+// SlackBlockAction은 block_actions payload의 개별 action입니다.
+type SlackBlockAction struct {
+	Type     string `json:"type"`
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	Value    string `json:"value"`
+	ActionTs string `json:"action_ts"`
+}
+
+// SlackEventEnvelope는 Events API 요청 본문입니다 (v1.5)
+// https://api.slack.com/apis/events-api#callback-field
+type SlackEventEnvelope struct {
+	Type         string     `json:"type"`      // "url_verification" 또는 "event_callback"
+	Challenge    string     `json:"challenge"` // url_verification: 그대로 응답해야 하는 값
+	TeamID       string     `json:"team_id"`
+	EnterpriseID string     `json:"enterprise_id"`
+	EventID      string     `json:"event_id"`
+	Event        SlackEvent `json:"event"`
+}
+
+// SlackEvent는 event_callback의 개별 이벤트입니다 (app_mention, message).
+type SlackEvent struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"` // message_changed, bot_message 등 (사용자가 새로 보낸 메시지는 빈 값)
+	User        string `json:"user"`
+	BotID       string `json:"bot_id"`
+	Text        string `json:"text"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"` // message: "im", "channel" 등
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"` // 스레드 안의 메시지면 부모 메시지 ts
+}
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// Slack 메시지 버튼의 action_id입니다 (v1.5)
+// 버튼 value에는 대상 Job ID(전체)가 들어가며, /slack/interactions 핸들러가 처리합니다.
+const (
+	ActionCancelJob  = "job_cancel"
+	ActionRetryJob   = "job_retry"
+	ActionShowOutput = "job_show_output"
+	ActionRevertJob  = "job_revert"
+	ActionApproveJob = "job_approve"
+	ActionRejectJob  = "job_reject"
+)
+
+func plainText(text string) types.SlackTextObject {
+	return types.SlackTextObject{Type: "plain_text", Text: text, Emoji: true}
+}
+
+// jobButton은 action에 맞는 라벨/스타일/확인 대화상자를 가진 버튼을 만듭니다.
+func jobButton(action string, jobID string) types.SlackButton {
+	button := types.SlackButton{Type: "button", ActionID: action, Value: jobID}
+	switch action {
+	case ActionCancelJob:
+		button.Text = plainText("🛑 취소")
+		button.Style = "danger"
+		button.Confirm = &types.SlackConfirmation{
+			Title:   plainText("작업 취소"),
+			Text:    types.SlackTextObject{Type: "mrkdwn", Text: fmt.Sprintf("작업 `%s`을(를) 취소할까요?", shortID(jobID))},
+			Confirm: plainText("취소하기"),
+			Deny:    plainText("닫기"),
+			Style:   "danger",
+		}
+	case ActionRetryJob:
+		button.Text = plainText("🔁 다시 실행")
+		button.Style = "primary"
+	case ActionShowOutput:
+		button.Text = plainText("📄 전체 출력")
+	case ActionRevertJob:
+		button.Text = plainText("↩️ 되돌리기")
+		button.Style = "danger"
+		button.Confirm = &types.SlackConfirmation{
+			Title:   plainText("변경 사항 되돌리기"),
+			Text:    types.SlackTextObject{Type: "mrkdwn", Text: fmt.Sprintf("작업 `%s`이(가) 변경한 파일을 실행 전 상태로 되돌릴까요?", shortID(jobID))},
+			Confirm: plainText("되돌리기"),
+			Deny:    plainText("닫기"),
+			Style:   "danger",
+		}
+	case ActionApproveJob:
+		button.Text = plainText("✅ 승인")
+		button.Style = "primary"
+		button.Confirm = &types.SlackConfirmation{
+			Title:   plainText("실행 계획 승인"),
+			Text:    types.SlackTextObject{Type: "mrkdwn", Text: fmt.Sprintf("작업 `%s`을(를) 파일 수정 권한으로 실행할까요?", shortID(jobID))},
+			Confirm: plainText("승인"),
+			Deny:    plainText("닫기"),
+		}
+	case ActionRejectJob:
+		button.Text = plainText("🚫 거절")
+		button.Style = "danger"
+	default:
+		button.Text = plainText(action)
+	}
+	return button
+}
+
+// JobActionsBlock은 작업에 대한 버튼 actions 블록을 만듭니다 (v1.5)
+func JobActionsBlock(jobID string, actions ...string) types.SlackBlock {
+	block := types.SlackBlock{Type: "actions"}
+	for _, action := range actions {
+		block.Elements = append(block.Elements, jobButton(action, jobID))
+	}
+	return block
+}
+
+// MessageBlocks는 mrkdwn 텍스트 메시지에 작업 버튼을 붙인 블록을 만듭니다 (v1.5)
+// 접수/진행 상황처럼 텍스트로 보내던 메시지에 버튼을 추가할 때 사용합니다.
+func MessageBlocks(text string, jobID string, actions ...string) []types.SlackBlock {
+	return []types.SlackBlock{sectionBlock(text), JobActionsBlock(jobID, actions...)}
+}
+
+// resultActions는 종료된 작업 결과 메시지에 붙일 버튼을 결정합니다.
+// 변경 파일이 있는 작업에만 되돌리기 버튼을 표시합니다.
+func resultActions(kind database.JobKind, changes *jobChanges) []string {
+	actions := []string{ActionRetryJob, ActionShowOutput}
+	if kind != database.JobKindRevert && changes != nil && len(changes.Files) > 0 {
+		actions = append(actions, ActionRevertJob)
+	}
+	return actions
+}
+
+// SendJobOutput은 작업의 전체 출력과 diff를 요청자에게만 보이는 메시지로 전송합니다 (v1.5)
+// 결과 메시지에는 diff와 출력이 잘려서 표시되므로 "전체 출력" 버튼에서 사용합니다.
+func (te *TaskExecutor) SendJobOutput(responseURL string, job *database.JobRecord) {
+	blocks := []types.SlackBlock{headerBlock(fmt.Sprintf("📄 작업 출력 (%s)", shortID(job.ID)))}
+	fallback := fmt.Sprintf("📄 *작업 출력* (ID: `%s`)\n\n", shortID(job.ID))
+
+	if job.Output != "" {
+		blocks = append(blocks, renderMarkdownBlocks(job.Output)...)
+		fallback += te.convertMarkdownToSlack(job.Output) + "\n"
+	} else {
+		blocks = append(blocks, sectionBlock("출력이 없습니다."))
+	}
+	if job.Error != "" {
+		blocks = append(blocks, sectionBlock("🚨 *오류*"), richTextBlock(types.SlackBlockElement{
+			Type:     "rich_text_quote",
+			Elements: []types.SlackBlockElement{textElement(truncateRunes(job.Error, maxBlockTextChars), nil)},
+		}))
+		fallback += fmt.Sprintf("\n🚨 *오류*\n> %s\n", job.Error)
+	}
+	if job.Diff != "" {
+		var b richTextBuilder
+		b.addPreformatted(job.Diff)
+		b.flush()
+		blocks = append(blocks, dividerBlock(), sectionBlock("💻 *변경된 코드*"))
+		blocks = append(blocks, b.blocks...)
+		fallback += "\n💻 *변경된 코드*\n```\n" + job.Diff + "```\n"
+	}
+
+	te.sendBlockMessages(replyTarget{ResponseURL: responseURL}, job.ID, "📄 작업 출력", blocks, te.splitMessage(fallback, maxResponseURLMessages), "ephemeral")
+}
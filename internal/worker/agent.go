@@ -0,0 +1,392 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultAgentName은 기본 제공되는 cursor-agent 백엔드 이름입니다 (v1.5)
+const DefaultAgentName = "cursor"
+
+// ErrUnknownAgent는 등록되지 않은 에이전트 이름을 지정했음을 나타냅니다 (v1.5)
+var ErrUnknownAgent = errors.New("알 수 없는 에이전트입니다")
+
+// Agent는 작업을 실행하는 코딩 CLI 백엔드입니다 (v1.5)
+//
+// 실행기(executeAgent)는 Command가 만든 명령어를 작업 디렉토리에서 프로세스 그룹으로 실행하고,
+// stdout을 줄 단위로 NewParser가 만든 파서에 전달합니다. stderr는 출력 뒤에 붙입니다.
+type Agent interface {
+	Name() string
+	Command(req AgentRequest) AgentCommand
+	NewParser() OutputParser
+	Models() []string       // --model로 선택할 수 있는 모델 (비어 있으면 모델 선택 불가)
+	SupportsResume() bool   // 채팅 세션을 재개하여 이어서 실행할 수 있는지 여부 (continue 작업)
+	SupportsReadOnly() bool // 파일을 수정하지 않고 실행할 수 있는지 여부 (--readonly 작업)
+}
+
+// AgentRequest는 에이전트 한 번의 실행 요청입니다 (v1.5)
+type AgentRequest struct {
+	Prompt        string // 자연어 프롬프트
+	Dir           string // 작업 디렉토리 (프로젝트 경로 또는 worktree)
+	ReadOnly      bool   // 파일 수정 없이 실행 (승인 전 계획 단계)
+	ResumeSession string // 이어서 실행할 채팅 세션 ID (continue 작업)
+	Model         string // 모델 (비어 있으면 에이전트 기본 모델)
+}
+
+// AgentCommand는 실행할 명령어입니다 (v1.5)
+type AgentCommand struct {
+	Path string
+	Args []string
+	Env  []string // 서버 환경변수에 더할 `이름=값` (비어 있으면 서버 환경 그대로)
+}
+
+// OutputParser는 에이전트 stdout을 한 줄씩 받아 사람이 읽을 수 있는 출력으로 변환합니다 (v1.5)
+// Feed는 실행 goroutine에서, Partial은 부분 출력 저장 goroutine에서 호출하므로 동시에 호출해도 안전해야 합니다.
+type OutputParser interface {
+	Feed(line string) string // 한 줄을 처리하고 새로 추가된 출력 텍스트를 반환
+	Output() string          // 최종 출력
+	Partial() string         // 실행 중 누적된 출력
+	SessionID() string       // 이어서 실행할 채팅 세션 ID (지원하지 않으면 빈 값)
+}
+
+// 에이전트 출력 형식 (AGENTS_CONFIG의 output)
+const (
+	AgentOutputText       = "text"        // stdout 그대로
+	AgentOutputStreamJSON = "stream-json" // cursor-agent 호환 줄 단위 JSON 이벤트
+)
+
+// cursorAgent는 cursor-agent CLI 백엔드입니다.
+type cursorAgent struct {
+	path   string
+	models []string
+}
+
+// NewCursorAgent는 cursor-agent 백엔드를 생성합니다 (CURSOR_CLI_PATH, CURSOR_MODELS).
+func NewCursorAgent(path string, models []string) Agent {
+	return &cursorAgent{path: path, models: models}
+}
+
+func (a *cursorAgent) Name() string { return DefaultAgentName }
+
+// Command는 `cursor-agent -p <prompt> [--model <model>] [--resume <id>] [--force] --output-format stream-json`을 만듭니다.
+func (a *cursorAgent) Command(req AgentRequest) AgentCommand {
+	// v1.1: --force 필수, --files 제거
+	args := []string{
+		"-p", req.Prompt, // 자연어 프롬프트 (파일명 포함 가능)
+	}
+	if req.Model != "" {
+		args = append(args, "--model", req.Model) // v1.5: 요청별 모델 선택
+	}
+	if req.ResumeSession != "" {
+		args = append(args, "--resume", req.ResumeSession) // v1.5: 이전 작업의 채팅 세션 이어서 실행
+	}
+	if !req.ReadOnly {
+		args = append(args, "--force") // 파일 수정 허용 (필수!)
+	}
+	args = append(args, "--output-format", "stream-json") // 줄 단위 JSON 이벤트 (v1.5: 실시간 스트리밍)
+	return AgentCommand{Path: a.path, Args: args}
+}
+
+func (a *cursorAgent) NewParser() OutputParser { return &streamParser{} }
+
+func (a *cursorAgent) Models() []string { return a.models }
+
+func (a *cursorAgent) SupportsResume() bool { return true }
+
+// SupportsReadOnly는 항상 true입니다. --force 없이 실행하면 cursor-agent는 파일을 수정하지 않습니다.
+func (a *cursorAgent) SupportsReadOnly() bool { return true }
+
+// AgentTemplate은 명령어 템플릿 백엔드 설정입니다 (v1.5: AGENTS_CONFIG의 agents 항목)
+//
+// 인자는 args, model_args(모델 지정 시), resume_args(세션 재개 시), write_args 또는 readonly_args 순서로 이어 붙이며,
+// 각 인자의 `{{prompt}}`, `{{model}}`, `{{session}}`, `{{dir}}`을 요청 값으로 바꿉니다.
+// env 값의 `${이름}`은 실행 시점의 서버 환경변수로 바꿉니다 (API 키를 설정 파일에 적지 않도록).
+type AgentTemplate struct {
+	Command      string            `json:"command"`
+	Args         []string          `json:"args"`
+	WriteArgs    []string          `json:"write_args,omitempty"`
+	ReadOnlyArgs []string          `json:"readonly_args,omitempty"`
+	ResumeArgs   []string          `json:"resume_args,omitempty"`
+	ModelArgs    []string          `json:"model_args,omitempty"`
+	Models       []string          `json:"models,omitempty"` // --model로 선택할 수 있는 모델 (model_args 필요)
+	Env          map[string]string `json:"env,omitempty"`
+	Output       string            `json:"output,omitempty"` // text(기본값) 또는 stream-json
+}
+
+// templateAgent는 AgentTemplate으로 실행하는 범용 백엔드입니다.
+type templateAgent struct {
+	name string
+	tmpl AgentTemplate
+}
+
+// NewTemplateAgent는 설정을 검증하고 명령어 템플릿 백엔드를 생성합니다.
+func NewTemplateAgent(name string, t AgentTemplate) (Agent, error) {
+	if err := ValidateProjectName(name); err != nil {
+		return nil, fmt.Errorf("잘못된 에이전트 이름입니다: `%s` (영문 소문자, 숫자, `-`, `_`, `.`만 사용, 최대 32자)", name)
+	}
+	if t.Command == "" {
+		return nil, fmt.Errorf("에이전트 %s: command가 비어 있습니다", name)
+	}
+	switch t.Output {
+	case "":
+		t.Output = AgentOutputText
+	case AgentOutputText, AgentOutputStreamJSON:
+	default:
+		return nil, fmt.Errorf("에이전트 %s: 지원하지 않는 output 형식입니다: %s (text 또는 stream-json)", name, t.Output)
+	}
+	if !hasPlaceholder(t.Args, "{{prompt}}") {
+		return nil, fmt.Errorf("에이전트 %s: args에 {{prompt}}가 없습니다", name)
+	}
+	if len(t.ResumeArgs) > 0 && !hasPlaceholder(t.ResumeArgs, "{{session}}") {
+		return nil, fmt.Errorf("에이전트 %s: resume_args에 {{session}}이 없습니다", name)
+	}
+	if len(t.Models) > 0 && !hasPlaceholder(t.ModelArgs, "{{model}}") {
+		return nil, fmt.Errorf("에이전트 %s: models를 지정하려면 model_args에 {{model}}이 필요합니다", name)
+	}
+	return &templateAgent{name: name, tmpl: t}, nil
+}
+
+func (a *templateAgent) Name() string { return a.name }
+
+// Command는 템플릿에 요청 값을 채워 명령어를 만듭니다.
+// 세션 재개(ResumeSession)는 SupportsResume이 true인 백엔드에만 요청됩니다 (CheckContinuable).
+func (a *templateAgent) Command(req AgentRequest) AgentCommand {
+	replacer := strings.NewReplacer("{{prompt}}", req.Prompt, "{{model}}", req.Model, "{{session}}", req.ResumeSession, "{{dir}}", req.Dir)
+
+	var args []string
+	add := func(template []string) {
+		for _, arg := range template {
+			args = append(args, replacer.Replace(arg))
+		}
+	}
+	add(a.tmpl.Args)
+	if req.Model != "" {
+		add(a.tmpl.ModelArgs)
+	}
+	if req.ResumeSession != "" {
+		add(a.tmpl.ResumeArgs)
+	}
+	if req.ReadOnly {
+		add(a.tmpl.ReadOnlyArgs)
+	} else {
+		add(a.tmpl.WriteArgs)
+	}
+
+	names := make([]string, 0, len(a.tmpl.Env))
+	for name := range a.tmpl.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var env []string
+	for _, name := range names {
+		env = append(env, name+"="+os.ExpandEnv(a.tmpl.Env[name]))
+	}
+	return AgentCommand{Path: a.tmpl.Command, Args: args, Env: env}
+}
+
+// NewParser는 output 형식에 맞는 파서를 생성합니다.
+func (a *templateAgent) NewParser() OutputParser {
+	if a.tmpl.Output == AgentOutputStreamJSON {
+		return &streamParser{}
+	}
+	return &textParser{}
+}
+
+func (a *templateAgent) Models() []string { return a.tmpl.Models }
+
+// SupportsResume은 resume_args가 있고 세션 ID를 보고하는 stream-json 출력일 때만 true입니다.
+// resume_args가 없으면 세션 ID가 있어도 새 대화로 실행되므로 이어서 실행할 수 없습니다.
+func (a *templateAgent) SupportsResume() bool {
+	return len(a.tmpl.ResumeArgs) > 0 && a.tmpl.Output == AgentOutputStreamJSON
+}
+
+// SupportsReadOnly는 readonly_args가 있을 때만 true입니다.
+// readonly_args가 없으면 args만으로 실행되므로, 백엔드에 따라 파일을 수정할 수 있습니다.
+func (a *templateAgent) SupportsReadOnly() bool {
+	return len(a.tmpl.ReadOnlyArgs) > 0
+}
+
+// CheckModel은 model이 에이전트에서 허용된 모델인지 확인합니다 (v1.5)
+// model이 비어 있으면 에이전트 기본 모델을 사용하므로 항상 허용합니다.
+func CheckModel(agent Agent, model string) error {
+	if model == "" {
+		return nil
+	}
+	models := agent.Models()
+	if len(models) == 0 {
+		return fmt.Errorf("`%s` 에이전트는 모델을 선택할 수 없습니다 (허용된 모델이 설정되지 않음)", agent.Name())
+	}
+	if !slices.Contains(models, model) {
+		return fmt.Errorf("`%s` 에이전트에서 허용되지 않은 모델입니다: `%s` (사용 가능: %s)", agent.Name(), model, strings.Join(models, ", "))
+	}
+	return nil
+}
+
+// CheckReadOnly는 읽기 전용 요청을 에이전트가 보장할 수 있는지 확인합니다 (v1.5)
+// 읽기 전용 작업은 승인 절차와 변경 사항 복원 없이 실행되므로, 보장할 수 없는 백엔드는 거부합니다.
+func CheckReadOnly(agent Agent, readOnly bool) error {
+	if readOnly && !agent.SupportsReadOnly() {
+		return fmt.Errorf("`%s` 에이전트는 읽기 전용 실행을 지원하지 않습니다 (readonly_args가 설정되지 않음)", agent.Name())
+	}
+	return nil
+}
+
+func hasPlaceholder(args []string, placeholder string) bool {
+	for _, arg := range args {
+		if strings.Contains(arg, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// textParser는 출력 형식이 text인 에이전트의 파서입니다. stdout을 그대로 누적합니다.
+type textParser struct {
+	mu   sync.Mutex
+	text strings.Builder
+}
+
+func (p *textParser) Feed(line string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delta := line + "\n"
+	p.text.WriteString(delta)
+	return delta
+}
+
+func (p *textParser) Output() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.text.String()
+}
+
+func (p *textParser) Partial() string { return p.Output() }
+
+// SessionID는 항상 빈 값입니다 (text 출력은 세션을 보고하지 않으므로 이어서 실행할 수 없음).
+func (p *textParser) SessionID() string { return "" }
+
+// AgentRegistry는 이름으로 선택할 수 있는 에이전트 백엔드 목록입니다 (v1.5)
+type AgentRegistry struct {
+	agents      map[string]Agent
+	defaultName string
+}
+
+// NewAgentRegistry는 기본 백엔드 하나로 목록을 생성합니다.
+func NewAgentRegistry(defaultAgent Agent) *AgentRegistry {
+	return &AgentRegistry{
+		agents:      map[string]Agent{defaultAgent.Name(): defaultAgent},
+		defaultName: defaultAgent.Name(),
+	}
+}
+
+// Register는 백엔드를 추가합니다. 같은 이름이 이미 있으면 에러를 반환합니다.
+func (r *AgentRegistry) Register(agent Agent) error {
+	if _, exists := r.agents[agent.Name()]; exists {
+		return fmt.Errorf("이미 등록된 에이전트입니다: %s", agent.Name())
+	}
+	r.agents[agent.Name()] = agent
+	return nil
+}
+
+// SetDefault는 에이전트를 지정하지 않은 작업에 사용할 백엔드를 바꿉니다.
+func (r *AgentRegistry) SetDefault(name string) error {
+	if _, ok := r.agents[name]; !ok {
+		return r.unknown(name)
+	}
+	r.defaultName = name
+	return nil
+}
+
+// Default는 기본 백엔드 이름입니다.
+func (r *AgentRegistry) Default() string {
+	return r.defaultName
+}
+
+// Get은 이름으로 백엔드를 찾습니다. 이름이 비어 있으면 기본 백엔드를 반환합니다.
+func (r *AgentRegistry) Get(name string) (Agent, error) {
+	if name == "" {
+		name = r.defaultName
+	}
+	agent, ok := r.agents[name]
+	if !ok {
+		return nil, r.unknown(name)
+	}
+	return agent, nil
+}
+
+// Names는 등록된 백엔드 이름을 정렬하여 반환합니다.
+func (r *AgentRegistry) Names() []string {
+	names := make([]string, 0, len(r.agents))
+	for name := range r.agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *AgentRegistry) unknown(name string) error {
+	return fmt.Errorf("%w: `%s` (사용 가능: %s)", ErrUnknownAgent, name, strings.Join(r.Names(), ", "))
+}
+
+// agentsFile은 AGENTS_CONFIG 파일 형식입니다.
+//
+// 예시:
+//
+//	{
+//	  "default": "cursor",
+//	  "agents": {
+//	    "claude": {
+//	      "command": "claude",
+//	      "args": ["-p", "{{prompt}}", "--output-format", "text"],
+//	      "write_args": ["--permission-mode", "acceptEdits"],
+//	      "readonly_args": ["--permission-mode", "plan"],
+//	      "model_args": ["--model", "{{model}}"],
+//	      "models": ["sonnet", "opus"],
+//	      "env": {"ANTHROPIC_API_KEY": "${CLAUDE_API_KEY}"}
+//	    }
+//	  }
+//	}
+type agentsFile struct {
+	Default string                   `json:"default"`
+	Agents  map[string]AgentTemplate `json:"agents"`
+}
+
+// LoadAgents는 AGENTS_CONFIG 파일의 명령어 템플릿 백엔드를 등록하고 기본 백엔드를 설정합니다 (v1.5)
+func (r *AgentRegistry) LoadAgents(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file agentsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("%s: JSON 형식 오류: %w", path, err)
+	}
+
+	names := make([]string, 0, len(file.Agents))
+	for name := range file.Agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		agent, err := NewTemplateAgent(name, file.Agents[name])
+		if err != nil {
+			return err
+		}
+		if err := r.Register(agent); err != nil {
+			return err
+		}
+	}
+
+	if file.Default != "" {
+		if err := r.SetDefault(file.Default); err != nil {
+			return errors.New("default: " + err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package worker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCursorAgentCommand(t *testing.T) {
+	agent := NewCursorAgent("/usr/local/bin/cursor-agent", []string{"gpt-5"})
+	tests := []struct {
+		name string
+		req  AgentRequest
+		want []string
+	}{
+		{"write", AgentRequest{Prompt: "수정해줘"}, []string{"-p", "수정해줘", "--force", "--output-format", "stream-json"}},
+		{"readonly", AgentRequest{Prompt: "계획", ReadOnly: true}, []string{"-p", "계획", "--output-format", "stream-json"}},
+		{"resume", AgentRequest{Prompt: "이어서", ResumeSession: "sess-1"}, []string{"-p", "이어서", "--resume", "sess-1", "--force", "--output-format", "stream-json"}},
+		{"model", AgentRequest{Prompt: "분석", Model: "gpt-5", ReadOnly: true}, []string{"-p", "분석", "--model", "gpt-5", "--output-format", "stream-json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := agent.Command(tt.req)
+			if cmd.Path != "/usr/local/bin/cursor-agent" || !reflect.DeepEqual(cmd.Args, tt.want) || cmd.Env != nil {
+				t.Errorf("Command() = %+v, want args %q", cmd, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateAgent(t *testing.T) {
+	valid := AgentTemplate{Command: "claude", Args: []string{"-p", "{{prompt}}"}}
+	tests := []struct {
+		name    string
+		agent   string
+		edit    func(t *AgentTemplate)
+		wantErr string
+	}{
+		{"valid", "claude", func(t *AgentTemplate) {}, ""},
+		{"stream-json", "claude", func(t *AgentTemplate) { t.Output = AgentOutputStreamJSON }, ""},
+		{"bad name", "Claude!", func(t *AgentTemplate) {}, "잘못된 에이전트 이름"},
+		{"no command", "claude", func(t *AgentTemplate) { t.Command = "" }, "command"},
+		{"bad output", "claude", func(t *AgentTemplate) { t.Output = "json" }, "output"},
+		{"no prompt", "claude", func(t *AgentTemplate) { t.Args = []string{"-p"} }, "{{prompt}}"},
+		{"resume without session", "claude", func(t *AgentTemplate) { t.ResumeArgs = []string{"--resume"} }, "{{session}}"},
+		{"models without model_args", "claude", func(t *AgentTemplate) { t.Models = []string{"sonnet"} }, "{{model}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := valid
+			tt.edit(&tmpl)
+			agent, err := NewTemplateAgent(tt.agent, tmpl)
+			if tt.wantErr == "" {
+				if err != nil || agent.Name() != tt.agent {
+					t.Errorf("NewTemplateAgent() = %v, %v", agent, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewTemplateAgent() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplateAgentCommand(t *testing.T) {
+	t.Setenv("TEST_AGENT_KEY", "secret")
+	agent, err := NewTemplateAgent("claude", AgentTemplate{
+		Command:      "claude",
+		Args:         []string{"-p", "{{prompt}}", "--cwd={{dir}}"},
+		WriteArgs:    []string{"--permission-mode", "acceptEdits"},
+		ReadOnlyArgs: []string{"--permission-mode", "plan"},
+		ResumeArgs:   []string{"--resume", "{{session}}"},
+		ModelArgs:    []string{"--model", "{{model}}"},
+		Models:       []string{"sonnet"},
+		Env:          map[string]string{"B_KEY": "${TEST_AGENT_KEY}", "A_MODE": "batch"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  AgentRequest
+		want []string
+	}{
+		{"write", AgentRequest{Prompt: "수정", Dir: "/repo"}, []string{"-p", "수정", "--cwd=/repo", "--permission-mode", "acceptEdits"}},
+		{"readonly", AgentRequest{Prompt: "계획", Dir: "/repo", ReadOnly: true}, []string{"-p", "계획", "--cwd=/repo", "--permission-mode", "plan"}},
+		{"resume", AgentRequest{Prompt: "이어서", Dir: "/repo", ResumeSession: "sess-1"}, []string{"-p", "이어서", "--cwd=/repo", "--resume", "sess-1", "--permission-mode", "acceptEdits"}},
+		{"model", AgentRequest{Prompt: "분석", Dir: "/repo", Model: "sonnet", ReadOnly: true}, []string{"-p", "분석", "--cwd=/repo", "--model", "sonnet", "--permission-mode", "plan"}},
+		// 프롬프트에 자리표시자가 있어도 다시 치환하지 않음
+		{"placeholder in prompt", AgentRequest{Prompt: "{{session}} {{dir}}", Dir: "/repo"}, []string{"-p", "{{session}} {{dir}}", "--cwd=/repo", "--permission-mode", "acceptEdits"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := agent.Command(tt.req)
+			if cmd.Path != "claude" || !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("Args = %q, want %q", cmd.Args, tt.want)
+			}
+			if want := []string{"A_MODE=batch", "B_KEY=secret"}; !reflect.DeepEqual(cmd.Env, want) {
+				t.Errorf("Env = %q, want %q", cmd.Env, want)
+			}
+		})
+	}
+}
+
+func TestTemplateAgentParser(t *testing.T) {
+	text, _ := NewTemplateAgent("text", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}})
+	p := text.NewParser()
+	p.Feed(`{"type":"result","result":"무시","session_id":"s"}`)
+	if p.Output() != "{\"type\":\"result\",\"result\":\"무시\",\"session_id\":\"s\"}\n" || p.SessionID() != "" {
+		t.Errorf("text parser Output() = %q, SessionID() = %q", p.Output(), p.SessionID())
+	}
+
+	stream, _ := NewTemplateAgent("stream", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}, Output: AgentOutputStreamJSON})
+	p = stream.NewParser()
+	p.Feed(`{"type":"result","result":"완료","session_id":"s"}`)
+	if p.Output() != "완료" || p.SessionID() != "s" {
+		t.Errorf("stream parser Output() = %q, SessionID() = %q", p.Output(), p.SessionID())
+	}
+}
+
+func TestSupportsResume(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl AgentTemplate
+		want bool
+	}{
+		{"stream-json with resume_args", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}, ResumeArgs: []string{"--resume", "{{session}}"}, Output: AgentOutputStreamJSON}, true},
+		{"stream-json without resume_args", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}, Output: AgentOutputStreamJSON}, false},
+		{"text with resume_args", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}, ResumeArgs: []string{"--resume", "{{session}}"}}, false},
+	}
+	for _, tt := range tests {
+		agent, err := NewTemplateAgent("x", tt.tmpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := agent.SupportsResume(); got != tt.want {
+			t.Errorf("%s: SupportsResume() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !NewCursorAgent("cursor-agent", nil).SupportsResume() {
+		t.Error("cursor SupportsResume() = false, want true")
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	withReadOnly, _ := NewTemplateAgent("claude", AgentTemplate{Command: "claude", Args: []string{"-p", "{{prompt}}"}, ReadOnlyArgs: []string{"--permission-mode", "plan"}})
+	withoutReadOnly, _ := NewTemplateAgent("aider", AgentTemplate{Command: "aider", Args: []string{"--message", "{{prompt}}"}})
+	tests := []struct {
+		name     string
+		agent    Agent
+		readOnly bool
+		wantErr  bool
+	}{
+		{"cursor", NewCursorAgent("cursor-agent", nil), true, false},
+		{"template with readonly_args", withReadOnly, true, false},
+		// readonly_args가 없으면 args만으로 실행되어 파일을 수정할 수 있음
+		{"template without readonly_args", withoutReadOnly, true, true},
+		{"write job", withoutReadOnly, false, false},
+	}
+	for _, tt := range tests {
+		if err := CheckReadOnly(tt.agent, tt.readOnly); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckReadOnly(%v) error = %v, wantErr %v", tt.name, tt.readOnly, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckModel(t *testing.T) {
+	withModels := NewCursorAgent("cursor-agent", []string{"gpt-5", "sonnet-4"})
+	withoutModels := NewCursorAgent("cursor-agent", nil)
+	tests := []struct {
+		name    string
+		agent   Agent
+		model   string
+		wantErr bool
+	}{
+		{"default model", withoutModels, "", false},
+		{"allowed", withModels, "sonnet-4", false},
+		{"not allowed", withModels, "gpt-4", true},
+		{"no models configured", withoutModels, "gpt-5", true},
+	}
+	for _, tt := range tests {
+		if err := CheckModel(tt.agent, tt.model); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckModel(%q) error = %v, wantErr %v", tt.name, tt.model, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAgentRegistry(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "agents.json")
+	os.WriteFile(config, []byte(`{
+		"default": "claude",
+		"agents": {
+			"claude": {"command": "claude", "args": ["-p", "{{prompt}}"]},
+			"aider": {"command": "aider", "args": ["--message", "{{prompt}}"], "output": "text"}
+		}
+	}`), 0o644)
+
+	r := NewAgentRegistry(NewCursorAgent("cursor-agent", nil))
+	if err := r.LoadAgents(config); err != nil {
+		t.Fatalf("LoadAgents: %v", err)
+	}
+	if got := strings.Join(r.Names(), ","); got != "aider,claude,cursor" {
+		t.Errorf("Names() = %s", got)
+	}
+	if r.Default() != "claude" {
+		t.Errorf("Default() = %s, want claude", r.Default())
+	}
+	if agent, err := r.Get(""); err != nil || agent.Name() != "claude" {
+		t.Errorf("Get(\"\") = %v, %v; want claude", agent, err)
+	}
+	if _, err := r.Get("gemini"); !errors.Is(err, ErrUnknownAgent) {
+		t.Errorf("Get(gemini) error = %v, want ErrUnknownAgent", err)
+	}
+	if err := r.Register(NewCursorAgent("other", nil)); err == nil {
+		t.Error("Register(cursor) again: want error")
+	}
+
+	for name, content := range map[string]string{
+		"bad json":        `{`,
+		"unknown default": `{"default": "gemini"}`,
+		"duplicate":       `{"agents": {"cursor": {"command": "x", "args": ["{{prompt}}"]}}}`,
+		"invalid agent":   `{"agents": {"x": {"command": "x", "args": []}}}`,
+	} {
+		os.WriteFile(config, []byte(content), 0o644)
+		if err := NewAgentRegistry(NewCursorAgent("cursor-agent", nil)).LoadAgents(config); err == nil {
+			t.Errorf("LoadAgents(%s): want error", name)
+		}
+	}
+}
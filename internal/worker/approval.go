@@ -0,0 +1,259 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// planPrompt는 승인 전 계획 단계에서 cursor-agent에 전달할 프롬프트를 만듭니다 (v1.5)
+func planPrompt(prompt string) string {
+	return "다음 요청을 수행하기 위한 실행 계획만 작성하세요. 파일을 수정하거나 명령을 실행하지 마세요.\n" +
+		"변경할 파일과 변경 내용, 실행할 명령을 단계별로 정리해주세요.\n\n" +
+		"요청:\n" + prompt
+}
+
+// runPlan은 승인이 필요한 작업의 계획 단계를 실행합니다 (v1.5)
+//
+// 에이전트를 읽기 전용으로(cursor-agent는 --force 없이, 명령어 템플릿은 readonly_args로) 실행하여 실행 계획을 받고, 작업을 승인 대기 상태로 전환한 뒤
+// 승인/거절 버튼이 있는 승인 요청 메시지를 보냅니다. 승인되면 작업이 다시 대기열에 들어가
+// 일반 작업과 같이 파일 수정 권한으로 실행됩니다. 읽기 전용을 지원하지 않는 백엔드이면 계획 단계를 실행하지 않고 작업을 실패 처리합니다.
+//
+// worktree 격리 모드에서는 계획 단계도 계획 전용 worktree에서 실행하고 끝나면 삭제합니다.
+// 이어서 실행하는 작업은 원본 작업의 worktree가 남아 있으면 그 worktree에서 계획을 작성합니다.
+//
+// git 저장소에서는 계획 단계 전후 스냅샷을 비교하여, 그 사이 변경된 파일을 실행 전 상태로 복원합니다.
+// 스냅샷이나 복원에 실패하면 계획 단계가 파일을 수정하지 않았다고 보장할 수 없으므로 작업을 실패 처리합니다.
+// 직접 실행 모드에서는 Run이 저장소 잠금(lockRepo)을 잡고 호출하므로, 같은 저장소에서 동시에 실행 중인
+// 다른 작업의 변경을 복원하지 않습니다.
+func (te *TaskExecutor) runPlan(ctx context.Context, cfg *ConfigFull, job Job, agent Agent, projectPath string, parent *database.JobRecord, opts runOptions) {
+	jobID := job.ID
+	prompt := strings.TrimSpace(job.Payload.Text)
+	shownPrompt := te.Redactor.String(prompt) // v1.5: Slack 메시지에는 비밀 값을 가린 프롬프트 표시
+
+	// 읽기 전용을 보장할 수 없는 백엔드로는 계획 단계를 실행하지 않음 (요청/프로젝트 설정 시점 이후 AGENTS_CONFIG가 바뀐 경우)
+	if err := CheckReadOnly(agent, true); err != nil {
+		errMsg := "❌ 실행 계획을 작성할 수 없습니다: " + err.Error()
+		log.Printf("[%s] %s", jobID, errMsg)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply := replyTo(job); reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
+		}
+		return
+	}
+
+	workDir := projectPath
+	if te.Worktree.Enabled {
+		wt := te.Worktree.reuseWorktree(projectPath, parent)
+		if wt == nil {
+			var err error
+			wt, err = te.Worktree.preparePlanWorktree(jobID, projectPath)
+			if err != nil {
+				errMsg := "❌ " + err.Error()
+				log.Printf("[%s] %s", jobID, errMsg)
+				cfg.DB.UpdateJobResult(jobID, "", errMsg)
+				cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+				if reply := replyTo(job); reply.ok() {
+					te.sendDelayedResponse(reply, errMsg)
+				}
+				return
+			}
+			defer removeWorktree(jobID, wt)
+		}
+		log.Printf("[%s] worktree에서 실행 계획을 작성합니다: %s", jobID, wt.Dir())
+		workDir = wt.Dir()
+	}
+
+	guard := ""
+	if _, err := git.TopLevel(workDir); err == nil {
+		commit, err := takeCheckpoint(workDir, jobID, "plan")
+		if err != nil {
+			errMsg := "❌ 계획 단계 스냅샷을 만들 수 없습니다: " + err.Error()
+			log.Printf("[%s] %s", jobID, errMsg)
+			cfg.DB.UpdateJobResult(jobID, "", errMsg)
+			cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+			if reply := replyTo(job); reply.ok() {
+				te.sendDelayedResponse(reply, errMsg)
+			}
+			return
+		}
+		guard = commit
+	}
+
+	// v1.5: 봇 토큰이 있으면 상태 메시지를 게시하고 승인 요청은 그 스레드에 게시
+	status := statusText("📝 실행 계획을 작성 중입니다...", job, shownPrompt)
+	reply := te.startReply(cfg, job, status)
+
+	progressDone := make(chan struct{})
+	if reply.ok() {
+		go te.sendProgressUpdates(jobID, reply, status, progressDone)
+	}
+
+	parser := agent.NewParser()
+	flusher := newOutputFlusher(jobID, cfg.DB, te.redacted(parser.Partial), outputFlushInterval)
+	flusher.Start()
+
+	log.Printf("[%s] 실행 계획 작성 시작 (읽기 전용): prompt='%s'", jobID, shownPrompt)
+	opts.ReadOnly = true
+	agentStarted := time.Now()
+	output, err := te.executeAgent(ctx, jobID, agent, planPrompt(prompt), workDir, parser, opts)
+
+	close(progressDone)
+	flusher.Stop()
+	recordSession(cfg, jobID, parser)
+	recordAgentUsage(cfg, job, agentStarted)
+
+	var restored []database.ChangedFile
+	var restoreErr error
+	if guard != "" {
+		restored, restoreErr = restorePlanChanges(workDir, jobID, guard)
+	}
+
+	// v1.5: 저장/로그/게시 전에 계획의 비밀 값을 가림
+	rawOutput, redactions := te.Redactor.Redact(string(output))
+	recordRedactions(cfg, jobID, redactions)
+	if restoreErr != nil {
+		errMsg := fmt.Sprintf("❌ 계획 단계에서 변경된 파일을 복원하지 못했습니다. 작업 디렉토리(%s)를 확인해주세요: %v", workDir, restoreErr)
+		log.Printf("[%s] %s", jobID, errMsg)
+		cfg.DB.UpdateJobResult(jobID, rawOutput, errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
+		}
+		te.finishStatus(reply, jobID, statusText("❌ 실행 계획 작성에 실패했습니다. 결과는 스레드를 확인하세요", job, shownPrompt), ActionShowOutput)
+		return
+	}
+	if errors.Is(err, ErrJobCancelled) {
+		log.Printf("[%s] %v", jobID, err)
+		cfg.DB.UpdateJobResult(jobID, rawOutput, err.Error())
+		if reply.ok() {
+			te.sendCancelledMessage(reply, jobID, err)
+		}
+		te.finishStatus(reply, jobID, statusText("🛑 작업이 취소되었습니다", job, shownPrompt), ActionRetryJob, ActionShowOutput)
+		return
+	}
+	if err != nil {
+		log.Printf("[%s] 실행 계획 작성 오류: %v, output: %s", jobID, err, rawOutput)
+		cfg.DB.UpdateJobResult(jobID, rawOutput, err.Error())
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			messages := te.formatErrorOutput(jobID, err, rawOutput, reply.maxMessages())
+			blocks := te.formatErrorBlocks(jobID, err, rawOutput)
+			blocks, messages = withRedactionNote(blocks, messages, redactions)
+			blocks = append(blocks, JobActionsBlock(jobID, ActionRetryJob, ActionShowOutput))
+			te.sendBlockMessages(reply, jobID, "❌ 실행 계획 작성 중 오류 발생", blocks, messages, "in_channel")
+		}
+		te.finishStatus(reply, jobID, statusText("❌ 실행 계획 작성에 실패했습니다. 결과는 스레드를 확인하세요", job, shownPrompt), ActionRetryJob, ActionShowOutput)
+		return
+	}
+
+	ok, err := cfg.DB.SetJobAwaitingApproval(jobID, rawOutput)
+	if err != nil {
+		log.Printf("[%s] 승인 대기 전환 실패: %v", jobID, err)
+		return
+	}
+	if !ok {
+		log.Printf("[%s] 계획 작성 중 취소된 작업입니다. 승인을 요청하지 않습니다.", jobID)
+		return
+	}
+	log.Printf("[%s] 실행 계획 작성 완료. 승인 대기 중입니다.", jobID)
+
+	if reply.ok() {
+		blocks := formatApprovalBlocks(jobID, job, shownPrompt, rawOutput, restored)
+		fallback := te.splitMessage(formatApprovalText(jobID, job, shownPrompt, te.convertMarkdownToSlack(rawOutput), restored), reply.maxMessages())
+		blocks, fallback = withRedactionNote(blocks, fallback, redactions)
+		te.sendBlockMessages(reply, jobID, "📝 실행 계획 승인 요청: "+truncateRunes(shownPrompt, 100), blocks, fallback, "in_channel")
+	}
+	te.finishStatus(reply, jobID, statusText("📝 실행 계획 승인을 기다리고 있습니다. 스레드의 계획을 확인하세요", job, shownPrompt), ActionApproveJob, ActionRejectJob, ActionCancelJob)
+}
+
+// restorePlanChanges는 계획 단계 중 변경된 파일을 스냅샷(guard) 상태로 복원하고 복원한 파일 목록을 반환합니다.
+// 변경 파일 경로는 저장소 최상위 기준이므로 프로젝트가 하위 디렉토리여도 저장소 최상위에서 복원합니다.
+func restorePlanChanges(dir string, jobID string, guard string) ([]database.ChangedFile, error) {
+	root, err := git.TopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+	after, err := git.Snapshot(dir, fmt.Sprintf("cursor job %s: plan-after", shortID(jobID)))
+	if err != nil {
+		return nil, err
+	}
+	changed, err := git.ChangedFiles(dir, guard, after)
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	files := make([]database.ChangedFile, 0, len(changed))
+	for _, f := range changed {
+		files = append(files, database.ChangedFile{Status: f.Status, Path: f.Path, OldPath: f.OldPath})
+	}
+	log.Printf("[%s] 계획 단계에서 %d개 파일이 변경되어 복원합니다.", jobID, len(files))
+	return files, restoreFiles(root, guard, files)
+}
+
+// approvalTarget은 승인 요청 메시지에 표시할 실행 대상입니다.
+func approvalTarget(job Job) string {
+	if job.ProjectName != "" {
+		return fmt.Sprintf("@%s", job.ProjectName)
+	}
+	return job.ProjectPath
+}
+
+// formatApprovalBlocks는 실행 계획과 승인/거절/취소 버튼이 있는 승인 요청 메시지를 만듭니다.
+func formatApprovalBlocks(jobID string, job Job, prompt string, plan string, restored []database.ChangedFile) []types.SlackBlock {
+	blocks := []types.SlackBlock{
+		headerBlock("📝 실행 계획 승인 요청"),
+		sectionBlock(fmt.Sprintf("*요청자:* <@%s>\n*대상:* `%s`\n> %s",
+			job.Payload.UserID, approvalTarget(job), truncateRunes(prompt, maxBlockTextChars-200))),
+		dividerBlock(),
+		sectionBlock("🗒️ *실행 계획*"),
+	}
+	if strings.TrimSpace(plan) != "" {
+		blocks = append(blocks, renderMarkdownBlocks(plan)...)
+	} else {
+		blocks = append(blocks, sectionBlock("계획 출력이 없습니다."))
+	}
+	if len(restored) > 0 {
+		blocks = append(blocks, sectionBlock(fmt.Sprintf("⚠️ 계획 단계에서 변경된 파일 %d개를 실행 전 상태로 복원했습니다: %s",
+			len(restored), truncateRunes(restoredPaths(restored), 500))))
+	}
+	blocks = append(blocks,
+		contextBlock(fmt.Sprintf("승인하면 파일 수정 권한으로 실행합니다. 지정된 승인자만 승인/거절할 수 있습니다. (`/cursor approve %s`, `/cursor reject %s [사유]`)", jobID[:8], jobID[:8])),
+		JobActionsBlock(jobID, ActionApproveJob, ActionRejectJob, ActionCancelJob),
+	)
+	return blocks
+}
+
+// formatApprovalText는 Block Kit 전송 실패 시 사용할 승인 요청 텍스트입니다.
+func formatApprovalText(jobID string, job Job, prompt string, plan string, restored []database.ChangedFile) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📝 *실행 계획 승인 요청* (ID: `%s`)\n", jobID[:8]))
+	b.WriteString(fmt.Sprintf("*요청자:* <@%s>\n*대상:* `%s`\n> %s\n\n", job.Payload.UserID, approvalTarget(job), prompt))
+	b.WriteString("🗒️ *실행 계획*\n")
+	b.WriteString(plan)
+	b.WriteString("\n")
+	if len(restored) > 0 {
+		b.WriteString(fmt.Sprintf("\n⚠️ 계획 단계에서 변경된 파일을 실행 전 상태로 복원했습니다: %s\n", restoredPaths(restored)))
+	}
+	b.WriteString(fmt.Sprintf("\n승인: `/cursor approve %s` · 거절: `/cursor reject %s [사유]`", jobID[:8], jobID[:8]))
+	return b.String()
+}
+
+func restoredPaths(files []database.ChangedFile) string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return strings.Join(paths, ", ")
+}
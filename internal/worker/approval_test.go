@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestorePlanChangesInSubdirectory(t *testing.T) {
+	repo := newTestRepo(t)
+	dir := filepath.Join(repo, "sub") // 저장소의 하위 디렉토리로 등록된 프로젝트
+	jobID := "11111111-0000-0000-0000-000000000000"
+
+	guard, err := takeCheckpoint(dir, jobID, "plan")
+	if err != nil {
+		t.Fatalf("takeCheckpoint: %v", err)
+	}
+	// 읽기 전용을 지키지 않은 계획 단계가 파일을 수정/추가한 상황
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plan.txt"), []byte("draft\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := restorePlanChanges(dir, jobID, guard)
+	if err != nil {
+		t.Fatalf("restorePlanChanges: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("restored files = %v, want 2", files)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "README.md")); err != nil || string(data) != "# test\n" {
+		t.Errorf("README.md = %q, %v; want 복원", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "plan.txt")); !os.IsNotExist(err) {
+		t.Errorf("계획 단계에서 추가한 파일이 남아 있습니다: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub")); !os.IsNotExist(err) {
+		t.Errorf("저장소 최상위 기준 경로를 하위 디렉토리에 복원했습니다: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// DefaultFileUploadThreshold는 출력/diff를 메시지 대신 파일로 첨부하는 기본 길이(바이트)입니다.
+const DefaultFileUploadThreshold = 8000
+
+// attachmentPreviewChars는 파일로 첨부한 출력/diff 중 메시지 본문에 미리보기로 남기는 최대 길이입니다.
+const attachmentPreviewChars = 3000
+
+// resultAttachment는 작업 결과 스레드에 파일로 첨부할 출력 또는 diff입니다 (v1.5)
+type resultAttachment struct {
+	Filename    string
+	Title       string
+	SnippetType string
+	Content     string
+}
+
+// canAttach는 결과를 파일로 첨부할 수 있는지 반환합니다.
+// 파일은 봇 토큰으로 스레드에 공유하므로, 스레드가 없는(response_url만 있는) 작업은 기존처럼 메시지로만 보냅니다.
+func (te *TaskExecutor) canAttach(reply replyTarget) bool {
+	return te.Slack != nil && te.FileUploadThreshold > 0 && reply.Channel != "" && reply.ThreadTS != ""
+}
+
+// attachLargeResults는 FileUploadThreshold를 넘는 출력/diff를 첨부 파일로 분리합니다 (v1.5)
+//
+// 메시지에 표시할 출력(앞부분 미리보기와 첨부 안내)과 변경 사항(diff 미리보기, DiffFile 설정)을 반환합니다.
+// 첨부할 수 없거나 임계값 이하이면 입력을 그대로 반환합니다.
+func (te *TaskExecutor) attachLargeResults(reply replyTarget, jobID string, rawOutput string, changes *jobChanges) (string, *jobChanges, []resultAttachment) {
+	if !te.canAttach(reply) {
+		return rawOutput, changes, nil
+	}
+
+	var attachments []resultAttachment
+	if len(rawOutput) > te.FileUploadThreshold {
+		filename := fmt.Sprintf("job-%s-output.md", shortID(jobID))
+		attachments = append(attachments, resultAttachment{
+			Filename:    filename,
+			Title:       fmt.Sprintf("작업 %s 전체 출력", shortID(jobID)),
+			SnippetType: "markdown",
+			Content:     rawOutput,
+		})
+		rawOutput = previewText(rawOutput, attachmentPreviewChars) +
+			fmt.Sprintf("\n\n📎 전체 출력(%d자)은 스레드에 첨부한 파일 `%s`을 확인하세요.", utf8.RuneCountInString(rawOutput), filename)
+	}
+	if changes != nil && len(changes.Diff) > te.FileUploadThreshold {
+		filename := fmt.Sprintf("job-%s.diff", shortID(jobID))
+		attachments = append(attachments, resultAttachment{
+			Filename:    filename,
+			Title:       fmt.Sprintf("작업 %s 전체 diff", shortID(jobID)),
+			SnippetType: "diff",
+			Content:     changes.Diff,
+		})
+		shown := *changes
+		shown.Diff = previewText(changes.Diff, attachmentPreviewChars)
+		shown.DiffFile = filename
+		changes = &shown
+	}
+	return rawOutput, changes, attachments
+}
+
+// uploadAttachments는 첨부 파일을 결과 스레드에 업로드합니다.
+// 업로드에 실패하면 스레드에 안내 메시지를 남기며, 전체 내용은 `/cursor show`와 API로 계속 조회할 수 있습니다.
+func (te *TaskExecutor) uploadAttachments(reply replyTarget, jobID string, attachments []resultAttachment) {
+	for _, a := range attachments {
+		ctx, cancel := context.WithTimeout(context.Background(), slackPostTimeout)
+		fileID, err := te.Slack.UploadFile(ctx, slack.FileUpload{
+			Channel:     reply.Channel,
+			ThreadTS:    reply.ThreadTS,
+			Filename:    a.Filename,
+			Title:       a.Title,
+			Content:     []byte(a.Content),
+			SnippetType: a.SnippetType,
+		})
+		cancel()
+		if err != nil {
+			log.Printf("[%s] 파일 첨부 실패 (%s): %v", jobID, a.Filename, err)
+			te.postReply(reply, types.SlackDelayedResponse{
+				Text: fmt.Sprintf("⚠️ `%s` 파일을 첨부하지 못했습니다. 전체 내용은 `/cursor show %s`로 확인하세요.", a.Filename, shortID(jobID)),
+			})
+			continue
+		}
+		log.Printf("[%s] 파일 첨부 완료: %s (%s, %d bytes)", jobID, a.Filename, fileID, len(a.Content))
+	}
+}
+
+// previewText는 s의 앞부분을 최대 n바이트까지 줄 단위로 잘라 반환합니다.
+// 코드 블록(```) 안에서 잘리면 블록을 닫아 이후 메시지가 코드로 표시되지 않게 합니다.
+func previewText(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := strings.LastIndex(s[:n], "\n")
+	if cut <= 0 {
+		cut = textutil.RuneStart(s, n)
+	}
+	preview := strings.TrimRight(s[:cut], "\n")
+	if strings.Count(preview, "```")%2 == 1 {
+		preview += "\n```"
+	}
+	return preview + "\n…"
+}
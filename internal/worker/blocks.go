@@ -0,0 +1,539 @@
+package worker
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// v1.5: Slack Block Kit 렌더러
+//
+// 작업 결과를 header / section / context / rich_text 블록으로 구성합니다.
+// rich_text는 mrkdwn 변환 없이 텍스트를 그대로 표시하므로, 코드/표/중첩 목록이 깨지지 않습니다.
+// 블록 전송이 실패하면 기존 텍스트 메시지(formatSuccessOutput/formatErrorOutput)로 대체합니다.
+
+const (
+	maxBlocksPerMessage = 50   // Slack 메시지당 최대 블록 수
+	maxBlockTextChars   = 3000 // section 텍스트 / rich_text 블록당 최대 길이
+	maxHeaderChars      = 150  // header 텍스트 최대 길이
+)
+
+// truncateRunes는 s를 최대 n자로 자르고, 잘린 경우 "…"를 붙입니다.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
+func headerBlock(text string) types.SlackBlock {
+	return types.SlackBlock{
+		Type: "header",
+		Text: &types.SlackTextObject{Type: "plain_text", Text: truncateRunes(text, maxHeaderChars), Emoji: true},
+	}
+}
+
+func sectionBlock(mrkdwn string) types.SlackBlock {
+	return types.SlackBlock{
+		Type: "section",
+		Text: &types.SlackTextObject{Type: "mrkdwn", Text: truncateRunes(mrkdwn, maxBlockTextChars)},
+	}
+}
+
+func contextBlock(items ...string) types.SlackBlock {
+	block := types.SlackBlock{Type: "context"}
+	for _, item := range items {
+		block.Elements = append(block.Elements, types.SlackBlockElement{Type: "mrkdwn", Text: item})
+	}
+	return block
+}
+
+func dividerBlock() types.SlackBlock {
+	return types.SlackBlock{Type: "divider"}
+}
+
+func richTextBlock(elements ...types.SlackBlockElement) types.SlackBlock {
+	block := types.SlackBlock{Type: "rich_text", Elements: make([]interface{}, 0, len(elements))}
+	for _, el := range elements {
+		block.Elements = append(block.Elements, el)
+	}
+	return block
+}
+
+func textElement(text string, style *types.SlackTextStyle) types.SlackBlockElement {
+	el := types.SlackBlockElement{Type: "text", Text: text}
+	if style != nil {
+		el.Style = style
+	}
+	return el
+}
+
+// richSection은 인라인 요소로 rich_text_section을 만듭니다.
+func richSection(elements ...types.SlackBlockElement) types.SlackBlockElement {
+	return types.SlackBlockElement{Type: "rich_text_section", Elements: elements}
+}
+
+// richTextBuilder는 rich_text 요소를 블록 크기 제한에 맞춰 여러 rich_text 블록으로 묶습니다.
+type richTextBuilder struct {
+	blocks   []types.SlackBlock
+	elements []types.SlackBlockElement
+	size     int
+}
+
+func (b *richTextBuilder) add(el types.SlackBlockElement, size int) {
+	if len(b.elements) > 0 && b.size+size > maxBlockTextChars {
+		b.flush()
+	}
+	b.elements = append(b.elements, el)
+	b.size += size
+}
+
+// addPreformatted는 코드/표/diff를 고정폭 블록으로 추가합니다. 긴 텍스트는 줄 단위로 나눕니다.
+func (b *richTextBuilder) addPreformatted(text string) {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return
+	}
+	for _, chunk := range splitLines(text, maxBlockTextChars) {
+		b.add(types.SlackBlockElement{
+			Type:     "rich_text_preformatted",
+			Elements: []types.SlackBlockElement{textElement(chunk, nil)},
+		}, len(chunk))
+	}
+}
+
+func (b *richTextBuilder) flush() {
+	if len(b.elements) == 0 {
+		return
+	}
+	b.blocks = append(b.blocks, richTextBlock(b.elements...))
+	b.elements = nil
+	b.size = 0
+}
+
+// splitLines는 text를 최대 limit 바이트 단위로 나눕니다. 가능하면 줄바꿈 위치에서 나눕니다.
+func splitLines(text string, limit int) []string {
+	var chunks []string
+	for len(text) > limit {
+		cut := strings.LastIndex(text[:limit], "\n")
+		if cut <= 0 {
+			cut = textutil.RuneStart(text, limit)
+		}
+		chunks = append(chunks, text[:cut])
+		text = strings.TrimPrefix(text[cut:], "\n")
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
+var (
+	mdListItemPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdHeadingPattern     = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	mdTableRowPattern    = regexp.MustCompile(`^\s*\|.*\|\s*$`)
+	mdTableSepPattern    = regexp.MustCompile(`^[\s|:\-]+$`)
+	mdInlinePattern      = regexp.MustCompile("`([^`]+)`|\\*\\*([^*]+)\\*\\*|~~([^~]+)~~|\\[([^\\]]+)\\]\\((https?://[^)\\s]+)\\)|\\*([^*\\s][^*]*)\\*")
+	mdQuotePrefixPattern = regexp.MustCompile(`^>\s?`)
+)
+
+// mdListItem은 목록 한 항목입니다.
+type mdListItem struct {
+	level   int
+	ordered bool
+	text    string
+}
+
+// renderMarkdownBlocks는 마크다운 텍스트를 rich_text 블록으로 변환합니다.
+//
+// 코드 블록과 표는 고정폭(rich_text_preformatted)으로, 목록은 들여쓰기 단계를 유지한 rich_text_list로,
+// 제목은 굵은 글씨, 인용은 rich_text_quote로 표시합니다.
+func renderMarkdownBlocks(markdown string) []types.SlackBlock {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var b richTextBuilder
+	var para []string
+	var list []mdListItem
+	var indents []int // 목록 들여쓰기 폭 스택 (중첩 단계 계산용)
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		text := strings.Join(para, "\n")
+		b.add(richSection(inlineElements(text)...), len(text))
+		para = nil
+	}
+	flushList := func() {
+		if len(list) == 0 {
+			return
+		}
+		for _, el := range listElements(list) {
+			b.add(el, elementSize(el))
+		}
+		list = nil
+		indents = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushPara()
+			flushList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.addPreformatted(strings.Join(code, "\n"))
+
+		case mdTableRowPattern.MatchString(line):
+			flushPara()
+			flushList()
+			var rows []string
+			for ; i < len(lines) && mdTableRowPattern.MatchString(lines[i]); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			b.addPreformatted(formatTable(rows))
+
+		case trimmed == "", trimmed == "---", trimmed == "***":
+			// 빈 줄 / 구분선은 문단 경계로만 사용
+			flushPara()
+			flushList()
+
+		case mdHeadingPattern.MatchString(trimmed):
+			flushPara()
+			flushList()
+			heading := mdHeadingPattern.FindStringSubmatch(trimmed)[1]
+			b.add(richSection(textElement(heading, &types.SlackTextStyle{Bold: true})), len(heading))
+
+		case mdListItemPattern.MatchString(line):
+			flushPara()
+			m := mdListItemPattern.FindStringSubmatch(line)
+			width := indentWidth(m[1])
+			for len(indents) > 0 && indents[len(indents)-1] > width {
+				indents = indents[:len(indents)-1]
+			}
+			if len(indents) == 0 || indents[len(indents)-1] < width {
+				indents = append(indents, width)
+			}
+			ordered := m[2] != "-" && m[2] != "*" && m[2] != "+"
+			list = append(list, mdListItem{level: len(indents) - 1, ordered: ordered, text: m[3]})
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushPara()
+			flushList()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, mdQuotePrefixPattern.ReplaceAllString(strings.TrimSpace(lines[i]), ""))
+			}
+			i--
+			text := strings.Join(quote, "\n")
+			b.add(types.SlackBlockElement{Type: "rich_text_quote", Elements: inlineElements(text)}, len(text))
+
+		default:
+			if len(list) > 0 && line != trimmed {
+				// 들여쓴 줄은 직전 목록 항목의 연속
+				list[len(list)-1].text += " " + trimmed
+				continue
+			}
+			flushList()
+			para = append(para, trimmed)
+		}
+	}
+	flushPara()
+	flushList()
+	b.flush()
+	return b.blocks
+}
+
+// elementSize는 요소에 포함된 텍스트 길이의 합입니다.
+func elementSize(el types.SlackBlockElement) int {
+	size := len(el.Text)
+	for _, child := range el.Elements {
+		size += elementSize(child)
+	}
+	return size
+}
+
+// indentWidth는 들여쓰기 폭을 계산합니다 (탭은 4칸).
+func indentWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// listElements는 연속된 목록 항목을 들여쓰기/종류가 같은 rich_text_list 요소로 묶습니다.
+func listElements(items []mdListItem) []types.SlackBlockElement {
+	var elements []types.SlackBlockElement
+	for _, item := range items {
+		style := "bullet"
+		if item.ordered {
+			style = "ordered"
+		}
+		section := richSection(inlineElements(item.text)...)
+
+		if n := len(elements); n > 0 && elements[n-1].Indent == item.level && elements[n-1].Style == style {
+			elements[n-1].Elements = append(elements[n-1].Elements, section)
+			continue
+		}
+		elements = append(elements, types.SlackBlockElement{
+			Type:     "rich_text_list",
+			Style:    style,
+			Indent:   item.level,
+			Elements: []types.SlackBlockElement{section},
+		})
+	}
+	return elements
+}
+
+// inlineElements는 인라인 마크다운(`코드`, **굵게**, *기울임*, ~~취소선~~, [링크](url))을 rich_text 요소로 변환합니다.
+func inlineElements(text string) []types.SlackBlockElement {
+	var elements []types.SlackBlockElement
+	last := 0
+	for _, m := range mdInlinePattern.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > last {
+			elements = append(elements, textElement(text[last:m[0]], nil))
+		}
+		switch {
+		case m[2] >= 0:
+			elements = append(elements, textElement(text[m[2]:m[3]], &types.SlackTextStyle{Code: true}))
+		case m[4] >= 0:
+			elements = append(elements, textElement(text[m[4]:m[5]], &types.SlackTextStyle{Bold: true}))
+		case m[6] >= 0:
+			elements = append(elements, textElement(text[m[6]:m[7]], &types.SlackTextStyle{Strike: true}))
+		case m[8] >= 0:
+			elements = append(elements, types.SlackBlockElement{Type: "link", Text: text[m[8]:m[9]], URL: text[m[10]:m[11]]})
+		case m[12] >= 0:
+			elements = append(elements, textElement(text[m[12]:m[13]], &types.SlackTextStyle{Italic: true}))
+		}
+		last = m[1]
+	}
+	if last < len(text) {
+		elements = append(elements, textElement(text[last:], nil))
+	}
+	if len(elements) == 0 {
+		elements = append(elements, textElement(" ", nil))
+	}
+	return elements
+}
+
+// formatTable은 마크다운 표를 열 너비를 맞춘 고정폭 텍스트로 변환합니다.
+func formatTable(rows []string) string {
+	var cells [][]string
+	var widths []int
+	for _, row := range rows {
+		if mdTableSepPattern.MatchString(row) {
+			continue
+		}
+		cols := strings.Split(strings.Trim(row, "|"), "|")
+		for i := range cols {
+			cols[i] = strings.TrimSpace(cols[i])
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := utf8.RuneCountInString(cols[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		cells = append(cells, cols)
+	}
+
+	var out strings.Builder
+	for r, cols := range cells {
+		for i, col := range cols {
+			if i > 0 {
+				out.WriteString(" | ")
+			}
+			out.WriteString(col)
+			if i < len(cols)-1 {
+				out.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(col)))
+			}
+		}
+		out.WriteString("\n")
+		if r == 0 && len(cells) > 1 {
+			// 머리글 구분선
+			for i, w := range widths {
+				if i > 0 {
+					out.WriteString("-+-")
+				}
+				out.WriteString(strings.Repeat("-", w))
+			}
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// formatSuccessBlocks는 작업 성공 결과를 Block Kit 블록으로 구성합니다 (v1.5)
+// 구성: 완료 헤더, 프롬프트 인용, 변경 파일 목록, diff, 실행 결과, Job ID/브랜치 context.
+func (te *TaskExecutor) formatSuccessBlocks(jobID string, rawOutput string, prompt string, branch string, changes *jobChanges) []types.SlackBlock {
+	blocks := []types.SlackBlock{
+		headerBlock("✅ Cursor AI 작업 완료"),
+		sectionBlock("📝 *요청 프롬프트*"),
+		richTextBlock(types.SlackBlockElement{Type: "rich_text_quote", Elements: []types.SlackBlockElement{textElement(truncateRunes(prompt, maxBlockTextChars), nil)}}),
+	}
+
+	lines := strings.Split(rawOutput, "\n")
+	var b richTextBuilder
+	if changes != nil {
+		if len(changes.Files) > 0 {
+			blocks = append(blocks, sectionBlock(fmt.Sprintf("📁 *변경된 파일* (%d개)", len(changes.Files))))
+			items := make([]types.SlackBlockElement, 0, len(changes.Files))
+			for _, f := range changes.Files {
+				path := []types.SlackBlockElement{textElement(f.Path, &types.SlackTextStyle{Code: true})}
+				if f.OldPath != "" {
+					path = []types.SlackBlockElement{
+						textElement(f.OldPath, &types.SlackTextStyle{Code: true}),
+						textElement(" → ", nil),
+						textElement(f.Path, &types.SlackTextStyle{Code: true}),
+					}
+				}
+				items = append(items, richSection(append(path, textElement(" ("+changeStatusText(f.Status)+")", nil))...))
+			}
+			for _, chunk := range chunkElements(items, maxShownFileItems) {
+				blocks = append(blocks, richTextBlock(types.SlackBlockElement{Type: "rich_text_list", Style: "bullet", Elements: chunk}))
+			}
+		} else {
+			blocks = append(blocks, sectionBlock("📁 *변경된 파일*\n변경된 파일이 없습니다."))
+		}
+
+		if changes.Diff != "" {
+			diff := changes.Diff
+			truncated := len(diff) > maxSlackDiffChars
+			diff = textutil.Truncate(diff, maxSlackDiffChars)
+			blocks = append(blocks, sectionBlock("💻 *변경된 코드*"))
+			b.addPreformatted(diff)
+			b.flush()
+			blocks = append(blocks, b.blocks...)
+			b.blocks = nil
+			if changes.DiffFile != "" {
+				blocks = append(blocks, contextBlock(fmt.Sprintf("📎 전체 diff: 스레드에 첨부한 파일 `%s`", changes.DiffFile)))
+			} else if truncated {
+				blocks = append(blocks, contextBlock(fmt.Sprintf("… 전체 diff: `/cursor show %s` 또는 GET /api/jobs/{id}", jobID[:8])))
+			}
+		}
+	} else {
+		if files := te.extractModifiedFiles(lines); len(files) > 0 {
+			var list strings.Builder
+			list.WriteString("📁 *변경된 파일*\n")
+			for _, file := range files {
+				list.WriteString(fmt.Sprintf("• `%s`\n", file))
+			}
+			blocks = append(blocks, sectionBlock(list.String()))
+		}
+		if code := te.extractCodeChanges(lines); code != "" {
+			blocks = append(blocks, sectionBlock("💻 *변경된 코드*"))
+			b.addPreformatted(code)
+			b.flush()
+			blocks = append(blocks, b.blocks...)
+		}
+	}
+
+	if summary := te.extractChangeSummary(lines); summary != "" {
+		blocks = append(blocks, sectionBlock("🔧 *주요 변경 사항*\n"+summary))
+	}
+
+	blocks = append(blocks, dividerBlock(), sectionBlock("📄 *실행 결과*"))
+	blocks = append(blocks, renderMarkdownBlocks(rawOutput)...)
+
+	meta := []string{fmt.Sprintf("🆔 Job ID: `%s`", jobID[:8])}
+	if branch != "" {
+		meta = append(meta, fmt.Sprintf("🌿 작업 브랜치: `%s`", branch))
+	}
+	meta = append(meta, fmt.Sprintf("💬 이어서 요청: `/cursor reply %s \"프롬프트\"`", jobID[:8]))
+	blocks = append(blocks, contextBlock(meta...))
+	return blocks
+}
+
+// formatErrorBlocks는 작업 실패 결과를 Block Kit 블록으로 구성합니다 (v1.5)
+func (te *TaskExecutor) formatErrorBlocks(jobID string, err error, rawOutput string) []types.SlackBlock {
+	blocks := []types.SlackBlock{
+		headerBlock("❌ Cursor AI 실행 중 오류 발생"),
+		sectionBlock("🚨 *오류 메시지*"),
+		richTextBlock(types.SlackBlockElement{Type: "rich_text_quote", Elements: []types.SlackBlockElement{textElement(truncateRunes(err.Error(), maxBlockTextChars), nil)}}),
+	}
+	if strings.TrimSpace(rawOutput) != "" {
+		blocks = append(blocks, dividerBlock(), sectionBlock("📄 *출력 내용*"))
+		blocks = append(blocks, renderMarkdownBlocks(rawOutput)...)
+	}
+	blocks = append(blocks, contextBlock(fmt.Sprintf("💡 자세한 정보: `/cursor show %s`", jobID[:8])))
+	return blocks
+}
+
+// maxShownFileItems는 rich_text_list 하나에 담는 변경 파일 항목 수입니다.
+const maxShownFileItems = 50
+
+// chunkElements는 요소를 size개씩 나눕니다.
+func chunkElements(elements []types.SlackBlockElement, size int) [][]types.SlackBlockElement {
+	var chunks [][]types.SlackBlockElement
+	for len(elements) > size {
+		chunks = append(chunks, elements[:size])
+		elements = elements[size:]
+	}
+	if len(elements) > 0 {
+		chunks = append(chunks, elements)
+	}
+	return chunks
+}
+
+// chunkBlocks는 블록을 메시지당 최대 블록 수 단위로 나눕니다.
+func chunkBlocks(blocks []types.SlackBlock) [][]types.SlackBlock {
+	var chunks [][]types.SlackBlock
+	for len(blocks) > maxBlocksPerMessage {
+		chunks = append(chunks, blocks[:maxBlocksPerMessage])
+		blocks = blocks[maxBlocksPerMessage:]
+	}
+	if len(blocks) > 0 {
+		chunks = append(chunks, blocks)
+	}
+	return chunks
+}
+
+// sendBlockMessages는 Block Kit 메시지를 전송합니다 (v1.5)
+// 블록이 최대 메시지 수(response_url 호출 횟수 등) 안에 담기지 않거나 첫 메시지가 거부되면(invalid_blocks 등) 텍스트 메시지로 대체합니다.
+func (te *TaskExecutor) sendBlockMessages(reply replyTarget, jobID string, notification string, blocks []types.SlackBlock, fallback []string, responseType string) {
+	chunks := chunkBlocks(blocks)
+	if len(chunks) == 0 || len(chunks) > reply.maxMessages() {
+		log.Printf("[%s] 블록 수가 너무 많아 텍스트 메시지로 전송합니다: %d개", jobID, len(blocks))
+		te.sendMultipleMessages(reply, fallback, jobID, responseType)
+		return
+	}
+
+	for i, chunk := range chunks {
+		log.Printf("[%s] 블록 메시지 전송 (%d/%d): %d개 블록", jobID, i+1, len(chunks), len(chunk))
+		err := te.postReply(reply, types.SlackDelayedResponse{
+			Text:         notification,
+			ResponseType: "in_channel",
+			Blocks:       chunk,
+		})
+		if err != nil {
+			log.Printf("[%s] 블록 메시지 전송 실패: %v", jobID, err)
+			if i == 0 {
+				te.sendMultipleMessages(reply, fallback, jobID, responseType)
+			}
+			return
+		}
+
+		// 메시지 간 짧은 대기 (Slack rate limit 방지)
+		if i < len(chunks)-1 {
+			time.Sleep(500 * time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+	"github.com/kakaovx/cursor-slack-server/internal/redact"
+)
+
+// jobChanges는 실행 전후 스냅샷으로 계산한 작업의 실제 변경 사항입니다 (v1.5)
+type jobChanges struct {
+	BaseCommit string                 // 실행 시작 시점의 HEAD
+	Before     string                 // 실행 전 스냅샷 커밋
+	After      string                 // 실행 후 스냅샷 커밋
+	Diff       string                 // Before..After unified diff
+	Files      []database.ChangedFile // Before..After 변경 파일
+	DiffFile   string                 // 전체 diff를 스레드에 첨부한 파일 이름 (이때 Diff는 미리보기)
+	Redactions int                    // diff에서 가린 비밀 값 수
+}
+
+// checkpointRef는 작업 스냅샷을 보존하는 ref 이름입니다.
+// ref로 연결해두면 git gc로 삭제되지 않고, worktree가 정리된 뒤에도 원본 저장소에서 조회할 수 있습니다.
+func checkpointRef(jobID string, phase string) string {
+	return fmt.Sprintf("refs/cursor/checkpoints/%s/%s", jobID, phase)
+}
+
+// takeCheckpoint는 작업 디렉토리의 현재 상태를 스냅샷 커밋으로 저장하고 ref로 보존합니다.
+func takeCheckpoint(dir string, jobID string, phase string) (string, error) {
+	commit, err := git.Snapshot(dir, fmt.Sprintf("cursor job %s: %s", shortID(jobID), phase))
+	if err != nil {
+		return "", err
+	}
+	if err := git.UpdateRef(dir, checkpointRef(jobID, phase), commit); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// beginChanges는 실행 전 스냅샷을 만듭니다.
+// git 저장소가 아니거나 스냅샷에 실패하면 nil을 반환하며, 이 경우 변경 사항은 출력에서 추정합니다.
+func beginChanges(jobID string, dir string) *jobChanges {
+	if _, err := git.TopLevel(dir); err != nil {
+		log.Printf("[%s] git 저장소가 아니므로 변경 사항을 추적하지 않습니다: %s", jobID, dir)
+		return nil
+	}
+
+	base, err := git.HeadCommit(dir)
+	if err != nil {
+		log.Printf("[%s] HEAD 조회 실패: %v", jobID, err)
+		return nil
+	}
+	before, err := takeCheckpoint(dir, jobID, "before")
+	if err != nil {
+		log.Printf("[%s] 실행 전 스냅샷 실패: %v", jobID, err)
+		return nil
+	}
+	return &jobChanges{BaseCommit: base, Before: before}
+}
+
+// finish는 실행 후 스냅샷을 만들고 실행 전 스냅샷과의 diff를 계산하여 DB에 기록합니다.
+// v1.5: diff는 비밀 값을 가린 뒤 저장합니다 (되돌리기는 스냅샷 커밋을 사용하므로 영향 없음).
+func (c *jobChanges) finish(jobID string, dir string, db DBInterface, redactor *redact.Redactor) error {
+	after, err := takeCheckpoint(dir, jobID, "after")
+	if err != nil {
+		return fmt.Errorf("실행 후 스냅샷 실패: %w", err)
+	}
+	c.After = after
+
+	diff, err := git.Diff(dir, c.Before, c.After)
+	if err != nil {
+		return err
+	}
+	c.Diff, c.Redactions = redactor.Redact(diff)
+
+	files, err := git.ChangedFiles(dir, c.Before, c.After)
+	if err != nil {
+		return err
+	}
+	c.Files = make([]database.ChangedFile, 0, len(files))
+	for _, f := range files {
+		c.Files = append(c.Files, database.ChangedFile{Status: f.Status, Path: f.Path, OldPath: f.OldPath})
+	}
+
+	return db.UpdateJobChanges(jobID, c.BaseCommit, c.Before, c.After, c.Diff, c.Files)
+}
+
+// changeStatusText는 변경 상태 코드를 표시용 텍스트로 변환합니다.
+func changeStatusText(status string) string {
+	switch status {
+	case "A":
+		return "추가"
+	case "M":
+		return "수정"
+	case "D":
+		return "삭제"
+	case "R":
+		return "이름 변경"
+	case "T":
+		return "타입 변경"
+	default:
+		return status
+	}
+}
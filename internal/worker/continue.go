@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// CheckContinuable은 작업을 이어서 실행할 수 있는지 확인합니다 (v1.5)
+// 종료된 작업 중 cursor-agent 채팅 세션이 기록된 작업만 이어서 실행할 수 있습니다.
+// 원본 작업의 에이전트 백엔드가 세션 재개를 지원하지 않으면(resume_args 없음) 이어서 실행할 수 없습니다.
+func CheckContinuable(parent *database.JobRecord, agents *AgentRegistry) error {
+	switch parent.Status {
+	case database.JobStatusPending, database.JobStatusRunning, database.JobStatusAwaitingApproval:
+		return fmt.Errorf("아직 종료되지 않은 작업입니다 (상태: %s)", parent.Status)
+	}
+	if parent.Kind == database.JobKindRevert {
+		return fmt.Errorf("되돌리기 작업은 이어서 실행할 수 없습니다")
+	}
+	if parent.SessionID == "" {
+		// v1.5: 세션을 보고하지 않는 에이전트 백엔드(output: text)의 작업 포함
+		return fmt.Errorf("에이전트 세션 ID가 기록되지 않은 작업입니다")
+	}
+	agent, err := agents.Get(parent.Agent)
+	if err != nil {
+		return err
+	}
+	if !agent.SupportsResume() {
+		return fmt.Errorf("`%s` 에이전트는 세션을 이어서 실행하는 기능을 지원하지 않습니다", agent.Name())
+	}
+	return nil
+}
+
+// continuationParent는 continue 작업이 이어서 실행할 원본 작업을 조회합니다 (v1.5)
+// 요청 이후 원본 작업이 바뀌었을 수 있으므로 실행 시점에 다시 확인합니다.
+func continuationParent(cfg *ConfigFull, job Job) (*database.JobRecord, error) {
+	parent, err := cfg.DB.GetJob(job.ParentJobID)
+	if err != nil || parent == nil {
+		return nil, fmt.Errorf("이어서 실행할 작업을 찾을 수 없습니다: %s", shortID(job.ParentJobID))
+	}
+	if err := CheckContinuable(parent, cfg.Agents); err != nil {
+		return nil, fmt.Errorf("작업 %s을(를) 이어서 실행할 수 없습니다: %v", shortID(parent.ID), err)
+	}
+	return parent, nil
+}
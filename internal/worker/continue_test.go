@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+func TestCheckContinuable(t *testing.T) {
+	agents := NewAgentRegistry(NewCursorAgent("cursor-agent", nil))
+	oneshot, err := NewTemplateAgent("oneshot", AgentTemplate{Command: "x", Args: []string{"{{prompt}}"}, Output: AgentOutputStreamJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agents.Register(oneshot); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		parent  database.JobRecord
+		wantErr bool
+	}{
+		{"completed with session", database.JobRecord{Status: database.JobStatusCompleted, SessionID: "s1"}, false},
+		{"failed with session", database.JobRecord{Status: database.JobStatusFailed, SessionID: "s1"}, false},
+		{"cancelled with session", database.JobRecord{Status: database.JobStatusCancelled, SessionID: "s1"}, false},
+		{"pending", database.JobRecord{Status: database.JobStatusPending, SessionID: "s1"}, true},
+		{"running", database.JobRecord{Status: database.JobStatusRunning, SessionID: "s1"}, true},
+		{"awaiting approval", database.JobRecord{Status: database.JobStatusAwaitingApproval, SessionID: "s1"}, true},
+		{"revert", database.JobRecord{Status: database.JobStatusCompleted, Kind: database.JobKindRevert, SessionID: "s1"}, true},
+		{"no session", database.JobRecord{Status: database.JobStatusCompleted}, true},
+		{"agent without resume", database.JobRecord{Status: database.JobStatusCompleted, SessionID: "s1", Agent: "oneshot"}, true},
+		{"unknown agent", database.JobRecord{Status: database.JobStatusCompleted, SessionID: "s1", Agent: "gemini"}, true},
+	}
+	for _, tt := range tests {
+		if err := CheckContinuable(&tt.parent, agents); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckContinuable() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -3,29 +3,36 @@ package worker
 import (
 	"log"
 	"sync"
+	"time"
 )
 
+// queuePollInterval은 새 작업 알림이 없을 때 큐를 다시 확인하는 주기입니다.
+// (재시작 후 재등록된 작업도 이 주기로 확인됩니다. 같은 DB는 서버 하나만 사용합니다.)
+const queuePollInterval = 2 * time.Second
+
 // Dispatcher는 작업자 풀과 작업 큐를 관리합니다.
 type Dispatcher struct {
-	WorkerPool  chan chan Job // 작업자들의 작업 채널을 등록하는 풀 (작업자 풀)
-	JobQueue    chan Job      // 외부(핸들러)에서 작업을 받는 공용 큐
-	maxWorkers  int           // 작업자 풀의 크기
-	workers     []*Worker     // 실행 중인 작업자 인스턴스 (관리용)
-	wg          *sync.WaitGroup
-	quit        chan struct{} // 디스패처 및 작업자 종료 신호
+	WorkerPool chan chan Job      // 작업자들의 작업 채널을 등록하는 풀 (작업자 풀)
+	JobQueue   *Queue             // 외부(핸들러)에서 작업을 받는 공용 큐 (v1.5: DB 기반 영속 큐)
+	maxWorkers int                // 작업자 풀의 크기
+	workers    []*Worker          // 실행 중인 작업자 인스턴스 (관리용)
+	newConfig  func() *ConfigFull // 작업 실행 시 사용할 설정 생성 함수
+	wg         *sync.WaitGroup
+	quit       chan struct{} // 디스패처 및 작업자 종료 신호
 }
 
 // NewDispatcher는 디스패처를 생성하고 작업자 풀을 초기화합니다.
-func NewDispatcher(jobQueue chan Job, maxWorkers int) *Dispatcher {
+func NewDispatcher(jobQueue *Queue, maxWorkers int, newConfig func() *ConfigFull) *Dispatcher {
 	workerPool := make(chan chan Job, maxWorkers)
 
 	return &Dispatcher{
-		WorkerPool:  workerPool,
-		JobQueue:    jobQueue,
-		maxWorkers:  maxWorkers,
-		workers:     make([]*Worker, 0, maxWorkers),
-		wg:          new(sync.WaitGroup),
-		quit:        make(chan struct{}),
+		WorkerPool: workerPool,
+		JobQueue:   jobQueue,
+		maxWorkers: maxWorkers,
+		workers:    make([]*Worker, 0, maxWorkers),
+		newConfig:  newConfig,
+		wg:         new(sync.WaitGroup),
+		quit:       make(chan struct{}),
 	}
 }
 
@@ -34,7 +41,7 @@ func (d *Dispatcher) Start(executor *TaskExecutor) {
 	// 1. 설정된 수(maxWorkers)만큼 작업자(Worker)를 생성하고 시작합니다.
 	for i := 0; i < d.maxWorkers; i++ {
 		d.wg.Add(1)
-		worker := NewWorker(i+1, d.WorkerPool, d.wg, d.quit, executor)
+		worker := NewWorker(i+1, d.WorkerPool, d.wg, d.quit, executor, d.JobQueue)
 		worker.Start()
 		d.workers = append(d.workers, worker)
 	}
@@ -44,22 +51,51 @@ func (d *Dispatcher) Start(executor *TaskExecutor) {
 	log.Printf("%d개의 작업자(Worker)로 디스패처를 시작합니다.", d.maxWorkers)
 }
 
-// dispatch는 JobQueue에서 작업을 가져와 WorkerPool의 유휴 작업자에게 전달합니다.
+// dispatch는 유휴 작업자가 생기면 JobQueue에서 작업을 가져와 전달합니다.
+// v1.5: 작업을 먼저 꺼낸 뒤 작업자를 기다리면 그 사이 작업이 running으로 보이므로,
+// 유휴 작업자를 먼저 확보한 다음 작업을 claim합니다.
 func (d *Dispatcher) dispatch() {
 	for {
+		// 1. 유휴 작업자의 작업 채널을 WorkerPool에서 가져옵니다.
+		//    (유휴 작업자가 없으면 여기서 블록됩니다.)
+		var workerJobChannel chan Job
 		select {
-		case job := <-d.JobQueue: // 1. 작업 큐에서 새 작업 수신
-			// 2. 유휴 작업자의 작업 채널을 WorkerPool에서 가져옵니다.
-			//    (유휴 작업자가 없으면 여기서 블록됩니다.)
-			workerJobChannel := <-d.WorkerPool
-
-			// 3. 해당 작업자에게 작업 전달
-			workerJobChannel <- job
-
+		case workerJobChannel = <-d.WorkerPool:
 		case <-d.quit:
-			// 4. 종료 신호 수신
 			return
 		}
+
+		// 2. 대기 중인 작업을 claim합니다. (없으면 새 작업 알림 또는 폴링 주기까지 대기)
+		for {
+			rec, err := d.JobQueue.claim()
+			if err != nil {
+				log.Printf("⚠️ 작업 큐 조회 실패: %v", err)
+			}
+
+			if rec != nil {
+				job := d.JobQueue.jobFromRecord(rec, d.newConfig())
+
+				// 3. 해당 작업자에게 작업 전달
+				select {
+				case workerJobChannel <- job:
+				case <-d.quit:
+					// 종료 중이면 작업을 다시 대기 상태로 되돌려 다음 실행 때 처리
+					if err := d.JobQueue.release(job.ID); err != nil {
+						log.Printf("[%s] 작업 반환 실패: %v", job.ID, err)
+					}
+					return
+				}
+				break
+			}
+
+			select {
+			case <-d.JobQueue.notify:
+			case <-time.After(queuePollInterval):
+			case <-d.quit:
+				// 4. 종료 신호 수신
+				return
+			}
+		}
 	}
 }
 
@@ -67,9 +103,9 @@ func (d *Dispatcher) dispatch() {
 func (d *Dispatcher) Stop() {
 	log.Println("   디스패처 종료 신호 전송 중...")
 	close(d.quit)
-	
+
 	log.Printf("   %d개 작업자 종료 대기 중...", d.maxWorkers)
 	d.wg.Wait()
-	
+
 	log.Printf("   ✅ %d개 작업자 모두 종료됨", d.maxWorkers)
 }
@@ -3,6 +3,7 @@ package worker
 import (
 	"time"
 
+	"github.com/kakaovx/cursor-slack-server/internal/database"
 	"github.com/kakaovx/cursor-slack-server/internal/types"
 )
 
@@ -14,5 +15,19 @@ type Job struct {
 	ID          string                      // 로깅 및 추적을 위한 고유 ID (예: UUID)
 	Payload     types.SlackCommandPayload   // Slack 페이로드
 	ReceivedAt  time.Time                   // 요청 수신 시간 (큐 대기 시간 측정용)
+	ProjectName string                      // v1.5: @이름으로 지정한 프로젝트 (없으면 빈 문자열)
+	ProjectPath string                      // v1.5: 요청 시점에 결정된 프로젝트 경로
+	OpenPR      bool                        // v1.5: 완료 후 변경 사항으로 PR 생성
+	Agent       string                      // v1.5: 실행할 에이전트 백엔드 (빈 값이면 기본 백엔드)
+	Model       string                      // v1.5: 에이전트 모델 (--model, 빈 값이면 에이전트 기본 모델)
+	Timeout     time.Duration               // v1.5: 작업 시간 제한 (--timeout, 0이면 기본값)
+	ReadOnly    bool                        // v1.5: 파일을 수정하지 않고 실행 (--readonly)
+	Kind        database.JobKind            // v1.5: 작업 종류 (agent, revert)
+	ParentJobID string                      // v1.5: 원본 작업 ID (revert 대상, 재실행 원본)
+	RequireApproval bool                    // v1.5: 파일 수정 전 실행 계획 승인 필요
+	Approved        bool                    // v1.5: 실행 계획이 승인됨 (승인 후 다시 claim된 실행)
+	ThreadTS        string                  // v1.5: 결과를 게시할 Slack 스레드 (Payload.ChannelID의 메시지)
+	MessageTS       string                  // v1.5: 진행 상황을 갱신할 상태 메시지 (승인 후 재실행 시 재사용)
+	PromptMissing   bool                    // v1.5: 비밀 값을 가리기 전의 프롬프트가 메모리에 없음 (서버 재시작, 실행하지 않음)
 	Config      interface{}                 // 서버 설정 (*server.Config)
 }
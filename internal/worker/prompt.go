@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// projectNamePattern은 프로젝트 이름 규칙입니다 (영문 소문자/숫자로 시작, 최대 32자).
+var projectNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,31}$`)
+
+// modelNamePattern은 --model 값 규칙입니다 (허용 여부는 작업 등록 시 에이전트의 허용 목록으로 확인).
+var modelNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,63}$`)
+
+// minJobTimeout은 --timeout으로 지정할 수 있는 최소 시간입니다.
+const minJobTimeout = time.Minute
+
+// ValidateProjectName은 프로젝트 이름이 규칙에 맞는지 확인합니다.
+func ValidateProjectName(name string) error {
+	if !projectNamePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 프로젝트 이름입니다: `%s` (영문 소문자, 숫자, `-`, `_`, `.`만 사용, 최대 32자)", name)
+	}
+	return nil
+}
+
+// PromptSpec은 /cursor 명령어 텍스트를 해석한 결과입니다 (v1.5)
+type PromptSpec struct {
+	Project string // @이름으로 지정한 프로젝트 (없으면 빈 문자열)
+	Prompt  string // cursor-agent에 전달할 프롬프트
+	OpenPR  bool   // --pr: 작업 완료 후 변경 사항으로 PR 생성
+	Agent   string // --agent=<이름>: 실행할 에이전트 백엔드 (없으면 프로젝트 설정 또는 기본 백엔드)
+
+	// v1.5: 에이전트 실행 옵션
+	Model    string        // --model <이름>: 에이전트 모델 (관리자가 허용한 모델만)
+	Timeout  time.Duration // --timeout <시간>: 작업 시간 제한 (0이면 기본값)
+	ReadOnly bool          // --readonly: 파일을 수정하지 않고 실행 (PR 생성, 승인 절차 없음)
+}
+
+// ParsePrompt는 명령어 텍스트에서 프로젝트 지정, 옵션과 프롬프트를 분리합니다.
+//
+// 옵션은 따옴표 밖에 있는 `--이름`, `--이름=값` 또는 `--이름 값` 형태이며, 알 수 없는 옵션은 프롬프트에 그대로 남깁니다.
+//
+// 예시:
+//
+//	@backend "로그인 버그 수정" --pr  → Project: "backend", Prompt: "\"로그인 버그 수정\"", OpenPR: true
+//	"README 정리"                     → Project: "",        Prompt: "\"README 정리\""
+//	--agent=claude "테스트 추가"      → Project: "",        Prompt: "\"테스트 추가\"", Agent: "claude"
+//	--model gpt-5 --readonly "분석"   → Project: "",        Prompt: "\"분석\"", Model: "gpt-5", ReadOnly: true
+func ParsePrompt(text string) (PromptSpec, error) {
+	text = strings.TrimSpace(text)
+
+	var spec PromptSpec
+	if strings.HasPrefix(text, "@") {
+		name, rest, _ := strings.Cut(text[1:], " ")
+		name = strings.ToLower(name)
+		if err := ValidateProjectName(name); err != nil {
+			return PromptSpec{}, err
+		}
+		spec.Project = name
+		text = strings.TrimSpace(rest)
+	}
+
+	text, err := extractOptions(text, optionTakesValue, func(name, value string) (bool, error) {
+		return spec.applyOption(name, value)
+	})
+	if err != nil {
+		return PromptSpec{}, err
+	}
+	if err := spec.Validate(); err != nil {
+		return PromptSpec{}, err
+	}
+
+	if text == "" {
+		return PromptSpec{}, errors.New("프롬프트가 비어있습니다. 사용법: /cursor [@프로젝트] \"자연어 프롬프트\" [--pr]")
+	}
+	spec.Prompt = text
+	return spec, nil
+}
+
+// applyOption은 옵션 하나를 spec에 반영합니다. 알 수 없는 옵션이면 false를 반환합니다.
+func (spec *PromptSpec) applyOption(name string, value string) (bool, error) {
+	switch name {
+	case "pr":
+		if value != "" {
+			return true, fmt.Errorf("`--pr` 옵션은 값을 받지 않습니다")
+		}
+		spec.OpenPR = true
+	case "agent":
+		// 등록된 에이전트인지는 작업 등록 시 확인 (AGENTS_CONFIG)
+		value = strings.ToLower(value)
+		if err := ValidateProjectName(value); err != nil {
+			return true, fmt.Errorf("`--agent` 옵션에는 에이전트 이름이 필요합니다 (예: `--agent=cursor`)")
+		}
+		spec.Agent = value
+	case "model":
+		if !modelNamePattern.MatchString(value) {
+			return true, fmt.Errorf("`--model` 옵션에는 모델 이름이 필요합니다 (예: `--model gpt-5`)")
+		}
+		spec.Model = value
+	case "timeout":
+		timeout, err := ParseTimeout(value)
+		if err != nil {
+			return true, err
+		}
+		spec.Timeout = timeout
+	case "readonly", "read-only":
+		if value != "" {
+			return true, fmt.Errorf("`--readonly` 옵션은 값을 받지 않습니다")
+		}
+		spec.ReadOnly = true
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// optionTakesValue는 `--이름 값`처럼 다음 토큰을 값으로 받는 옵션인지 확인합니다.
+func optionTakesValue(name string) bool {
+	switch name {
+	case "agent", "model", "timeout":
+		return true
+	}
+	return false
+}
+
+// Validate는 함께 사용할 수 없는 옵션을 확인합니다 (v1.5)
+// API 요청처럼 ParsePrompt 이후 필드를 바꾼 경우에도 호출합니다.
+func (spec PromptSpec) Validate() error {
+	if spec.ReadOnly && spec.OpenPR {
+		return errors.New("`--readonly` 작업은 파일을 수정하지 않으므로 `--pr`과 함께 사용할 수 없습니다")
+	}
+	return nil
+}
+
+// ParseTimeout은 `--timeout` 값과 프로젝트 시간 제한(`30m`, `1h30m` 등 Go duration 형식)을 해석합니다 (v1.5)
+// 최대값은 작업 등록 시 서버 설정으로 확인합니다.
+func ParseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < minJobTimeout {
+		return 0, fmt.Errorf("시간 제한 값이 올바르지 않습니다: `%s` (1분 이상, 예: `30m`, `1h`)", value)
+	}
+	return timeout, nil
+}
+
+// extractOptions는 따옴표 밖에 있는 `--옵션` 토큰을 찾아 apply로 전달하고, 처리된 토큰을 제거한 텍스트를 반환합니다.
+// Slack이 자동 변환하는 “ ” 따옴표도 인식합니다.
+// takesValue가 true인 옵션에 `=값`이 없으면 다음 토큰을 값으로 사용합니다 (따옴표나 `--`로 시작하는 토큰 제외).
+func extractOptions(text string, takesValue func(name string) bool, apply func(name, value string) (bool, error)) (string, error) {
+	runes := []rune(text)
+	var out strings.Builder
+	var closing rune // 현재 열린 따옴표의 닫는 문자 (0이면 따옴표 밖)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if closing != 0 {
+			out.WriteRune(r)
+			if r == closing {
+				closing = 0
+			}
+			i++
+			continue
+		}
+
+		switch r {
+		case '"':
+			closing = '"'
+		case '“':
+			closing = '”'
+		}
+
+		atTokenStart := i == 0 || unicode.IsSpace(runes[i-1])
+		if atTokenStart && r == '-' && i+2 < len(runes) && runes[i+1] == '-' && unicode.IsLetter(runes[i+2]) {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) {
+				end++
+			}
+			name, value, hasValue := strings.Cut(string(runes[i+2:end]), "=")
+			name = strings.ToLower(name)
+			if !hasValue && takesValue(name) {
+				start := end
+				for start < len(runes) && unicode.IsSpace(runes[start]) {
+					start++
+				}
+				valueEnd := start
+				for valueEnd < len(runes) && !unicode.IsSpace(runes[valueEnd]) {
+					valueEnd++
+				}
+				next := string(runes[start:valueEnd])
+				if next != "" && runes[start] != '"' && runes[start] != '“' && !strings.HasPrefix(next, "--") {
+					value = next
+					end = valueEnd
+				}
+			}
+			handled, err := apply(name, value)
+			if err != nil {
+				return "", err
+			}
+			if handled {
+				i = end
+				continue
+			}
+		}
+
+		out.WriteRune(r)
+		i++
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
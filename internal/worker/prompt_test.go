@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateProjectName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"backend", false},
+		{"web-app_2.0", false},
+		{"0day", false},
+		{strings.Repeat("a", 32), false},
+		{strings.Repeat("a", 33), true},
+		{"", true},
+		{"Backend", true},
+		{"-backend", true},
+		{"back end", true},
+		{"프로젝트", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateProjectName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateProjectName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParsePrompt(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    PromptSpec
+		wantErr bool
+	}{
+		{text: `"README 정리"`, want: PromptSpec{Prompt: `"README 정리"`}},
+		{text: `  @backend "로그인 버그 수정"  `, want: PromptSpec{Project: "backend", Prompt: `"로그인 버그 수정"`}},
+		{text: `@Backend 테스트 추가`, want: PromptSpec{Project: "backend", Prompt: "테스트 추가"}},
+		{text: `이메일은 a@b.com으로`, want: PromptSpec{Prompt: "이메일은 a@b.com으로"}},
+		{text: `@backend`, wantErr: true},
+		{text: `@bad!name "수정"`, wantErr: true},
+		{text: ``, wantErr: true},
+		// --pr
+		{text: `@backend "로그인 버그 수정" --pr`, want: PromptSpec{Project: "backend", Prompt: `"로그인 버그 수정"`, OpenPR: true}},
+		{text: `--PR 수정`, want: PromptSpec{Prompt: "수정", OpenPR: true}},
+		{text: `"--pr 옵션 설명" 작성`, want: PromptSpec{Prompt: `"--pr 옵션 설명" 작성`}},
+		{text: `“--pr은 따옴표 안” 유지`, want: PromptSpec{Prompt: `“--pr은 따옴표 안” 유지`}},
+		{text: `수정 --unknown 유지`, want: PromptSpec{Prompt: "수정 --unknown 유지"}},
+		{text: `a--pr 유지`, want: PromptSpec{Prompt: "a--pr 유지"}},
+		{text: `수정 --pr=yes`, wantErr: true},
+		{text: `--pr`, wantErr: true},
+		// --agent
+		{text: `--agent=Claude "테스트 추가"`, want: PromptSpec{Prompt: `"테스트 추가"`, Agent: "claude"}},
+		{text: `--agent claude 테스트 추가`, want: PromptSpec{Prompt: "테스트 추가", Agent: "claude"}},
+		{text: `--agent "테스트 추가"`, wantErr: true},
+		{text: `--agent=!bad 수정`, wantErr: true},
+		// --model, --timeout, --readonly
+		{text: `--model gpt-5 --readonly "분석"`, want: PromptSpec{Prompt: `"분석"`, Model: "gpt-5", ReadOnly: true}},
+		{text: `--model=openai/gpt-5.1:high 분석`, want: PromptSpec{Prompt: "분석", Model: "openai/gpt-5.1:high"}},
+		{text: `--timeout 1h30m 마이그레이션`, want: PromptSpec{Prompt: "마이그레이션", Timeout: 90 * time.Minute}},
+		{text: `--read-only 분석`, want: PromptSpec{Prompt: "분석", ReadOnly: true}},
+		{text: `--model --readonly 분석`, wantErr: true},
+		{text: `--timeout 30s 분석`, wantErr: true},
+		{text: `--timeout soon 분석`, wantErr: true},
+		{text: `--readonly=true 분석`, wantErr: true},
+		{text: `--readonly --pr 분석`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePrompt(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePrompt(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParsePrompt(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExtractOptions(t *testing.T) {
+	takesValue := func(name string) bool { return name == "agent" }
+	tests := []struct {
+		text     string
+		want     string
+		wantOpts []string
+	}{
+		{"수정 --pr", "수정", []string{"pr="}},
+		{"--pr --Name=x 수정", "수정", []string{"pr=", "name=x"}},
+		{"--agent x 수정", "수정", []string{"agent=x"}},
+		{"--agent --pr 수정", "수정", []string{"agent=", "pr="}},
+		{`--agent "x" 수정`, `"x" 수정`, []string{"agent="}},
+		{`"a --pr" b`, `"a --pr" b`, nil},
+		{`"열린 따옴표 --pr`, `"열린 따옴표 --pr`, nil},
+		{"--skip 수정", "--skip 수정", []string{"skip="}},
+		{"-- 수정 --1", "-- 수정 --1", nil},
+	}
+	for _, tt := range tests {
+		var opts []string
+		got, err := extractOptions(tt.text, takesValue, func(name, value string) (bool, error) {
+			opts = append(opts, name+"="+value)
+			return name != "skip", nil
+		})
+		if err != nil {
+			t.Errorf("extractOptions(%q) error = %v", tt.text, err)
+			continue
+		}
+		if got != tt.want || strings.Join(opts, ",") != strings.Join(tt.wantOpts, ",") {
+			t.Errorf("extractOptions(%q) = %q %v, want %q %v", tt.text, got, opts, tt.want, tt.wantOpts)
+		}
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30m", 30 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"1m", time.Minute, false},
+		{"59s", 0, true},
+		{"-5m", 0, true},
+		{"30", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeout(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTimeout(%q) = %v, %v; want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
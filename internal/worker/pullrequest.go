@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/forge"
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+)
+
+// errNoChanges는 PR로 만들 변경 사항이 없음을 나타냅니다.
+var errNoChanges = errors.New("변경 사항이 없어 PR을 만들지 않았습니다")
+
+// PullRequestConfig는 작업 완료 후 PR 생성 설정입니다 (v1.5)
+//
+// --pr 옵션이나 프로젝트의 auto_pr 설정이 켜진 작업은 성공 후 변경 사항을
+// `cursor/job-<ID>` 브랜치로 커밋/push하고 Forge 클라이언트로 PR을 생성합니다.
+type PullRequestConfig struct {
+	Forge      forge.Client // nil이면 PR 생성 비활성 (FORGE_TYPE, FORGE_TOKEN)
+	Remote     string       // push할 원격 저장소 (PR_REMOTE, 기본값: origin)
+	BaseBranch string       // PR 대상 브랜치 (PR_BASE_BRANCH, 비어 있으면 프로젝트의 현재 브랜치)
+	Repo       string       // 저장소 경로 (FORGE_REPO, 비어 있으면 remote URL에서 추출)
+}
+
+// prTimeout은 push와 PR 생성 API 호출에 허용하는 최대 시간입니다.
+const prTimeout = 2 * time.Minute
+
+// open은 작업 변경 사항을 커밋하여 push하고 PR을 생성합니다.
+// 작업 전부터 있던 미커밋 변경 사항은 제외하고, 작업이 변경한 파일만 BaseCommit 위에 커밋합니다.
+func (c PullRequestConfig) open(jobID string, projectPath string, prompt string, changes *jobChanges) (*forge.PullRequest, string, error) {
+	if c.Forge == nil {
+		return nil, "", errors.New("PR 생성이 설정되지 않았습니다 (FORGE_TYPE, FORGE_TOKEN 환경 변수 확인)")
+	}
+	if changes == nil || changes.BaseCommit == "" {
+		return nil, "", errors.New("커밋이 있는 git 저장소에서만 PR을 만들 수 있습니다")
+	}
+	if len(changes.Files) == 0 {
+		return nil, "", errNoChanges
+	}
+
+	remote := c.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	base := c.BaseBranch
+	if base == "" {
+		current, err := git.CurrentBranch(projectPath)
+		if err != nil {
+			return nil, "", err
+		}
+		base = current
+	}
+
+	repo := c.Repo
+	if repo == "" {
+		remoteURL, err := git.RemoteURL(projectPath, remote)
+		if err != nil {
+			return nil, "", err
+		}
+		if repo, err = forge.ParseRepo(remoteURL); err != nil {
+			return nil, "", err
+		}
+	}
+
+	files := make([]git.FileChange, 0, len(changes.Files))
+	for _, f := range changes.Files {
+		files = append(files, git.FileChange{Status: f.Status, Path: f.Path, OldPath: f.OldPath})
+	}
+
+	title := pullRequestTitle(prompt)
+	commit, err := git.CommitChanges(projectPath, changes.BaseCommit, changes.After, files,
+		fmt.Sprintf("%s\n\nJob: %s", title, jobID))
+	if err != nil {
+		return nil, "", fmt.Errorf("변경 사항 커밋 실패: %w", err)
+	}
+
+	branch := jobBranchName(jobID)
+	if err := git.Push(projectPath, remote, commit, branch); err != nil {
+		return nil, "", fmt.Errorf("브랜치 push 실패: %w", err)
+	}
+	log.Printf("[%s] %s/%s 브랜치로 push 완료 (%s)", jobID, remote, branch, commit[:8])
+
+	ctx, cancel := context.WithTimeout(context.Background(), prTimeout)
+	defer cancel()
+
+	pr, err := c.Forge.CreatePullRequest(ctx, forge.PullRequestInput{
+		Repo:  repo,
+		Title: title,
+		Body:  pullRequestBody(jobID, prompt, changes),
+		Head:  branch,
+		Base:  base,
+	})
+	if err != nil {
+		return nil, branch, fmt.Errorf("PR 생성 실패: %w", err)
+	}
+	return pr, branch, nil
+}
+
+// pullRequestTitle은 프롬프트 첫 줄로 PR 제목을 만듭니다.
+func pullRequestTitle(prompt string) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(prompt), "\n")
+	title = strings.Trim(title, "\"“” ")
+	if runes := []rune(title); len(runes) > 72 {
+		title = string(runes[:69]) + "..."
+	}
+	return "cursor: " + title
+}
+
+// pullRequestBody는 PR 본문을 만듭니다.
+func pullRequestBody(jobID string, prompt string, changes *jobChanges) string {
+	var body strings.Builder
+	body.WriteString("Cursor Agent 작업으로 생성된 PR입니다.\n\n")
+	body.WriteString("### 요청 프롬프트\n\n")
+	for _, line := range strings.Split(strings.TrimSpace(prompt), "\n") {
+		body.WriteString("> " + line + "\n")
+	}
+	body.WriteString("\n### 변경된 파일\n\n")
+	for _, f := range changes.Files {
+		body.WriteString(fmt.Sprintf("- `%s` (%s)\n", f.Path, changeStatusText(f.Status)))
+	}
+	body.WriteString(fmt.Sprintf("\nJob ID: `%s`\n", jobID))
+	return body.String()
+}
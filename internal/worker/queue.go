@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/redact"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// QueueStore는 영속 작업 큐가 사용하는 저장소 인터페이스입니다 (*database.DB가 구현).
+type QueueStore interface {
+	CreateJob(job *database.JobRecord) error
+	ClaimNextJob() (*database.JobRecord, error)
+	ReleaseJob(jobID string) error
+	GetJob(jobID string) (*database.JobRecord, error)
+}
+
+// Queue는 job_records 테이블을 기반으로 하는 영속 작업 큐입니다 (v1.5)
+//
+// 작업은 pending 상태의 행으로 저장되므로 서버가 재시작되어도 사라지지 않습니다.
+// Dispatcher는 유휴 작업자가 생기면 ClaimNextJob으로 가장 오래된 작업을 트랜잭션으로 가져갑니다.
+//
+// v1.5: job_records.prompt에는 비밀 값을 가린 프롬프트를 저장하고, 가리기 전의 원문은
+// 작업이 끝날 때까지 메모리(prompts)에만 보관하여 에이전트에 전달합니다.
+// 가린 값이 있는 작업은 prompt_redacted로 표시하며, 서버가 재시작되어 원문이 없으면
+// 가린 프롬프트로 실행하지 않고 실패 처리합니다 (ErrPromptUnavailable, 다시 실행/승인도 거부).
+type Queue struct {
+	Redactor *redact.Redactor // v1.5: 저장할 프롬프트의 비밀 값 가리기 (nil이면 가리지 않음)
+
+	store   QueueStore
+	notify  chan struct{} // 새 작업 등록 알림 (Dispatcher의 폴링 대기를 깨움)
+	mu      sync.Mutex
+	prompts map[string]string // v1.5: 비밀 값을 가리기 전의 프롬프트 (작업 ID별, 가린 값이 있는 작업만)
+}
+
+// ErrPromptUnavailable은 비밀 값을 가리기 전의 프롬프트 원문이 없어 작업을 실행할 수 없을 때 반환됩니다 (v1.5)
+var ErrPromptUnavailable = errors.New("비밀 값을 가리기 전의 프롬프트가 서버에 남아 있지 않아 실행할 수 없습니다. 프롬프트를 다시 입력해 새로 요청해주세요")
+
+// NewQueue는 영속 작업 큐를 생성합니다.
+func NewQueue(store QueueStore) *Queue {
+	return &Queue{
+		store:   store,
+		notify:  make(chan struct{}, 1),
+		prompts: make(map[string]string),
+	}
+}
+
+// Enqueue는 작업을 pending 상태로 저장하고 Dispatcher에 알립니다.
+// v1.5: job.Prompt는 비밀 값을 가린 프롬프트로 바뀌며(Slack 메시지와 감사 로그에도 그대로 사용), 원문은 메모리에만 남깁니다.
+func (q *Queue) Enqueue(job *database.JobRecord) error {
+	job.Status = database.JobStatusPending
+	raw := job.Prompt
+	job.Prompt = q.Redactor.String(raw)
+	job.PromptRedacted = job.Prompt != raw
+	if err := q.store.CreateJob(job); err != nil {
+		job.Prompt = raw
+		job.PromptRedacted = false
+		return err
+	}
+	if job.PromptRedacted {
+		q.mu.Lock()
+		q.prompts[job.ID] = raw
+		q.mu.Unlock()
+	}
+
+	q.Notify()
+
+	log.Printf("[%s] 작업이 큐에 등록되었습니다.", job.ID)
+	return nil
+}
+
+// Notify는 Dispatcher에 대기 중인 작업이 생겼음을 알립니다.
+// 승인된 작업처럼 Enqueue를 거치지 않고 pending으로 돌아온 작업에 사용합니다 (v1.5)
+func (q *Queue) Notify() {
+	// 이미 알림이 대기 중이면 추가로 보낼 필요 없음
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// claim은 다음 대기 중인 작업을 가져옵니다. 없으면 nil을 반환합니다.
+func (q *Queue) claim() (*database.JobRecord, error) {
+	return q.store.ClaimNextJob()
+}
+
+// release는 claim한 작업을 다시 대기 상태로 되돌립니다.
+func (q *Queue) release(jobID string) error {
+	return q.store.ReleaseJob(jobID)
+}
+
+// prompt는 에이전트에 전달할 프롬프트를 반환합니다 (v1.5)
+// 메모리에 원문이 있으면 원문을, 없으면 저장된(가린) 프롬프트를 반환합니다.
+func (q *Queue) prompt(rec *database.JobRecord) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if raw, ok := q.prompts[rec.ID]; ok {
+		return raw
+	}
+	return rec.Prompt
+}
+
+// HasPrompt는 작업을 실행할 프롬프트가 있는지 반환합니다 (v1.5)
+// 비밀 값을 가린 작업(PromptRedacted)은 원문이 메모리에 남아 있을 때만 true입니다.
+func (q *Queue) HasPrompt(rec *database.JobRecord) bool {
+	if !rec.PromptRedacted {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.prompts[rec.ID]
+	return ok
+}
+
+// forgetFinished는 다시 실행되지 않을 작업의 프롬프트 원문을 메모리에서 지웁니다 (v1.5)
+// 작업자가 작업을 마칠 때마다 호출되며, 대기 중이거나 승인 대기 중인 작업(승인 후 다시 실행)의 원문은 남깁니다.
+// 실행되지 않고 취소/거절된 작업도 여기서 함께 정리됩니다.
+func (q *Queue) forgetFinished() {
+	q.mu.Lock()
+	ids := make([]string, 0, len(q.prompts))
+	for id := range q.prompts {
+		ids = append(ids, id)
+	}
+	q.mu.Unlock()
+
+	for _, id := range ids {
+		rec, err := q.store.GetJob(id)
+		if err != nil {
+			log.Printf("[%s] 작업 상태 조회 실패: %v", id, err)
+			continue
+		}
+		if rec != nil && (rec.Status == database.JobStatusPending || rec.Status == database.JobStatusRunning ||
+			rec.Status == database.JobStatusAwaitingApproval) {
+			continue
+		}
+		q.mu.Lock()
+		delete(q.prompts, id)
+		q.mu.Unlock()
+	}
+}
+
+// jobFromRecord는 DB 작업 레코드를 Worker가 실행할 Job으로 변환합니다.
+// v1.5: 프롬프트는 저장된(가린) 값 대신 q.prompt의 원문을 사용하고, 원문이 없으면 PromptMissing을 표시합니다.
+func (q *Queue) jobFromRecord(rec *database.JobRecord, config *ConfigFull) Job {
+	return Job{
+		ID: rec.ID,
+		Payload: types.SlackCommandPayload{
+			Text:         q.prompt(rec),
+			UserName:     rec.UserName,
+			UserID:       rec.UserID,
+			ResponseURL:  rec.ResponseURL,
+			ChannelID:    rec.ChannelID,
+			ChannelName:  rec.ChannelName,
+			TeamID:       rec.TeamID,
+			EnterpriseID: rec.EnterpriseID,
+		},
+		ReceivedAt:      rec.CreatedAt,
+		ProjectName:     rec.ProjectName,
+		ProjectPath:     rec.ProjectPath,
+		OpenPR:          rec.OpenPR,
+		Agent:           rec.Agent,
+		Model:           rec.Model,
+		Timeout:         time.Duration(rec.TimeoutSeconds) * time.Second,
+		ReadOnly:        rec.ReadOnly,
+		Kind:            rec.Kind,
+		ParentJobID:     rec.ParentJobID,
+		RequireApproval: rec.RequireApproval,
+		Approved:        rec.ReviewedBy != "",
+		ThreadTS:        rec.ThreadTS,
+		MessageTS:       rec.MessageTS,
+		PromptMissing:   !q.HasPrompt(rec),
+		Config:          config,
+	}
+}
@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
+	"github.com/kakaovx/cursor-slack-server/internal/types"
+)
+
+// errNoSlackClient는 스레드에 게시해야 하지만 봇 토큰이 설정되지 않았음을 나타냅니다.
+var errNoSlackClient = errors.New("SLACK_BOT_TOKEN이 설정되지 않아 스레드에 메시지를 보낼 수 없습니다")
+
+// slackPostTimeout은 Web API로 메시지 하나를 게시할 때의 최대 대기 시간입니다.
+const slackPostTimeout = 30 * time.Second
+
+const (
+	maxResponseURLMessages = 5  // response_url 최대 호출 횟수 (30분 안에 5회)
+	maxThreadMessages      = 20 // 스레드에 결과를 나눠 게시할 최대 메시지 수
+)
+
+// replyTarget은 작업 메시지를 보낼 위치입니다 (v1.5)
+//
+// 스레드가 있으면 봇 토큰으로 그 스레드에 chat.postMessage로 게시하고, 없으면 response_url로 보냅니다.
+// 멘션/DM(Events API)으로 요청된 작업은 요청이 들어온 스레드를 사용하고,
+// 봇 토큰이 설정되어 있으면 슬래시 명령어로 요청된 작업도 실행 시작 시 게시한 상태 메시지의 스레드를 사용합니다.
+type replyTarget struct {
+	ResponseURL string
+	Channel     string
+	ThreadTS    string
+	MessageTS   string // 진행 상황을 chat.update로 갱신하는 상태 메시지 (없으면 진행 메시지를 새로 보냄)
+}
+
+// replyTo는 작업의 메시지 전송 위치를 반환합니다.
+func replyTo(job Job) replyTarget {
+	return replyTarget{
+		ResponseURL: job.Payload.ResponseURL,
+		Channel:     job.Payload.ChannelID,
+		ThreadTS:    job.ThreadTS,
+		MessageTS:   job.MessageTS,
+	}
+}
+
+// ok는 메시지를 보낼 곳이 있는지 반환합니다 (API 요청은 결과를 DB로만 조회).
+func (r replyTarget) ok() bool {
+	return r.ResponseURL != "" || r.ThreadTS != ""
+}
+
+// maxMessages는 결과를 나눠 보낼 수 있는 최대 메시지 수입니다.
+func (r replyTarget) maxMessages() int {
+	if r.ThreadTS != "" {
+		return maxThreadMessages
+	}
+	return maxResponseURLMessages
+}
+
+// postReply는 메시지를 스레드 또는 response_url로 전송합니다.
+// 스레드에는 채널 공개 메시지만 게시할 수 있으므로 ResponseType은 무시됩니다.
+func (te *TaskExecutor) postReply(r replyTarget, payload types.SlackDelayedResponse) error {
+	if r.ThreadTS == "" {
+		return te.PostDelayedResponse(r.ResponseURL, payload)
+	}
+	if te.Slack == nil {
+		return errNoSlackClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), slackPostTimeout)
+	defer cancel()
+	_, err := te.Slack.PostMessage(ctx, slack.Message{
+		Channel:  r.Channel,
+		Text:     payload.Text,
+		Blocks:   payload.Blocks,
+		ThreadTS: r.ThreadTS,
+	})
+	return err
+}
+
+// startReply는 작업 실행을 시작할 때 메시지를 보낼 위치를 정합니다 (v1.5)
+//
+// 봇 토큰이 있고 요청한 채널을 알면 상태 메시지(text, 취소 버튼)를 게시하고, 이후 진행 상황은
+// 상태 메시지를 chat.update로 갱신하며 결과는 그 스레드에 게시합니다. 멘션/DM 요청은 요청한 스레드 안에 게시합니다.
+// 승인 후 다시 실행되는 작업처럼 이미 상태 메시지가 있으면 새로 게시하지 않고 갱신합니다.
+// 게시에 실패하면(봇이 채널에 없는 경우 등) response_url로 보냅니다.
+func (te *TaskExecutor) startReply(cfg *ConfigFull, job Job, text string) replyTarget {
+	reply := replyTo(job)
+	if te.Slack == nil || reply.Channel == "" {
+		return reply
+	}
+	if reply.MessageTS != "" {
+		err := te.updateStatus(reply, job.ID, text, ActionCancelJob)
+		if err == nil {
+			return reply
+		}
+		log.Printf("[%s] 상태 메시지 갱신 실패, 새로 게시합니다: %v", job.ID, err)
+		reply.MessageTS = ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), slackPostTimeout)
+	defer cancel()
+	posted, err := te.Slack.PostMessage(ctx, slack.Message{
+		Channel:  reply.Channel,
+		Text:     text,
+		Blocks:   MessageBlocks(text, job.ID, ActionCancelJob),
+		ThreadTS: reply.ThreadTS,
+	})
+	if err != nil {
+		log.Printf("[%s] 상태 메시지 게시 실패, response_url로 전송합니다: %v", job.ID, err)
+		return reply
+	}
+
+	reply.MessageTS = posted.TS
+	if reply.ThreadTS == "" {
+		reply.ThreadTS = posted.TS
+	}
+	if err := cfg.DB.UpdateJobThread(job.ID, reply.ThreadTS, reply.MessageTS); err != nil {
+		log.Printf("[%s] 스레드 기록 실패: %v", job.ID, err)
+	}
+	return reply
+}
+
+// updateStatus는 상태 메시지를 text와 버튼으로 바꿉니다. 상태 메시지가 없으면 아무것도 하지 않습니다.
+func (te *TaskExecutor) updateStatus(reply replyTarget, jobID string, text string, actions ...string) error {
+	if reply.MessageTS == "" || te.Slack == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), slackPostTimeout)
+	defer cancel()
+	return te.Slack.UpdateMessage(ctx, reply.Channel, reply.MessageTS, text, MessageBlocks(text, jobID, actions...))
+}
+
+// finishStatus는 작업이 끝났을 때 상태 메시지를 최종 상태로 갱신합니다.
+func (te *TaskExecutor) finishStatus(reply replyTarget, jobID string, text string, actions ...string) {
+	if err := te.updateStatus(reply, jobID, text, actions...); err != nil {
+		log.Printf("[%s] 상태 메시지 갱신 실패: %v", jobID, err)
+	}
+}
+
+// statusText는 상태 메시지 본문입니다: 요청자, 대상 프로젝트, 프롬프트를 함께 표시합니다.
+func statusText(headline string, job Job, prompt string) string {
+	return fmt.Sprintf("%s (ID: `%s`)\n*요청자:* <@%s> · *대상:* `%s`\n> %s",
+		headline, shortID(job.ID), job.Payload.UserID, approvalTarget(job), truncateRunes(prompt, 500))
+}
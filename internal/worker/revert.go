@@ -0,0 +1,229 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+)
+
+// CheckRevertable은 작업을 되돌릴 수 있는 상태인지 확인합니다 (v1.5)
+// 요청 접수 시점의 사전 검사이며, 파일 충돌 여부는 실행 시점에 다시 확인합니다.
+func CheckRevertable(target *database.JobRecord) error {
+	switch target.Status {
+	case database.JobStatusPending, database.JobStatusRunning:
+		return errors.New("대기 중이거나 실행 중인 작업은 되돌릴 수 없습니다")
+	}
+	if target.RevertedBy != "" {
+		return fmt.Errorf("이미 되돌린 작업입니다 (revert 작업: %s)", shortID(target.RevertedBy))
+	}
+	if target.CheckpointBefore == "" || target.CheckpointAfter == "" {
+		return errors.New("실행 전후 스냅샷이 없는 작업입니다 (git 저장소가 아니거나 이전 버전에서 실행된 작업)")
+	}
+	if len(target.ChangedFiles) == 0 {
+		return errors.New("되돌릴 변경 사항이 없는 작업입니다")
+	}
+	return nil
+}
+
+// revertDir은 대상 작업이 파일을 변경한 디렉토리를 반환합니다.
+// worktree에서 실행된 작업은 해당 worktree가 남아 있어야 되돌릴 수 있습니다.
+func revertDir(target *database.JobRecord) (string, error) {
+	if target.WorktreePath == "" {
+		return target.ProjectPath, nil
+	}
+	if _, err := os.Stat(target.WorktreePath); err != nil {
+		return "", fmt.Errorf("작업이 실행된 worktree가 정리되어 되돌릴 수 없습니다: %s", target.WorktreePath)
+	}
+	return target.WorktreePath, nil
+}
+
+// changedPaths는 변경 파일 목록의 모든 경로(이름 변경 전 경로 포함)를 반환합니다.
+func changedPaths(files []database.ChangedFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		if f.OldPath != "" {
+			paths = append(paths, f.OldPath)
+		}
+	}
+	return paths
+}
+
+// checkLaterJobs는 대상 작업 이후 같은 디렉토리에서 실행된 작업이 같은 파일을 변경했는지 확인합니다.
+func checkLaterJobs(db DBInterface, target *database.JobRecord, revertID string) error {
+	if target.StartedAt == nil {
+		return nil
+	}
+	later, err := db.ListJobsStartedAfter(target.ProjectPath, target.WorktreePath, *target.StartedAt, revertID)
+	if err != nil {
+		return fmt.Errorf("이후 작업 조회 실패: %w", err)
+	}
+
+	touched := make(map[string]bool)
+	for _, p := range changedPaths(target.ChangedFiles) {
+		touched[p] = true
+	}
+
+	var conflicts []string
+	for _, job := range later {
+		if job.Status == database.JobStatusRunning {
+			return fmt.Errorf("같은 프로젝트에서 실행 중인 작업이 있습니다 (ID: %s). 완료 후 다시 시도해주세요", shortID(job.ID))
+		}
+		for _, p := range changedPaths(job.ChangedFiles) {
+			if touched[p] {
+				conflicts = append(conflicts, fmt.Sprintf("%s (%s)", shortID(job.ID), p))
+				break
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("이후 작업이 같은 파일을 변경하여 되돌릴 수 없습니다: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// restoreFiles는 변경 파일을 실행 전 스냅샷(before) 상태로 되돌립니다.
+// 작업이 추가한 파일은 삭제하고, 수정/삭제한 파일은 before 내용으로 복원합니다.
+// 변경 파일 경로는 저장소 최상위 기준이므로 root는 git.TopLevel로 구한 경로여야 합니다.
+func restoreFiles(root string, before string, files []database.ChangedFile) error {
+	var restore []string
+	var remove []string
+	for _, f := range files {
+		switch f.Status {
+		case "A":
+			remove = append(remove, f.Path)
+		case "R":
+			remove = append(remove, f.Path)
+			restore = append(restore, f.OldPath)
+		default:
+			restore = append(restore, f.Path)
+		}
+	}
+
+	for _, p := range remove {
+		if err := os.Remove(filepath.Join(root, p)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("파일 삭제 실패 (%s): %w", p, err)
+		}
+	}
+	if err := git.RestorePaths(root, before, restore); err != nil {
+		return fmt.Errorf("파일 복원 실패: %w", err)
+	}
+	return nil
+}
+
+// runRevert는 대상 작업의 변경 사항을 실행 전 스냅샷으로 되돌립니다 (v1.5)
+//
+// 되돌리기 자체도 하나의 작업으로 기록되며, 실행 전후 스냅샷과 diff가 일반 작업과 같이 저장됩니다.
+// 다음 경우에는 파일을 건드리지 않고 실패 처리합니다.
+//   - 이후 같은 디렉토리에서 실행된 작업이 같은 파일을 변경한 경우
+//   - 작업 완료 후 해당 파일이 직접 수정된 경우
+func (te *TaskExecutor) runRevert(cfg *ConfigFull, job Job) {
+	jobID := job.ID
+	reply := replyTo(job)
+
+	fail := func(err error) {
+		errMsg := "❌ " + err.Error()
+		log.Printf("[%s] 되돌리기 실패: %v", jobID, err)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, fmt.Sprintf("❌ *되돌리기 실패* (ID: `%s`)\n> %s", shortID(jobID), err.Error()))
+		}
+	}
+
+	if rec, err := cfg.DB.GetJob(jobID); err == nil && rec.Status == database.JobStatusCancelled {
+		log.Printf("[%s] 실행 직전에 취소된 작업입니다. 실행하지 않습니다.", jobID)
+		return
+	}
+
+	target, err := cfg.DB.GetJob(job.ParentJobID)
+	if err != nil {
+		fail(fmt.Errorf("되돌릴 작업을 찾을 수 없습니다: %s", shortID(job.ParentJobID)))
+		return
+	}
+	if err := CheckRevertable(target); err != nil {
+		fail(err)
+		return
+	}
+	dir, err := revertDir(target)
+	if err != nil {
+		fail(err)
+		return
+	}
+	// 프로젝트 체크아웃을 되돌리는 동안 같은 저장소의 다른 작업은 실행하지 않음 (worktree에서 실행된 작업은 제외)
+	if target.WorktreePath == "" {
+		defer lockRepo(jobID, dir)()
+	}
+	if err := cfg.DB.UpdateJobProjectPath(jobID, target.ProjectPath); err != nil {
+		log.Printf("[%s] 프로젝트 경로 기록 실패: %v", jobID, err)
+	}
+	if target.WorktreePath != "" {
+		if err := cfg.DB.UpdateJobWorktree(jobID, target.WorktreePath, target.Branch); err != nil {
+			log.Printf("[%s] worktree 기록 실패: %v", jobID, err)
+		}
+	}
+
+	if err := checkLaterJobs(cfg.DB, target, jobID); err != nil {
+		fail(err)
+		return
+	}
+
+	// 현재 상태 스냅샷 (되돌리기 작업의 before)
+	changes := beginChanges(jobID, dir)
+	if changes == nil {
+		fail(fmt.Errorf("현재 상태 스냅샷을 만들 수 없습니다: %s", dir))
+		return
+	}
+
+	// 변경 파일 경로는 저장소 최상위 기준 (프로젝트가 저장소의 하위 디렉토리로 등록된 경우 포함)
+	root, err := git.TopLevel(dir)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	// 작업 완료 후 직접 수정된 파일이 있으면 덮어쓰지 않음
+	modified, err := git.ChangedPaths(root, target.CheckpointAfter, changes.Before, changedPaths(target.ChangedFiles))
+	if err != nil {
+		fail(fmt.Errorf("현재 상태 비교 실패: %w", err))
+		return
+	}
+	if len(modified) > 0 {
+		fail(fmt.Errorf("작업 완료 후 변경된 파일이 있어 되돌릴 수 없습니다: %s", strings.Join(modified, ", ")))
+		return
+	}
+
+	log.Printf("[%s] 작업 %s 되돌리기 시작: %d개 파일", jobID, target.ID, len(target.ChangedFiles))
+	if err := restoreFiles(root, target.CheckpointBefore, target.ChangedFiles); err != nil {
+		fail(err)
+		return
+	}
+
+	if err := changes.finish(jobID, dir, cfg.DB, te.Redactor); err != nil {
+		log.Printf("[%s] 변경 사항 기록 실패: %v", jobID, err)
+	}
+	recordRedactions(cfg, jobID, changes.Redactions)
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("작업 %s의 변경 사항을 되돌렸습니다.\n", shortID(target.ID)))
+	for _, f := range target.ChangedFiles {
+		summary.WriteString(fmt.Sprintf("- %s: %s\n", changeStatusText(f.Status), f.Path))
+	}
+
+	cfg.DB.UpdateJobResult(jobID, summary.String(), "")
+	cfg.DB.UpdateJobStatus(jobID, database.JobStatusCompleted)
+	if err := cfg.DB.MarkJobReverted(target.ID, jobID); err != nil {
+		log.Printf("[%s] 되돌리기 기록 실패: %v", jobID, err)
+	}
+	log.Printf("[%s] 작업 %s 되돌리기 완료", jobID, target.ID)
+
+	if reply.ok() {
+		te.sendDelayedResponse(reply, fmt.Sprintf("↩️ *되돌리기 완료* (ID: `%s`)\n```\n%s```", shortID(jobID), summary.String()))
+	}
+}
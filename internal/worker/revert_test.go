@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+)
+
+// newRevertConfig는 실제 DB를 사용하는 되돌리기 테스트용 설정을 만듭니다.
+func newRevertConfig(t *testing.T) *ConfigFull {
+	t.Helper()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &ConfigFull{DB: db}
+}
+
+// runTestJob은 dir에서 edit로 파일을 변경하는 작업을 실행한 것처럼 실행 전후 스냅샷을 기록합니다.
+func runTestJob(t *testing.T, cfg *ConfigFull, dir string, edit func()) *database.JobRecord {
+	t.Helper()
+	id := uuid.NewString()
+	if err := cfg.DB.CreateJob(&database.JobRecord{ID: id, Prompt: "수정해줘", UserID: "U1", ChannelID: "C1", ProjectPath: dir, Status: database.JobStatusPending, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	cfg.DB.UpdateJobStatus(id, database.JobStatusRunning)
+	changes := beginChanges(id, dir)
+	if changes == nil {
+		t.Fatal("beginChanges = nil")
+	}
+	edit()
+	if err := changes.finish(id, dir, cfg.DB, nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	cfg.DB.UpdateJobStatus(id, database.JobStatusCompleted)
+	rec, err := cfg.DB.GetJob(id)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	return rec
+}
+
+// revertTestJob은 target을 되돌리는 작업을 실행하고 그 결과를 반환합니다.
+func revertTestJob(t *testing.T, cfg *ConfigFull, target *database.JobRecord) *database.JobRecord {
+	t.Helper()
+	id := uuid.NewString()
+	if err := cfg.DB.CreateJob(&database.JobRecord{ID: id, Prompt: "revert", UserID: "U1", ChannelID: "C1", Kind: database.JobKindRevert, ParentJobID: target.ID, Status: database.JobStatusPending, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	cfg.DB.UpdateJobStatus(id, database.JobStatusRunning)
+	(&TaskExecutor{}).runRevert(cfg, Job{ID: id, Kind: database.JobKindRevert, ParentJobID: target.ID})
+	rec, err := cfg.DB.GetJob(id)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	return rec
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRunRevert(t *testing.T) {
+	for _, sub := range []string{"", "sub"} { // 저장소 최상위, 하위 디렉토리로 등록된 프로젝트
+		t.Run("dir="+sub, func(t *testing.T) {
+			cfg := newRevertConfig(t)
+			dir := filepath.Join(newTestRepo(t), sub)
+			writeTestFile(t, filepath.Join(dir, "keep.txt"), "keep\n")
+			writeTestFile(t, filepath.Join(dir, "old.txt"), "old\n")
+			writeTestFile(t, filepath.Join(dir, "move.txt"), "이름을 바꿀 파일입니다\n")
+
+			target := runTestJob(t, cfg, dir, func() {
+				writeTestFile(t, filepath.Join(dir, "new.txt"), "new\n")
+				writeTestFile(t, filepath.Join(dir, "keep.txt"), "changed\n")
+				os.Remove(filepath.Join(dir, "old.txt"))
+				os.Rename(filepath.Join(dir, "move.txt"), filepath.Join(dir, "moved.txt"))
+			})
+			statuses := make(map[string]bool)
+			for _, f := range target.ChangedFiles {
+				statuses[f.Status] = true
+			}
+			if !statuses["A"] || !statuses["M"] || !statuses["D"] || !statuses["R"] {
+				t.Fatalf("ChangedFiles = %+v, want 추가/수정/삭제/이름 변경", target.ChangedFiles)
+			}
+
+			revert := revertTestJob(t, cfg, target)
+			if revert.Status != database.JobStatusCompleted {
+				t.Fatalf("revert status = %s (%s), want completed", revert.Status, revert.Error)
+			}
+			for name, want := range map[string]string{"keep.txt": "keep\n", "old.txt": "old\n", "move.txt": "이름을 바꿀 파일입니다\n"} {
+				if got := readTestFile(t, filepath.Join(dir, name)); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+			for _, name := range []string{"new.txt", "moved.txt"} {
+				if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+					t.Errorf("%s가 남아 있습니다: %v", name, err)
+				}
+			}
+			if rec, _ := cfg.DB.GetJob(target.ID); rec.RevertedBy != revert.ID {
+				t.Errorf("RevertedBy = %q, want %q", rec.RevertedBy, revert.ID)
+			}
+		})
+	}
+}
+
+func TestRunRevertRefusesConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		after   func(t *testing.T, cfg *ConfigFull, dir string) // 대상 작업 이후의 변경
+		want    string                                          // 남아 있어야 하는 README.md 내용
+		wantErr string
+	}{
+		{
+			name: "later job",
+			after: func(t *testing.T, cfg *ConfigFull, dir string) {
+				runTestJob(t, cfg, dir, func() { writeTestFile(t, filepath.Join(dir, "README.md"), "# later job\n") })
+			},
+			want:    "# later job\n",
+			wantErr: "이후 작업이 같은 파일을 변경",
+		},
+		{
+			name: "edited after job",
+			after: func(t *testing.T, cfg *ConfigFull, dir string) {
+				writeTestFile(t, filepath.Join(dir, "README.md"), "# edited\n")
+			},
+			want:    "# edited\n",
+			wantErr: "작업 완료 후 변경된 파일",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newRevertConfig(t)
+			dir := filepath.Join(newTestRepo(t), "sub")
+			target := runTestJob(t, cfg, dir, func() { writeTestFile(t, filepath.Join(dir, "README.md"), "# target job\n") })
+			tt.after(t, cfg, dir)
+
+			revert := revertTestJob(t, cfg, target)
+			if revert.Status != database.JobStatusFailed || !strings.Contains(revert.Error, tt.wantErr) {
+				t.Errorf("revert = %s (%s), want failed %q", revert.Status, revert.Error, tt.wantErr)
+			}
+			if got := readTestFile(t, filepath.Join(dir, "README.md")); got != tt.want {
+				t.Errorf("README.md = %q, want %q (변경하지 않음)", got, tt.want)
+			}
+			if rec, _ := cfg.DB.GetJob(target.ID); rec.RevertedBy != "" {
+				t.Errorf("RevertedBy = %q, want 빈 값", rec.RevertedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// streamEvent는 cursor-agent `--output-format stream-json`이 한 줄씩 출력하는 이벤트입니다.
+//
+// 예시:
+//
+//	{"type":"system","subtype":"init","session_id":"...","model":"..."}
+//	{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"..."}]}}
+//	{"type":"tool_call","subtype":"started","call_id":"...","tool_call":{...}}
+//	{"type":"result","subtype":"success","is_error":false,"result":"...","session_id":"..."}
+type streamEvent struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	Message   *struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"message"`
+	Result  string `json:"result"`
+	IsError bool   `json:"is_error"`
+}
+
+// streamParser는 stream-json 이벤트를 누적하여 사람이 읽을 수 있는 출력으로 변환합니다.
+// JSON이 아닌 줄은 일반 텍스트 출력으로 취급합니다 (--output-format text 호환).
+type streamParser struct {
+	mu        sync.Mutex
+	text      strings.Builder
+	result    string
+	hasResult bool
+	sessionID string
+}
+
+// Feed는 한 줄을 파싱하고, 새로 추가된 출력 텍스트를 반환합니다.
+func (p *streamParser) Feed(line string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return ""
+	}
+
+	var ev streamEvent
+	if !strings.HasPrefix(trimmed, "{") || json.Unmarshal([]byte(trimmed), &ev) != nil || ev.Type == "" {
+		// 일반 텍스트 줄
+		delta := line + "\n"
+		p.text.WriteString(delta)
+		return delta
+	}
+
+	if ev.SessionID != "" {
+		p.sessionID = ev.SessionID
+	}
+
+	switch ev.Type {
+	case "assistant":
+		if ev.Message == nil {
+			return ""
+		}
+		var delta strings.Builder
+		for _, c := range ev.Message.Content {
+			if c.Type == "text" && c.Text != "" {
+				delta.WriteString(c.Text)
+			}
+		}
+		p.text.WriteString(delta.String())
+		return delta.String()
+	case "result":
+		p.result = ev.Result
+		p.hasResult = true
+	}
+	return ""
+}
+
+// Output은 지금까지의 출력을 반환합니다.
+// result 이벤트를 받았다면 최종 결과 텍스트를, 아니면 누적된 assistant 텍스트를 반환합니다.
+func (p *streamParser) Output() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.hasResult && p.result != "" {
+		return p.result
+	}
+	return p.text.String()
+}
+
+// Partial은 실행 중 누적된 출력을 반환합니다.
+func (p *streamParser) Partial() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.text.String()
+}
+
+// SessionID는 cursor-agent가 보고한 채팅 세션 ID를 반환합니다.
+func (p *streamParser) SessionID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sessionID
+}
+
+// lineWriter는 io.Writer로 받은 바이트를 줄 단위로 잘라 콜백에 전달합니다.
+// exec.Cmd의 Stdout으로 사용하여 프로세스 출력을 실시간으로 처리합니다.
+type lineWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	onLine func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			break
+		}
+		line := string(w.buf.Next(idx + 1))
+		w.onLine(strings.TrimRight(line, "\r\n"))
+	}
+	return len(p), nil
+}
+
+// Flush는 줄바꿈 없이 남아 있는 마지막 줄을 처리합니다.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.onLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+// outputFlusher는 부분 출력을 주기적으로 DB에 저장합니다.
+// 매 줄마다 쓰지 않고 interval 간격으로 변경된 내용만 기록하여 DB 부하를 줄입니다.
+type outputFlusher struct {
+	jobID    string
+	db       DBInterface
+	source   func() string
+	interval time.Duration
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+func newOutputFlusher(jobID string, db DBInterface, source func() string, interval time.Duration) *outputFlusher {
+	return &outputFlusher{
+		jobID:    jobID,
+		db:       db,
+		source:   source,
+		interval: interval,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Start는 주기적 저장 goroutine을 시작합니다.
+func (f *outputFlusher) Start() {
+	go func() {
+		defer close(f.stopped)
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+
+		last := ""
+		for {
+			select {
+			case <-f.done:
+				return
+			case <-ticker.C:
+				current := f.source()
+				if current == last {
+					continue
+				}
+				if err := f.db.UpdateJobOutput(f.jobID, current); err != nil {
+					log.Printf("[%s] 부분 출력 저장 실패: %v", f.jobID, err)
+					continue
+				}
+				last = current
+			}
+		}
+	}()
+}
+
+// Stop은 저장 goroutine을 종료하고 완료될 때까지 기다립니다.
+func (f *outputFlusher) Stop() {
+	close(f.done)
+	<-f.stopped
+}
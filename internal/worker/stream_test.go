@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStreamParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       []string
+		wantDeltas  []string
+		wantOutput  string
+		wantPartial string
+		wantSession string
+	}{
+		{
+			name: "assistant text and result",
+			lines: []string{
+				`{"type":"system","subtype":"init","session_id":"sess-1","model":"gpt-5"}`,
+				`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"파일을 "}]}}`,
+				`{"type":"tool_call","subtype":"started","call_id":"c1","tool_call":{}}`,
+				`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"수정했습니다"}]}}`,
+				`{"type":"result","subtype":"success","is_error":false,"result":"완료: 파일 1개 수정","session_id":"sess-1"}`,
+			},
+			wantDeltas:  []string{"", "파일을 ", "", "수정했습니다", ""},
+			wantOutput:  "완료: 파일 1개 수정",
+			wantPartial: "파일을 수정했습니다",
+			wantSession: "sess-1",
+		},
+		{
+			name: "empty result falls back to assistant text",
+			lines: []string{
+				`{"type":"assistant","message":{"content":[{"type":"text","text":"a"},{"type":"tool_use"},{"type":"text","text":"b"}]}}`,
+				`{"type":"result","result":""}`,
+			},
+			wantDeltas:  []string{"ab", ""},
+			wantOutput:  "ab",
+			wantPartial: "ab",
+		},
+		{
+			name:        "plain text lines (output-format text)",
+			lines:       []string{"첫 줄", "", "{not json", `{"no_type":true}`},
+			wantDeltas:  []string{"첫 줄\n", "", "{not json\n", "{\"no_type\":true}\n"},
+			wantOutput:  "첫 줄\n{not json\n{\"no_type\":true}\n",
+			wantPartial: "첫 줄\n{not json\n{\"no_type\":true}\n",
+		},
+		{
+			name:        "assistant without message",
+			lines:       []string{`{"type":"assistant"}`, `{"type":"system","session_id":"sess-2"}`},
+			wantDeltas:  []string{"", ""},
+			wantSession: "sess-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &streamParser{}
+			for i, line := range tt.lines {
+				if got := p.Feed(line); got != tt.wantDeltas[i] {
+					t.Errorf("Feed(%q) = %q, want %q", line, got, tt.wantDeltas[i])
+				}
+			}
+			if got := p.Output(); got != tt.wantOutput {
+				t.Errorf("Output() = %q, want %q", got, tt.wantOutput)
+			}
+			if got := p.Partial(); got != tt.wantPartial {
+				t.Errorf("Partial() = %q, want %q", got, tt.wantPartial)
+			}
+			if got := p.SessionID(); got != tt.wantSession {
+				t.Errorf("SessionID() = %q, want %q", got, tt.wantSession)
+			}
+		})
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{onLine: func(line string) { lines = append(lines, line) }}
+
+	for _, chunk := range []string{"fir", "st\r\nsec", "ond\n\nth", "ird"} {
+		if n, err := w.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if got := strings.Join(lines, "|"); got != "first|second|" {
+		t.Errorf("lines before Flush = %q, want %q", got, "first|second|")
+	}
+	w.Flush()
+	w.Flush()
+	if got := strings.Join(lines, "|"); got != "first|second||third" {
+		t.Errorf("lines after Flush = %q, want %q", got, "first|second||third")
+	}
+}
@@ -4,28 +4,88 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kakaovx/cursor-slack-server/internal/database"
 	"github.com/kakaovx/cursor-slack-server/internal/process"
+	"github.com/kakaovx/cursor-slack-server/internal/redact"
+	"github.com/kakaovx/cursor-slack-server/internal/slack"
+	"github.com/kakaovx/cursor-slack-server/internal/textutil"
 	"github.com/kakaovx/cursor-slack-server/internal/types"
 )
 
+// ErrJobCancelled는 사용자 요청으로 작업이 취소되었음을 나타냅니다 (v1.5)
+var ErrJobCancelled = errors.New("작업이 취소되었습니다")
+
 // TaskExecutor는 실제 cursor-agent 작업을 실행하고 모든 보안 검증을 수행합니다.
 type TaskExecutor struct {
 	allowedResponseDomains []string // (SSRF 방어) 허용 도메인
+
+	Worktree    WorktreeConfig    // v1.5: 작업별 git worktree 격리 설정 (기본값: 비활성)
+	PullRequest PullRequestConfig // v1.5: 작업 완료 후 PR 생성 설정 (기본값: 비활성)
+	Slack       *slack.Client     // v1.5: 멘션/DM으로 요청된 작업의 결과를 스레드에 게시 (SLACK_BOT_TOKEN, 없으면 nil)
+
+	// v1.5: 출력/diff가 이 길이(바이트)를 넘으면 결과 스레드에 파일로 첨부하고 메시지에는 미리보기만 표시 (0이면 첨부하지 않음)
+	FileUploadThreshold int
+
+	// v1.5: 작업 출력/diff/계획과 Slack에 표시하는 프롬프트에서 비밀 값을 가림 (DB 저장, Slack 게시 전)
+	Redactor *redact.Redactor
+
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc // v1.5: 실행 중인 작업의 취소 함수 (Job ID → cancel)
 }
 
 // NewTaskExecutor는 TaskExecutor의 인스턴스를 생성합니다.
 func NewTaskExecutor(allowedDomains []string) *TaskExecutor {
 	return &TaskExecutor{
 		allowedResponseDomains: allowedDomains,
+		FileUploadThreshold:    DefaultFileUploadThreshold,
+		Redactor:               redact.New(nil),
+		running:                make(map[string]context.CancelCauseFunc),
+	}
+}
+
+// Cancel은 이 실행기에서 실행 중인 작업을 취소합니다 (v1.5)
+// 작업의 컨텍스트를 취소하면 executeAgent가 프로세스 그룹 전체를 종료합니다.
+// 실행 중인 작업이 아니면 false를 반환합니다.
+func (te *TaskExecutor) Cancel(jobID string, cancelledBy string) bool {
+	te.mu.Lock()
+	cancel, ok := te.running[jobID]
+	te.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	cancel(fmt.Errorf("%w (요청자: %s)", ErrJobCancelled, cancelledBy))
+	return true
+}
+
+// trackJob은 실행 중인 작업의 취소 함수를 등록합니다.
+func (te *TaskExecutor) trackJob(jobID string, cancel context.CancelCauseFunc) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.running[jobID] = cancel
+}
+
+// untrackJob은 종료된 작업을 실행 목록에서 제거합니다.
+func (te *TaskExecutor) untrackJob(jobID string) {
+	te.mu.Lock()
+	cancel, ok := te.running[jobID]
+	delete(te.running, jobID)
+	te.mu.Unlock()
+
+	if ok {
+		cancel(nil)
 	}
 }
 
@@ -39,12 +99,28 @@ type DBInterface interface {
 	CreateJob(job *database.JobRecord) error
 	UpdateJobStatus(jobID string, status database.JobStatus) error
 	UpdateJobResult(jobID string, output string, errorMsg string) error
+	UpdateJobOutput(jobID string, output string) error
+	GetJob(jobID string) (*database.JobRecord, error)
+	UpdateJobProjectPath(jobID string, projectPath string) error
+	GetProject(name string) (*database.Project, error)
+	UpdateJobWorktree(jobID string, worktreePath string, branch string) error
+	UpdateJobChanges(jobID string, baseCommit string, checkpointBefore string, checkpointAfter string, diff string, files []database.ChangedFile) error
+	UpdateJobPullRequest(jobID string, branch string, prURL string) error
+	MarkJobReverted(jobID string, revertJobID string) error
+	ListJobsStartedAfter(projectPath string, worktreePath string, since time.Time, excludeID string) ([]*database.JobRecord, error)
+	SetJobAwaitingApproval(jobID string, plan string) (bool, error)
+	UpdateJobSession(jobID string, sessionID string) error
+	UpdateJobThread(jobID string, threadTS string, messageTS string) error
+	AddUsage(scope string, subject string, period string, delta int64) error
+	AddJobRedactions(jobID string, count int) error
 }
 
 // ConfigFull은 전체 설정을 담는 구조체입니다 (타입 assertion용)
 type ConfigFull struct {
-	CursorCLIPath string
-	DB            DBInterface
+	Agents     *AgentRegistry // v1.5: 작업을 실행할 에이전트 백엔드 (기존 CursorCLIPath 대체)
+	JobTimeout time.Duration  // v1.5: 시간 제한이 기록되지 않은 작업의 시간 제한 (JOB_TIMEOUT)
+	KillGrace  time.Duration  // v1.5: 시간 초과/취소 시 SIGTERM 후 SIGKILL까지 기다리는 시간 (JOB_KILL_GRACE, 0이면 바로 SIGKILL)
+	DB         DBInterface
 	Config
 }
 
@@ -58,8 +134,14 @@ func (te *TaskExecutor) Run(job Job) {
 		log.Printf("[%s] 잘못된 Config 타입", job.ID)
 		return
 	}
+
+	// v1.5: 되돌리기 작업은 cursor-agent를 실행하지 않음
+	if job.Kind == database.JobKindRevert {
+		te.runRevert(cfg, job)
+		return
+	}
 	
-	responseURL := payload.ResponseURL
+	reply := replyTo(job)
 	jobID := job.ID
 
 	// 1. 프롬프트 추출 (v1.1: 단순화)
@@ -68,60 +150,193 @@ func (te *TaskExecutor) Run(job Job) {
 	if prompt == "" {
 		errMsg := "❌ 프롬프트가 비어있습니다. 사용법: /cursor \"자연어 프롬프트\""
 		log.Printf("[%s] %s", jobID, errMsg)
-		if responseURL != "" {
-			te.sendDelayedResponse(responseURL, errMsg)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
 		}
 		return
 	}
 
-	// 1.5. 프로젝트 경로 확인 (v1.2)
-	projectPath, isSet := cfg.GetProjectPath()
-	if !isSet {
-		errMsg := "❌ 프로젝트 경로가 설정되지 않았습니다.\n" +
-			"먼저 `/cursor set-path <프로젝트_경로>` 명령어로 경로를 설정해주세요.\n" +
-			"예시: `/cursor set-path /Users/username/projects/my-project`"
+	// v1.5: 서버 재시작으로 비밀 값을 가리기 전의 원문이 없으면 가린 프롬프트로 실행하지 않음
+	if job.PromptMissing {
+		errMsg := "❌ " + ErrPromptUnavailable.Error()
 		log.Printf("[%s] %s", jobID, errMsg)
-		if responseURL != "" {
-			te.sendDelayedResponse(responseURL, errMsg)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
 		}
 		return
 	}
 
-	// v1.3: DB에 작업 생성
-	jobRecord := &database.JobRecord{
-		ID:          jobID,
-		Prompt:      prompt,
-		ProjectPath: projectPath,
-		Status:      database.JobStatusPending,
-		UserID:      payload.UserID,
-		UserName:    payload.UserName,
-		CreatedAt:   time.Now(),
+	// v1.5: Slack 메시지와 PR에는 비밀 값을 가린 프롬프트를 표시 (cursor-agent에는 원본 전달)
+	shownPrompt := te.Redactor.String(prompt)
+
+	// 1.5. 프로젝트 경로 확인 (v1.2, v1.5: 작업별 프로젝트)
+	projectPath, err := resolveProjectPath(cfg, job)
+	if err != nil {
+		errMsg := "❌ " + err.Error()
+		log.Printf("[%s] %s", jobID, errMsg)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
+		}
+		return
 	}
-	if err := cfg.DB.CreateJob(jobRecord); err != nil {
-		log.Printf("[%s] DB 작업 생성 실패: %v", jobID, err)
+
+	// v1.5: 요청 시점에 결정된 에이전트 백엔드 (이후 AGENTS_CONFIG에서 제거되었으면 실행하지 않음)
+	agent, err := cfg.Agents.Get(job.Agent)
+	if err != nil {
+		errMsg := "❌ " + err.Error()
+		log.Printf("[%s] %s", jobID, errMsg)
+		cfg.DB.UpdateJobResult(jobID, "", errMsg)
+		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+		if reply.ok() {
+			te.sendDelayedResponse(reply, errMsg)
+		}
+		return
+	}
+
+	// v1.5: 취소 가능하도록 실행 컨텍스트 등록
+	ctx, cancel := context.WithCancelCause(context.Background())
+	te.trackJob(jobID, cancel)
+	defer te.untrackJob(jobID)
+
+	// 작업 시작 (v1.5: Dispatcher가 큐에서 claim할 때 running으로 전환됨)
+	// claim과 실행 컨텍스트 등록 사이에 취소된 작업은 실행하지 않음
+	// (취소 요청은 DB를 먼저 바꾸고 Cancel을 호출하므로, 등록 후 확인하면 둘 중 하나는 반드시 취소를 처리함)
+	if rec, err := cfg.DB.GetJob(jobID); err == nil && rec != nil && rec.Status == database.JobStatusCancelled {
+		log.Printf("[%s] 실행 직전에 취소된 작업입니다. 실행하지 않습니다.", jobID)
+		return
+	}
+	if err := cfg.DB.UpdateJobProjectPath(jobID, projectPath); err != nil {
+		log.Printf("[%s] 프로젝트 경로 기록 실패: %v", jobID, err)
+	}
+
+	// v1.5: 이어서 실행하는 작업은 원본 작업의 cursor-agent 세션을 재개
+	// v1.5: 요청별 모델, 시간 제한, 읽기 전용 옵션
+	opts := runOptions{ReadOnly: job.ReadOnly, Model: job.Model, Timeout: job.Timeout, KillGrace: cfg.KillGrace}
+	if opts.Timeout == 0 {
+		opts.Timeout = cfg.JobTimeout
+	}
+	var parent *database.JobRecord
+	if job.Kind == database.JobKindContinue {
+		parent, err = continuationParent(cfg, job)
+		if err != nil {
+			errMsg := "❌ " + err.Error()
+			log.Printf("[%s] %s", jobID, errMsg)
+			cfg.DB.UpdateJobResult(jobID, "", errMsg)
+			cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+			if reply.ok() {
+				te.sendDelayedResponse(reply, errMsg)
+			}
+			return
+		}
+		opts.ResumeSession = parent.SessionID
+	}
+
+	// v1.5: worktree 격리 없이 같은 체크아웃을 수정하므로 같은 저장소의 작업(계획 단계 포함)은 하나씩 실행
+	if !te.Worktree.Enabled {
+		defer lockRepo(jobID, projectPath)()
 	}
 
-	// 작업 시작
-	cfg.DB.UpdateJobStatus(jobID, database.JobStatusRunning)
+	// v1.5: 승인이 필요한 작업은 먼저 읽기 전용으로 실행 계획만 작성하고 승인 대기로 전환
+	if job.RequireApproval && !job.Approved {
+		te.runPlan(ctx, cfg, job, agent, projectPath, parent, opts)
+		return
+	}
+
+	// v1.5: 봇 토큰이 있으면 상태 메시지를 게시하고 결과는 그 스레드에 게시 (response_url 5회 제한 없음)
+	reply = te.startReply(cfg, job, statusText("⏳ 작업을 실행 중입니다...", job, shownPrompt))
+
+	// v1.5: worktree 격리 모드면 작업 전용 worktree에서 실행
+	workDir := projectPath
+	branch := ""
+	pushedBranch := "" // v1.5: PR용으로 원격에 push된 브랜치 (worktree 정리 후에도 기록 유지)
+	if te.Worktree.Enabled {
+		// v1.5: 이어서 실행하는 작업은 원본 작업의 worktree가 남아 있으면 그대로 사용
+		wt := te.Worktree.reuseWorktree(projectPath, parent)
+		if wt == nil {
+			wt, err = te.Worktree.prepareWorktree(jobID, projectPath)
+		}
+		if err != nil {
+			errMsg := "❌ " + err.Error()
+			log.Printf("[%s] %s", jobID, errMsg)
+			cfg.DB.UpdateJobResult(jobID, "", errMsg)
+			cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
+			if reply.ok() {
+				te.sendDelayedResponse(reply, errMsg)
+			}
+			return
+		}
+		log.Printf("[%s] worktree에서 실행합니다: %s (브랜치: %s)", jobID, wt.Dir(), wt.Branch)
+		workDir = wt.Dir()
+		branch = wt.Branch
+		if err := cfg.DB.UpdateJobWorktree(jobID, wt.Path, wt.Branch); err != nil {
+			log.Printf("[%s] worktree 기록 실패: %v", jobID, err)
+		}
+		defer func() {
+			if te.Worktree.cleanupWorktree(jobID, wt) {
+				// 로컬 브랜치는 삭제되므로 원격에 push된 브랜치만 기록 (worktree 경로는 실행 위치로 유지)
+				cfg.DB.UpdateJobWorktree(jobID, wt.Path, pushedBranch)
+			}
+		}()
+	}
 
 	// 진행 상황 업데이트를 위한 channel
 	progressDone := make(chan struct{})
 	
-	// 주기적으로 진행 상황 전송 (2분마다, 최대 4회) - Slack 요청인 경우에만
-	if responseURL != "" {
-		go te.sendProgressUpdates(jobID, responseURL, progressDone)
+	// 주기적으로 진행 상황 전송 (2분마다, response_url은 최대 4회) - Slack 요청인 경우에만
+	if reply.ok() {
+		go te.sendProgressUpdates(jobID, reply, statusText("⏳ 작업을 실행 중입니다...", job, shownPrompt), progressDone)
 	}
 
-	// 2. cursor-agent 실행 (v1.1: --force 추가, --files 제거)
-	log.Printf("[%s] 작업자 실행 시작: prompt='%s'", jobID, prompt)
-	output, err := te.executeCursorCommand(jobID, prompt, projectPath, cfg.CursorCLIPath)
+	// 2. 에이전트 실행 (v1.1: --force 추가, --files 제거, v1.5: 에이전트 백엔드 선택)
+	// v1.5: 출력을 실시간으로 파싱하고 부분 출력을 주기적으로 DB에 저장
+	// v1.5: 실행 전 스냅샷 (실제 변경 사항 계산용)
+	changes := beginChanges(jobID, workDir)
+
+	parser := agent.NewParser()
+	flusher := newOutputFlusher(jobID, cfg.DB, te.redacted(parser.Partial), outputFlushInterval)
+	flusher.Start()
+
+	log.Printf("[%s] 작업자 실행 시작 (에이전트: %s): prompt='%s'", jobID, agent.Name(), shownPrompt)
+	agentStarted := time.Now()
+	output, err := te.executeAgent(ctx, jobID, agent, prompt, workDir, parser, opts)
 	
-	// 진행 상황 업데이트 중지
+	// 진행 상황 업데이트 및 부분 출력 저장 중지
 	close(progressDone)
+	flusher.Stop()
+	recordSession(cfg, jobID, parser)
+	recordAgentUsage(cfg, job, agentStarted)
+
+	// v1.5: 실행 후 스냅샷과 비교하여 실제 변경 사항 기록 (취소/실패한 작업 포함)
+	if changes != nil {
+		if err := changes.finish(jobID, workDir, cfg.DB, te.Redactor); err != nil {
+			log.Printf("[%s] 변경 사항 기록 실패: %v", jobID, err)
+			changes = nil
+		}
+	}
 
 	// 3. 결과 포맷팅
-	rawOutput := string(output)
-	if err != nil {
+	// v1.5: 저장/로그/게시 전에 출력의 비밀 값을 가림
+	rawOutput, redactions := te.Redactor.Redact(string(output))
+	if changes != nil {
+		redactions += changes.Redactions
+	}
+	recordRedactions(cfg, jobID, redactions)
+	if errors.Is(err, ErrJobCancelled) {
+		// v1.5: 취소된 작업 - 상태는 취소 요청 시 이미 cancelled로 기록됨
+		log.Printf("[%s] %v", jobID, err)
+		cfg.DB.UpdateJobResult(jobID, rawOutput, err.Error())
+
+		if reply.ok() {
+			te.sendCancelledMessage(reply, jobID, err)
+		}
+		te.finishStatus(reply, jobID, statusText("🛑 작업이 취소되었습니다", job, shownPrompt), ActionRetryJob, ActionShowOutput)
+	} else if err != nil {
 		log.Printf("[%s] 작업자 실행 오류: %v, output: %s", jobID, err, rawOutput)
 
 		// v1.3: 실패 결과 저장
@@ -129,10 +344,18 @@ func (te *TaskExecutor) Run(job Job) {
 		cfg.DB.UpdateJobStatus(jobID, database.JobStatusFailed)
 		
 		// 에러 메시지 포맷팅 (마크다운 적용)
-		if responseURL != "" {
-			messages := te.formatErrorOutput(jobID, err, rawOutput)
-			te.sendMultipleMessages(responseURL, messages, jobID)
+		// v1.5: Block Kit으로 전송하고, 실패 시 텍스트 메시지로 대체
+		// v1.5: 긴 출력은 스레드에 파일로 첨부하고 메시지에는 미리보기만 표시
+		if reply.ok() {
+			shownOutput, _, attachments := te.attachLargeResults(reply, jobID, rawOutput, nil)
+			messages := te.formatErrorOutput(jobID, err, shownOutput, reply.maxMessages())
+			blocks := te.formatErrorBlocks(jobID, err, shownOutput)
+			blocks, messages = withRedactionNote(blocks, messages, redactions)
+			blocks = append(blocks, JobActionsBlock(jobID, resultActions(job.Kind, changes)...))
+			te.sendBlockMessages(reply, jobID, "❌ Cursor AI 실행 중 오류 발생", blocks, messages, "in_channel")
+			te.uploadAttachments(reply, jobID, attachments)
 		}
+		te.finishStatus(reply, jobID, statusText("❌ 작업이 실패했습니다. 결과는 스레드를 확인하세요", job, shownPrompt), resultActions(job.Kind, changes)...)
 	} else {
 		log.Printf("[%s] 작업자 실행 완료.", jobID)
 
@@ -141,28 +364,213 @@ func (te *TaskExecutor) Run(job Job) {
 		cfg.DB.UpdateJobStatus(jobID, database.JobStatusCompleted)
 		
 		// 성공 메시지 포맷팅 (마크다운 적용, before/after 표시)
-		if responseURL != "" {
-			messages := te.formatSuccessOutput(jobID, rawOutput, prompt)
-			te.sendMultipleMessages(responseURL, messages, jobID)
+		// v1.5: Block Kit으로 전송하고, 실패 시 텍스트 메시지로 대체
+		// v1.5: 긴 출력/diff는 스레드에 파일로 첨부하고 메시지에는 미리보기만 표시
+		if reply.ok() {
+			shownOutput, shownChanges, attachments := te.attachLargeResults(reply, jobID, rawOutput, changes)
+			messages := te.formatSuccessOutput(jobID, shownOutput, shownPrompt, branch, shownChanges, reply.maxMessages())
+			blocks := te.formatSuccessBlocks(jobID, shownOutput, shownPrompt, branch, shownChanges)
+			blocks, messages = withRedactionNote(blocks, messages, redactions)
+			blocks = append(blocks, JobActionsBlock(jobID, resultActions(job.Kind, changes)...))
+			te.sendBlockMessages(reply, jobID, "✅ Cursor AI 작업 완료: "+truncateRunes(shownPrompt, 100), blocks, messages, "in_channel")
+			te.uploadAttachments(reply, jobID, attachments)
+		}
+		te.finishStatus(reply, jobID, statusText("✅ 작업이 완료되었습니다. 결과는 스레드를 확인하세요", job, shownPrompt), resultActions(job.Kind, changes)...)
+
+		// v1.5: --pr 또는 프로젝트 auto_pr 설정 시 PR 생성
+		if job.OpenPR {
+			pushedBranch = te.openPullRequest(cfg, jobID, projectPath, shownPrompt, changes, reply)
 		}
 	}
 }
 
-// executeCursorCommand는 context.WithTimeout과 process group kill을 사용하여
-// cursor-agent를 안전하게 실행합니다.
-func (te *TaskExecutor) executeCursorCommand(jobID string, prompt string, projectPath string, cursorCLIPath string) ([]byte, error) {
-	// 1. 타임아웃 컨텍스트 생성 (15분)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-	defer cancel()
+// sendCancelledMessage는 작업 취소 결과를 다시 실행/전체 출력 버튼과 함께 전송합니다.
+func (te *TaskExecutor) sendCancelledMessage(reply replyTarget, jobID string, err error) {
+	message := fmt.Sprintf("🛑 *작업이 취소되었습니다* (ID: `%s`)\n> %s", jobID[:8], err.Error())
+	te.postReply(reply, types.SlackDelayedResponse{
+		Text:         message,
+		ResponseType: "in_channel",
+		Blocks:       MessageBlocks(message, jobID, ActionRetryJob, ActionShowOutput),
+	})
+}
+
+// openPullRequest는 작업 변경 사항으로 PR을 만들고 결과를 기록/전송합니다 (v1.5)
+// PR 생성 실패는 작업 자체의 실패로 처리하지 않습니다. push된 브랜치 이름을 반환합니다.
+func (te *TaskExecutor) openPullRequest(cfg *ConfigFull, jobID string, projectPath string, prompt string, changes *jobChanges, reply replyTarget) string {
+	pr, branch, err := te.PullRequest.open(jobID, projectPath, prompt, changes)
+
+	var text string
+	switch {
+	case errors.Is(err, errNoChanges):
+		log.Printf("[%s] %v", jobID, err)
+		text = "ℹ️ " + err.Error()
+	case err != nil:
+		log.Printf("[%s] PR 생성 실패: %v", jobID, err)
+		if branch != "" {
+			// push는 성공했으므로 브랜치는 기록
+			cfg.DB.UpdateJobPullRequest(jobID, branch, "")
+		}
+		text = fmt.Sprintf("⚠️ *PR을 만들지 못했습니다* (ID: `%s`)\n> %s", jobID[:8], err.Error())
+	default:
+		log.Printf("[%s] PR 생성 완료: %s", jobID, pr.URL)
+		if err := cfg.DB.UpdateJobPullRequest(jobID, branch, pr.URL); err != nil {
+			log.Printf("[%s] PR 기록 실패: %v", jobID, err)
+		}
+		text = fmt.Sprintf("🔀 *PR이 생성되었습니다*: <%s|#%d> (브랜치: `%s`)", pr.URL, pr.Number, branch)
+	}
+
+	if reply.ok() {
+		te.sendDelayedResponse(reply, text)
+	}
+	return branch
+}
+
+// resolveProjectPath는 작업을 실행할 프로젝트 경로를 결정합니다 (v1.5)
+//
+// 우선순위: @이름으로 지정한 프로젝트 > 요청 시점에 기록된 경로 > 현재 기본 경로.
+// 이름 있는 프로젝트는 실행 시점에 다시 조회하므로, 대기 중에 프로젝트가 삭제되면 실패합니다.
+func resolveProjectPath(cfg *ConfigFull, job Job) (string, error) {
+	if job.ProjectName != "" {
+		project, err := cfg.DB.GetProject(job.ProjectName)
+		if err != nil {
+			return "", err
+		}
+		if project == nil {
+			return "", fmt.Errorf("등록되지 않은 프로젝트입니다: `%s`\n`/cursor project list`로 등록된 프로젝트를 확인하세요.", job.ProjectName)
+		}
+		return project.Path, nil
+	}
+
+	if job.ProjectPath != "" {
+		return job.ProjectPath, nil
+	}
+
+	if projectPath, isSet := cfg.GetProjectPath(); isSet {
+		return projectPath, nil
+	}
+
+	return "", errors.New("프로젝트 경로가 설정되지 않았습니다.\n" +
+		"먼저 `/cursor set-path <프로젝트_경로>` 명령어로 기본 경로를 설정하거나,\n" +
+		"`/cursor project add <이름> <경로>`로 프로젝트를 등록한 뒤 `/cursor @이름 \"프롬프트\"`로 실행해주세요.")
+}
+
+// runOptions는 에이전트 실행 옵션입니다 (v1.5)
+type runOptions struct {
+	ReadOnly      bool          // 파일 수정 없이 실행 (cursor-agent: --force 없이, 승인 전 계획 단계 또는 --readonly)
+	ResumeSession string        // --resume으로 이어서 실행할 채팅 세션 ID (continue 작업)
+	Model         string        // --model로 선택한 모델
+	Timeout       time.Duration // 작업 시간 제한 (요청 > 프로젝트 > JOB_TIMEOUT 순서로 결정)
+	KillGrace     time.Duration // 시간 초과/취소 시 SIGTERM 후 SIGKILL까지 기다리는 시간 (JOB_KILL_GRACE)
+}
+
+// 에이전트 실행 시간 제한 (v1.5)
+const (
+	DefaultJobTimeout    = 15 * time.Minute // JOB_TIMEOUT 기본값
+	DefaultMaxJobTimeout = time.Hour        // JOB_TIMEOUT_MAX 기본값 (요청/프로젝트별 시간 제한의 최대값)
+	DefaultKillGrace     = 10 * time.Second // JOB_KILL_GRACE 기본값 (시간 초과/취소 시 SIGTERM 후 SIGKILL까지 기다리는 시간)
+)
+
+// FormatDuration은 시간 제한을 "1시간 30분", "15분", "30초" 형식으로 표시합니다 (v1.5)
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	var parts []string
+	if h := d / time.Hour; h > 0 {
+		parts = append(parts, fmt.Sprintf("%d시간", h))
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		parts = append(parts, fmt.Sprintf("%d분", m))
+	}
+	if s := d % time.Minute / time.Second; s > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%d초", s))
+	}
+	return strings.Join(parts, " ")
+}
+
+// recordSession은 cursor-agent가 보고한 채팅 세션 ID를 기록합니다 (v1.5)
+// 실패하거나 취소된 작업도 세션은 남아 있으므로 이어서 실행할 수 있도록 항상 기록합니다.
+func recordSession(cfg *ConfigFull, jobID string, parser OutputParser) {
+	sessionID := parser.SessionID()
+	if sessionID == "" {
+		return
+	}
+	if err := cfg.DB.UpdateJobSession(jobID, sessionID); err != nil {
+		log.Printf("[%s] 세션 ID 기록 실패: %v", jobID, err)
+	}
+}
+
+// recordAgentUsage는 cursor-agent 실행 시간을 요청한 채널의 하루 사용량에 더합니다 (v1.5)
+// 실패하거나 취소된 실행도 사용한 시간만큼 기록합니다. API 요청(채널 없음)은 기록하지 않습니다.
+func recordAgentUsage(cfg *ConfigFull, job Job, started time.Time) {
+	if job.Payload.ChannelID == "" {
+		return
+	}
+	seconds := int64(time.Since(started).Round(time.Second) / time.Second)
+	if seconds <= 0 {
+		return
+	}
+	if err := cfg.DB.AddUsage(database.UsageChannelAgentSeconds, job.Payload.ChannelID, database.DayPeriod(started), seconds); err != nil {
+		log.Printf("[%s] 채널 사용량 기록 실패: %v", job.ID, err)
+	}
+}
 
-	// 2. 명령어 인자 생성 (v1.1: --force 필수, --files 제거)
-	args := []string{
-		"-p", prompt,              // 자연어 프롬프트 (파일명 포함 가능)
-		"--force",                 // 파일 수정 허용 (필수!)
-		"--output-format", "text", // 텍스트 출력
+// redacted는 실행 중 부분 출력을 DB에 저장하기 전에 비밀 값을 가리는 source를 반환합니다 (v1.5)
+func (te *TaskExecutor) redacted(source func() string) func() string {
+	return func() string {
+		return te.Redactor.String(source())
 	}
+}
 
-	cmd := exec.CommandContext(ctx, cursorCLIPath, args...)
+// recordRedactions는 작업 결과에서 가린 비밀 값 수를 기록합니다 (v1.5)
+func recordRedactions(cfg *ConfigFull, jobID string, count int) {
+	if count == 0 {
+		return
+	}
+	log.Printf("[%s] 작업 결과에서 비밀 값 %d개를 가렸습니다", jobID, count)
+	if err := cfg.DB.AddJobRedactions(jobID, count); err != nil {
+		log.Printf("[%s] 가린 비밀 값 수 기록 실패: %v", jobID, err)
+	}
+}
+
+// withRedactionNote는 비밀 값을 가린 경우 결과 메시지 끝에 안내를 추가합니다 (v1.5)
+func withRedactionNote(blocks []types.SlackBlock, messages []string, count int) ([]types.SlackBlock, []string) {
+	if count == 0 {
+		return blocks, messages
+	}
+	note := fmt.Sprintf("🔒 비밀 값으로 보이는 내용 %d개를 가렸습니다", count)
+	blocks = append(blocks, contextBlock(note))
+	if len(messages) > 0 {
+		messages[len(messages)-1] += "\n\n" + note
+	}
+	return blocks, messages
+}
+
+// outputFlushInterval은 실행 중인 작업의 부분 출력을 DB에 저장하는 주기입니다.
+const outputFlushInterval = 1 * time.Second
+
+// executeAgent는 context.WithTimeout과 process group kill을 사용하여
+// 에이전트 CLI를 안전하게 실행합니다.
+// v1.5: stdout을 줄 단위로 parser에 전달하여 실행 중에도 출력을 확인할 수 있습니다.
+// v1.5: ctx가 취소되면(작업 취소 요청) 타임아웃과 동일하게 프로세스 그룹을 종료합니다.
+// v1.5: opts로 읽기 전용 실행(승인 전 계획 단계)과 세션 재개를 지정합니다.
+// v1.5: 명령어와 출력 형식은 agent가 결정합니다 (cursor-agent 또는 AGENTS_CONFIG의 명령어 템플릿).
+func (te *TaskExecutor) executeAgent(ctx context.Context, jobID string, agent Agent, prompt string, projectPath string, parser OutputParser, opts runOptions) ([]byte, error) {
+	// 1. 타임아웃 컨텍스트 생성 (v1.5: 작업별 시간 제한, Run에서 JOB_TIMEOUT으로 채움)
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// 2. 명령어 생성 (v1.5: 에이전트 백엔드별)
+	command := agent.Command(AgentRequest{
+		Prompt:        prompt,
+		Dir:           projectPath,
+		ReadOnly:      opts.ReadOnly,
+		ResumeSession: opts.ResumeSession,
+		Model:         opts.Model,
+	})
+	// v1.5: 종료는 아래 select에서 직접 처리 (CommandContext는 ctx 종료 시 곧바로 SIGKILL을 보냄)
+	cmd := exec.Command(command.Path, command.Args...)
+	if len(command.Env) > 0 {
+		cmd.Env = append(os.Environ(), command.Env...)
+	}
 
 	// 3. (보안) 작업 디렉토리 격리
 	cmd.Dir = projectPath
@@ -171,11 +579,12 @@ func (te *TaskExecutor) executeCursorCommand(jobID string, prompt string, projec
 	// 타임아웃 시 좀비 프로세스 방지
 	process.SetupProcessGroup(cmd)
 
-	log.Printf("[%s] Executing: %s %s (in %s)", jobID, cursorCLIPath, strings.Join(args, " "), cmd.Dir)
+	log.Printf("[%s] Executing (%s): %s %s (in %s)", jobID, agent.Name(), command.Path, strings.Join(command.Args, " "), cmd.Dir)
 
-	// 5. 실행 및 결과 수집 (stdout + stderr)
-	var outb, errb bytes.Buffer
-	cmd.Stdout = &outb
+	// 5. 실행 및 결과 수집 (stdout은 줄 단위 스트리밍, stderr는 버퍼)
+	var errb bytes.Buffer
+	stdout := &lineWriter{onLine: func(line string) { parser.Feed(line) }}
+	cmd.Stdout = stdout
 	cmd.Stderr = &errb
 
 	err := cmd.Start()
@@ -192,9 +601,14 @@ func (te *TaskExecutor) executeCursorCommand(jobID string, prompt string, projec
 	// 타임아웃 또는 완료 대기
 	select {
 	case <-ctx.Done():
-		// 타임아웃 발생 - 프로세스 그룹 강제 종료
-		log.Printf("[%s] 작업 시간 초과 (15분). 프로세스 그룹 강제 종료 시도...", jobID)
-		if err := process.KillProcessGroup(cmd); err != nil {
+		// 타임아웃 또는 취소 요청 - 프로세스 그룹 종료 (v1.5: SIGTERM 후 유예 시간이 지나면 SIGKILL, Windows는 유예 없이 바로 종료)
+		cause := context.Cause(ctx)
+		if errors.Is(cause, ErrJobCancelled) {
+			log.Printf("[%s] 작업 취소 요청. 프로세스 그룹 종료 시도...", jobID)
+		} else {
+			log.Printf("[%s] 작업 시간 초과 (%s). 프로세스 그룹 종료 시도...", jobID, FormatDuration(opts.Timeout))
+		}
+		if err := process.KillProcessGroup(cmd, opts.KillGrace); err != nil {
 			log.Printf("[%s] 프로세스 종료 실패: %v", jobID, err)
 		}
 		// cmd.Wait()가 완료될 때까지 잠시 대기 (최대 2초)
@@ -206,22 +620,28 @@ func (te *TaskExecutor) executeCursorCommand(jobID string, prompt string, projec
 			log.Printf("[%s] 프로세스 종료 대기 시간 초과", jobID)
 		}
 		// 출력 결합
-		combinedOutput := append(outb.Bytes(), errb.Bytes()...)
-		return combinedOutput, fmt.Errorf("명령어 실행 시간 초과 (15분)")
+		stdout.Flush()
+		combinedOutput := append([]byte(parser.Output()), errb.Bytes()...)
+		if errors.Is(cause, ErrJobCancelled) {
+			return combinedOutput, cause
+		}
+		return combinedOutput, fmt.Errorf("명령어 실행 시간 초과 (%s)", FormatDuration(opts.Timeout))
 
 	case err = <-done:
 		// 정상 완료 또는 에러
 		// 출력 결합
-		combinedOutput := append(outb.Bytes(), errb.Bytes()...)
+		stdout.Flush()
+		combinedOutput := append([]byte(parser.Output()), errb.Bytes()...)
 		if err != nil {
-			return combinedOutput, fmt.Errorf("cursor-agent 실행 실패: %w", err)
+			return combinedOutput, fmt.Errorf("%s 실행 실패: %w", agent.Name(), err)
 		}
 		return combinedOutput, nil
 	}
 }
 
 // sendProgressUpdates는 작업 진행 중 주기적으로 상태를 Slack에 전송합니다
-func (te *TaskExecutor) sendProgressUpdates(jobID string, responseURL string, done <-chan struct{}) {
+// v1.5: 상태 메시지가 있으면 새 메시지 대신 상태 메시지(status + 경과 시간)를 횟수 제한 없이 갱신합니다.
+func (te *TaskExecutor) sendProgressUpdates(jobID string, reply replyTarget, status string, done <-chan struct{}) {
 	ticker := time.NewTicker(2 * time.Minute) // 2분마다 업데이트
 	defer ticker.Stop()
 	
@@ -239,8 +659,8 @@ func (te *TaskExecutor) sendProgressUpdates(jobID string, responseURL string, do
 			elapsed += 120 // 2분 = 120초
 			updateCount++
 			
-			// 최대 업데이트 횟수 제한
-			if updateCount > maxUpdates {
+			// 최대 업데이트 횟수 제한 (상태 메시지 갱신은 response_url 호출 횟수와 무관)
+			if reply.MessageTS == "" && updateCount > maxUpdates {
 				log.Printf("[%s] 최대 업데이트 횟수 도달", jobID)
 				return
 			}
@@ -259,67 +679,39 @@ func (te *TaskExecutor) sendProgressUpdates(jobID string, responseURL string, do
 			log.Printf("[%s] 진행 상황 업데이트: %s", jobID, timeStr)
 			
 			// 진행 상황 메시지 전송
-			te.sendProgressMessage(responseURL, message)
+			if reply.MessageTS != "" {
+				if err := te.updateStatus(reply, jobID, status+"\n"+message, ActionCancelJob); err != nil {
+					log.Printf("[%s] 상태 메시지 갱신 실패: %v", jobID, err)
+				}
+				continue
+			}
+			te.sendProgressMessage(reply, jobID, message)
 		}
 	}
 }
 
 // sendProgressMessage는 진행 상황 메시지를 전송합니다 (SSRF 검증 포함)
-func (te *TaskExecutor) sendProgressMessage(responseURL string, message string) {
-	// 1. (보안 핵심) SSRF 방어를 위한 URL 검증
-	parsedURL, err := url.Parse(responseURL)
-	if err != nil {
-		log.Printf("SSRF 방어: 유효하지 않은 ResponseURL: %s", responseURL)
-		return
-	}
-
-	// 2. 스킴(Scheme) 검증
-	if parsedURL.Scheme != "https" {
-		log.Printf("SSRF 방어: 'https'가 아닌 스킴 차단: %s", parsedURL.Scheme)
-		return
-	}
-
-	// 3. 허용 목록(Allow-list) 기반 도메인 검증
-	isAllowed := false
-	for _, allowedDomain := range te.allowedResponseDomains {
-		if parsedURL.Hostname() == allowedDomain || strings.HasSuffix(parsedURL.Hostname(), "."+allowedDomain) {
-			isAllowed = true
-			break
-		}
-	}
-
-	if !isAllowed {
-		log.Printf("SSRF 방어: 허용되지 않는 도메인으로의 응답 시도 차단: %s", responseURL)
-		return
-	}
-
-	// 4. Slack 메시지 전송 (새 메시지 추가)
+// v1.5: 취소 버튼을 함께 표시합니다.
+func (te *TaskExecutor) sendProgressMessage(reply replyTarget, jobID string, message string) {
+	// Slack 메시지 전송 (새 메시지 추가)
 	payload := types.SlackDelayedResponse{
 		Text:         message,
 		ResponseType: "in_channel", // 채널에 공개
+		Blocks:       MessageBlocks(message, jobID, ActionCancelJob),
 	}
-
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("Error marshaling progress message: %v", err)
-		return
-	}
-
-	resp, err := http.Post(responseURL, "application/json", bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		log.Printf("Error sending progress message to %s: %v", responseURL, err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Slack progress message returned non-200 status: %d", resp.StatusCode)
+	if err := te.postReply(reply, payload); err != nil {
+		log.Printf("Error sending progress message: %v", err)
 	}
 }
 
+// maxSlackDiffChars는 Slack 메시지에 포함하는 diff의 최대 길이입니다.
+const maxSlackDiffChars = 8000
+
 // formatSuccessOutput은 cursor-agent 성공 출력을 Slack 마크다운으로 포맷팅합니다.
 // 반환값: 메시지 배열 (40,000자씩 분할)
-func (te *TaskExecutor) formatSuccessOutput(jobID string, rawOutput string, prompt string) []string {
+// v1.5: worktree 격리 모드면 작업 브랜치를 함께 표시합니다.
+// v1.5: changes가 있으면 git 스냅샷 diff를 변경 파일/코드의 기준으로 사용합니다 (없으면 출력에서 추정).
+func (te *TaskExecutor) formatSuccessOutput(jobID string, rawOutput string, prompt string, branch string, changes *jobChanges, maxMessages int) []string {
 	var result strings.Builder
 	result.WriteString("✅ *Cursor AI 작업 완료*\n\n")
 	result.WriteString(fmt.Sprintf("📝 *요청 프롬프트*\n> %s\n\n", prompt))
@@ -327,30 +719,61 @@ func (te *TaskExecutor) formatSuccessOutput(jobID string, rawOutput string, prom
 	// cursor-agent 출력 파싱
 	lines := strings.Split(rawOutput, "\n")
 	
-	// 변경된 파일 목록 추출
-	modifiedFiles := te.extractModifiedFiles(lines)
-	if len(modifiedFiles) > 0 {
-		result.WriteString("📁 *변경된 파일*\n")
-		for _, file := range modifiedFiles {
-			result.WriteString(fmt.Sprintf("• `%s`\n", file))
+	if changes != nil {
+		// v1.5: 실행 전후 스냅샷 기준 변경 파일
+		if len(changes.Files) > 0 {
+			result.WriteString("📁 *변경된 파일*\n")
+			for _, f := range changes.Files {
+				if f.OldPath != "" {
+					result.WriteString(fmt.Sprintf("• `%s` → `%s` (%s)\n", f.OldPath, f.Path, changeStatusText(f.Status)))
+				} else {
+					result.WriteString(fmt.Sprintf("• `%s` (%s)\n", f.Path, changeStatusText(f.Status)))
+				}
+			}
+			result.WriteString("\n")
+		} else {
+			result.WriteString("📁 *변경된 파일*\n변경된 파일이 없습니다.\n\n")
+		}
+
+		// v1.5: 실행 전후 스냅샷 기준 diff
+		if changes.Diff != "" {
+			diff := changes.Diff
+			if changes.DiffFile != "" {
+				diff += "\n... (전체 diff: 스레드에 첨부한 파일 `" + changes.DiffFile + "`)\n"
+			} else if len(diff) > maxSlackDiffChars {
+				diff = textutil.Truncate(diff, maxSlackDiffChars) + "\n... (전체 diff: `/cursor show " + jobID[:8] + "` 또는 GET /api/jobs/{id})\n"
+			}
+			result.WriteString("💻 *변경된 코드*\n")
+			result.WriteString("```\n")
+			result.WriteString(diff)
+			result.WriteString("```\n\n")
+		}
+	} else {
+		// 변경된 파일 목록 추출
+		modifiedFiles := te.extractModifiedFiles(lines)
+		if len(modifiedFiles) > 0 {
+			result.WriteString("📁 *변경된 파일*\n")
+			for _, file := range modifiedFiles {
+				result.WriteString(fmt.Sprintf("• `%s`\n", file))
+			}
+			result.WriteString("\n")
+		}
+
+		// 실제 코드 변경 내용 추출 (코드 블록 형태로 표시)
+		codeChanges := te.extractCodeChanges(lines)
+		if codeChanges != "" {
+			result.WriteString("💻 *변경된 코드*\n")
+			result.WriteString("```\n")
+			result.WriteString(codeChanges)
+			result.WriteString("```\n\n")
 		}
-		result.WriteString("\n")
-	}
-	
-	// 실제 코드 변경 내용 추출 (코드 블록 형태로 표시)
-	codeChanges := te.extractCodeChanges(lines)
-	if codeChanges != "" {
-		result.WriteString("💻 *변경된 코드*\n")
-		result.WriteString("```\n")
-		result.WriteString(codeChanges)
-		result.WriteString("```\n\n")
 	}
-	
+
 	// 주요 변경 사항 추출 (diff가 있으면 표시)
-	changes := te.extractChangeSummary(lines)
-	if changes != "" {
+	summary := te.extractChangeSummary(lines)
+	if summary != "" {
 		result.WriteString("🔧 *주요 변경 사항*\n")
-		result.WriteString(changes)
+		result.WriteString(summary)
 		result.WriteString("\n")
 	}
 	
@@ -358,15 +781,18 @@ func (te *TaskExecutor) formatSuccessOutput(jobID string, rawOutput string, prom
 	result.WriteString("📄 *실행 결과*\n\n")
 	slackFormattedOutput := te.convertMarkdownToSlack(rawOutput)
 	result.WriteString(slackFormattedOutput)
+	if branch != "" {
+		result.WriteString(fmt.Sprintf("\n\n🌿 작업 브랜치: `%s`", branch))
+	}
 	result.WriteString(fmt.Sprintf("\n\n🆔 Job ID: `%s`", jobID[:8]))
 	
 	// 메시지를 40,000자 단위로 분할
-	return te.splitMessage(result.String())
+	return te.splitMessage(result.String(), maxMessages)
 }
 
 // formatErrorOutput은 에러 출력을 Slack 마크다운으로 포맷팅합니다.
 // 반환값: 메시지 배열 (40,000자씩 분할)
-func (te *TaskExecutor) formatErrorOutput(jobID string, err error, rawOutput string) []string {
+func (te *TaskExecutor) formatErrorOutput(jobID string, err error, rawOutput string, maxMessages int) []string {
 	var result strings.Builder
 	result.WriteString("❌ *Cursor AI 실행 중 오류 발생*\n\n")
 	result.WriteString(fmt.Sprintf("🚨 *오류 메시지*\n> %s\n\n", err.Error()))
@@ -382,7 +808,7 @@ func (te *TaskExecutor) formatErrorOutput(jobID string, err error, rawOutput str
 	result.WriteString(fmt.Sprintf("\n💡 자세한 정보: `/cursor show %s`", jobID[:8]))
 	
 	// 메시지를 40,000자 단위로 분할
-	return te.splitMessage(result.String())
+	return te.splitMessage(result.String(), maxMessages)
 }
 
 // extractModifiedFiles는 cursor-agent 출력에서 변경된 파일 목록을 추출합니다.
@@ -735,9 +1161,9 @@ func (te *TaskExecutor) convertMarkdownLinks(text string) string {
 }
 
 // splitMessage는 메시지를 Slack 최대 크기(40,000자)로 분할합니다.
-func (te *TaskExecutor) splitMessage(message string) []string {
+// v1.5: maxMessages는 보낼 수 있는 최대 메시지 수입니다 (response_url 5회, 스레드 게시는 더 많이).
+func (te *TaskExecutor) splitMessage(message string, maxMessages int) []string {
 	const maxSlackMessageSize = 40000
-	const maxMessages = 5 // Slack response_url 최대 호출 횟수
 	
 	// 메시지가 최대 크기 이하면 그대로 반환
 	if len(message) <= maxSlackMessageSize {
@@ -789,10 +1215,14 @@ func (te *TaskExecutor) splitMessage(message string) []string {
 }
 
 // sendMultipleMessages는 여러 메시지를 순차적으로 전송합니다.
-func (te *TaskExecutor) sendMultipleMessages(responseURL string, messages []string, jobID string) {
+// v1.5: responseType으로 채널 공개(in_channel) / 요청자 전용(ephemeral)을 지정합니다.
+func (te *TaskExecutor) sendMultipleMessages(reply replyTarget, messages []string, jobID string, responseType string) {
 	for i, message := range messages {
 		log.Printf("[%s] 메시지 전송 (%d/%d): %d자", jobID, i+1, len(messages), len(message))
-		te.sendDelayedResponse(responseURL, message)
+		err := te.postReply(reply, types.SlackDelayedResponse{Text: message, ResponseType: responseType})
+		if err != nil {
+			log.Printf("[%s] 메시지 전송 실패: %v", jobID, err)
+		}
 		
 		// 메시지 간 짧은 대기 (Slack rate limit 방지)
 		if i < len(messages)-1 {
@@ -801,19 +1231,33 @@ func (te *TaskExecutor) sendMultipleMessages(responseURL string, messages []stri
 	}
 }
 
-// sendDelayedResponse는 SSRF 공격을 방지하기 위해 ResponseURL을 검증한 후 전송합니다.
-func (te *TaskExecutor) sendDelayedResponse(responseURL string, message string) {
+// sendDelayedResponse는 텍스트 메시지를 채널에 공개로 전송합니다.
+// response_url로 보낼 때는 SSRF 공격을 방지하기 위해 URL을 검증합니다.
+func (te *TaskExecutor) sendDelayedResponse(reply replyTarget, message string) {
+	payload := types.SlackDelayedResponse{
+		Text:         message,
+		ResponseType: "in_channel", // 채널에 공개
+	}
+	if err := te.postReply(reply, payload); err != nil {
+		log.Printf("Error sending delayed response: %v", err)
+	}
+}
+
+// PostDelayedResponse는 ResponseURL을 검증한 후 지연 응답을 전송합니다.
+// v1.5: Block Kit 메시지 전송 실패 시 텍스트로 대체할 수 있도록 오류를 반환합니다.
+// 서버의 인터랙션 핸들러도 버튼 클릭 응답을 보낼 때 사용합니다.
+func (te *TaskExecutor) PostDelayedResponse(responseURL string, payload types.SlackDelayedResponse) error {
 	// 1. (보안 핵심) SSRF 방어를 위한 URL 검증
 	parsedURL, err := url.Parse(responseURL)
 	if err != nil {
 		log.Printf("SSRF 방어: 유효하지 않은 ResponseURL: %s", responseURL)
-		return
+		return fmt.Errorf("유효하지 않은 ResponseURL: %w", err)
 	}
 
 	// 2. 스킴(Scheme) 검증
 	if parsedURL.Scheme != "https" {
 		log.Printf("SSRF 방어: 'https'가 아닌 스킴 차단: %s", parsedURL.Scheme)
-		return
+		return fmt.Errorf("허용되지 않는 스킴: %s", parsedURL.Scheme)
 	}
 
 	// 3. 허용 목록(Allow-list) 기반 도메인 검증
@@ -827,29 +1271,24 @@ func (te *TaskExecutor) sendDelayedResponse(responseURL string, message string)
 
 	if !isAllowed {
 		log.Printf("SSRF 방어: 허용되지 않는 도메인으로의 응답 시도 차단: %s", responseURL)
-		return
+		return fmt.Errorf("허용되지 않는 도메인: %s", parsedURL.Hostname())
 	}
 
 	// 4. Slack 응답 전송
-	payload := types.SlackDelayedResponse{
-		Text:         message,
-		ResponseType: "in_channel", // 채널에 공개
-	}
-
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("Error marshaling delayed response: %v", err)
-		return
+		return fmt.Errorf("error marshaling delayed response: %w", err)
 	}
 
 	resp, err := http.Post(responseURL, "application/json", bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		log.Printf("Error sending delayed response to %s: %v", responseURL, err)
-		return
+		return fmt.Errorf("error sending delayed response to %s: %w", responseURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("Slack delayed response returned non-200 status: %d", resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack delayed response returned non-200 status: %d %s", resp.StatusCode, strings.TrimSpace(string(body)))
 	}
+	return nil
 }
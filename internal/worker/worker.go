@@ -14,10 +14,11 @@ type Worker struct {
 	wg          *sync.WaitGroup
 	quit        chan struct{}
 	executor    *TaskExecutor   // (의존성 주입) 실제 작업 실행기
+	queue       *Queue          // v1.5: 작업을 마친 뒤 프롬프트 원문 정리
 }
 
 // NewWorker는 작업자를 생성합니다.
-func NewWorker(id int, pool chan chan Job, wg *sync.WaitGroup, quit chan struct{}, exec *TaskExecutor) *Worker {
+func NewWorker(id int, pool chan chan Job, wg *sync.WaitGroup, quit chan struct{}, exec *TaskExecutor, queue *Queue) *Worker {
 	return &Worker{
 		ID:          id,
 		WorkerPool:  pool,
@@ -25,6 +26,7 @@ func NewWorker(id int, pool chan chan Job, wg *sync.WaitGroup, quit chan struct{
 		wg:          wg,
 		quit:        quit,
 		executor:    exec,
+		queue:       queue,
 	}
 }
 
@@ -47,6 +49,7 @@ func (w *Worker) Start() {
 				//    이 작업은 동기적으로 실행되며, 이 작업이 끝나야 다음 작업을 받습니다.
 				//    이것이 동시성을 'N'개로 제어하는 핵심입니다.
 				w.executor.Run(job)
+				w.queue.forgetFinished()
 
 				log.Printf("작업자 #%d: Job %s 처리 완료", w.ID, job.ID)
 
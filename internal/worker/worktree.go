@@ -0,0 +1,230 @@
+package worker
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kakaovx/cursor-slack-server/internal/database"
+	"github.com/kakaovx/cursor-slack-server/internal/git"
+)
+
+// WorktreeCleanup은 작업 종료 후 worktree 정리 정책입니다.
+type WorktreeCleanup string
+
+const (
+	WorktreeCleanupIfClean WorktreeCleanup = "if-clean" // 변경 사항이 없을 때만 삭제 (기본값)
+	WorktreeCleanupAlways  WorktreeCleanup = "always"   // 항상 worktree와 브랜치 삭제
+	WorktreeCleanupKeep    WorktreeCleanup = "keep"     // 항상 유지 (수동 정리)
+)
+
+// ParseWorktreeCleanup은 WORKTREE_CLEANUP 환경 변수 값을 해석합니다.
+func ParseWorktreeCleanup(value string) (WorktreeCleanup, error) {
+	switch c := WorktreeCleanup(strings.ToLower(strings.TrimSpace(value))); c {
+	case "":
+		return WorktreeCleanupIfClean, nil
+	case WorktreeCleanupIfClean, WorktreeCleanupAlways, WorktreeCleanupKeep:
+		return c, nil
+	default:
+		return "", fmt.Errorf("알 수 없는 worktree 정리 정책: %s (if-clean, always, keep 중 선택)", value)
+	}
+}
+
+// WorktreeConfig는 작업별 git worktree 격리 설정입니다 (v1.5)
+//
+// 활성화하면 각 작업이 프로젝트 HEAD에서 만든 `cursor/job-<ID>` 브랜치의 별도 worktree에서 실행되므로,
+// 여러 작업자가 같은 저장소를 동시에 수정해도 서로의 변경 사항을 덮어쓰지 않습니다.
+type WorktreeConfig struct {
+	Enabled bool            // WORKTREE_MODE=on
+	Dir     string          // worktree를 생성할 기본 디렉토리 (WORKTREE_DIR)
+	Cleanup WorktreeCleanup // 작업 종료 후 정리 정책 (WORKTREE_CLEANUP)
+}
+
+// worktree는 작업 하나에 할당된 git worktree입니다.
+type worktree struct {
+	RepoDir string // 원본 저장소 최상위 경로
+	Path    string // worktree 경로 (저장소 최상위)
+	Branch  string // 작업 브랜치
+	Rel     string // 저장소 최상위 기준 프로젝트 경로 (하위 디렉토리로 등록된 프로젝트, 최상위면 ".")
+}
+
+// Dir은 worktree 안의 프로젝트 경로(cursor-agent 실행 위치)를 반환합니다.
+// 직접 실행 모드와 같은 디렉토리에서 실행되도록 Rel을 붙입니다.
+func (wt *worktree) Dir() string {
+	return filepath.Join(wt.Path, wt.Rel)
+}
+
+// projectRel은 repoDir 기준 projectPath의 상대 경로를 반환합니다.
+// git은 심볼릭 링크를 해석한 최상위 경로를 반환하므로 양쪽 모두 해석한 뒤 비교합니다.
+func projectRel(repoDir string, projectPath string) (string, error) {
+	root, err := filepath.EvalSymlinks(repoDir)
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.EvalSymlinks(projectPath)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("프로젝트 경로가 저장소 밖에 있습니다: %s", projectPath)
+	}
+	return rel, nil
+}
+
+// worktreeMu는 같은 저장소에 대한 worktree 추가/삭제를 직렬화합니다.
+// (git은 .git/worktrees 및 ref 갱신 시 잠금 파일을 사용하므로 동시 실행 시 실패할 수 있음)
+var worktreeMu sync.Mutex
+
+// jobBranchName은 작업 ID로 브랜치 이름을 만듭니다.
+func jobBranchName(jobID string) string {
+	return "cursor/job-" + shortID(jobID)
+}
+
+// shortID는 표시용 8자리 작업 ID를 반환합니다.
+func shortID(jobID string) string {
+	if len(jobID) > 8 {
+		return jobID[:8]
+	}
+	return jobID
+}
+
+// prepareWorktree는 projectPath 저장소에 작업 전용 worktree를 생성합니다.
+// 재시도된 작업이라 이전 worktree가 남아 있으면 삭제 후 다시 만듭니다.
+func (c WorktreeConfig) prepareWorktree(jobID string, projectPath string) (*worktree, error) {
+	return c.addWorktree(jobID, projectPath, shortID(jobID), jobBranchName(jobID))
+}
+
+// preparePlanWorktree는 승인 전 계획 단계 전용 worktree를 생성합니다 (v1.5)
+// 승인 후 실행할 worktree와 겹치지 않도록 별도 경로(<저장소>-<ID>-plan)와 브랜치(cursor/plan-<ID>)를 사용하며,
+// 계획 단계가 끝나면 정리 정책과 관계없이 삭제합니다 (removeWorktree).
+func (c WorktreeConfig) preparePlanWorktree(jobID string, projectPath string) (*worktree, error) {
+	return c.addWorktree(jobID, projectPath, shortID(jobID)+"-plan", "cursor/plan-"+shortID(jobID))
+}
+
+// addWorktree는 projectPath 저장소에 <저장소>-<suffix> 경로와 branch 브랜치로 worktree를 생성합니다.
+func (c WorktreeConfig) addWorktree(jobID string, projectPath string, suffix string, branch string) (*worktree, error) {
+	repoDir, err := git.TopLevel(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("worktree 격리 모드에는 git 저장소가 필요합니다: %w", err)
+	}
+	rel, err := projectRel(repoDir, projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	wt := &worktree{
+		RepoDir: repoDir,
+		Path:    filepath.Join(c.Dir, filepath.Base(repoDir)+"-"+suffix),
+		Branch:  branch,
+		Rel:     rel,
+	}
+
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("worktree 디렉토리 생성 실패: %w", err)
+	}
+
+	// 이전 시도에서 남은 worktree 정리
+	if _, err := os.Stat(wt.Path); err == nil {
+		log.Printf("[%s] 이전 시도의 worktree를 삭제합니다: %s", jobID, wt.Path)
+		if err := git.RemoveWorktree(repoDir, wt.Path); err != nil {
+			os.RemoveAll(wt.Path)
+		}
+	}
+	git.PruneWorktrees(repoDir)
+
+	if err := git.AddWorktree(repoDir, wt.Path, wt.Branch); err != nil {
+		return nil, fmt.Errorf("worktree 생성 실패: %w", err)
+	}
+	// 커밋되지 않은 하위 디렉토리는 worktree에 없음
+	if _, err := os.Stat(wt.Dir()); err != nil {
+		git.RemoveWorktree(repoDir, wt.Path)
+		git.DeleteBranch(repoDir, wt.Branch)
+		return nil, fmt.Errorf("worktree에 프로젝트 디렉토리가 없습니다 (커밋되지 않은 디렉토리): %s", rel)
+	}
+	return wt, nil
+}
+
+// reuseWorktree는 이어서 실행하는 작업이 원본 작업의 worktree를 사용할 수 있으면 반환합니다 (v1.5)
+// 원본 작업의 변경 사항은 worktree에만 있으므로, 남아 있으면 같은 worktree/브랜치에서 이어서 실행합니다.
+// 원본 worktree가 정리되었으면 nil을 반환하며, 이 경우 새 worktree를 만듭니다.
+func (c WorktreeConfig) reuseWorktree(projectPath string, parent *database.JobRecord) *worktree {
+	if parent == nil || parent.WorktreePath == "" || parent.Branch == "" {
+		return nil
+	}
+	if _, err := os.Stat(parent.WorktreePath); err != nil {
+		return nil
+	}
+	repoDir, err := git.TopLevel(projectPath)
+	if err != nil {
+		return nil
+	}
+	rel, err := projectRel(repoDir, projectPath)
+	if err != nil {
+		return nil
+	}
+	return &worktree{RepoDir: repoDir, Path: parent.WorktreePath, Branch: parent.Branch, Rel: rel}
+}
+
+// cleanupWorktree는 정리 정책에 따라 worktree와 작업 브랜치를 삭제합니다.
+// 삭제했으면 true를 반환합니다.
+func (c WorktreeConfig) cleanupWorktree(jobID string, wt *worktree) bool {
+	switch c.Cleanup {
+	case WorktreeCleanupKeep:
+		return false
+	case WorktreeCleanupIfClean, "":
+		clean, err := git.IsClean(wt.Path)
+		if err != nil {
+			log.Printf("[%s] worktree 상태 확인 실패, 유지합니다: %v", jobID, err)
+			return false
+		}
+		if !clean {
+			log.Printf("[%s] 변경 사항이 있어 worktree를 유지합니다: %s (브랜치: %s)", jobID, wt.Path, wt.Branch)
+			return false
+		}
+	}
+
+	return removeWorktree(jobID, wt)
+}
+
+// removeWorktree는 worktree와 작업 브랜치를 삭제합니다. 삭제했으면 true를 반환합니다.
+func removeWorktree(jobID string, wt *worktree) bool {
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
+
+	if err := git.RemoveWorktree(wt.RepoDir, wt.Path); err != nil {
+		log.Printf("[%s] worktree 삭제 실패: %v", jobID, err)
+		return false
+	}
+	if err := git.DeleteBranch(wt.RepoDir, wt.Branch); err != nil {
+		log.Printf("[%s] 작업 브랜치 삭제 실패: %v", jobID, err)
+	}
+	log.Printf("[%s] worktree 삭제 완료: %s", jobID, wt.Path)
+	return true
+}
+
+// repoLocks는 직접 실행 모드의 저장소별 실행 잠금입니다 (저장소 최상위 경로 → *sync.Mutex).
+var repoLocks sync.Map
+
+// lockRepo는 dir이 속한 저장소의 실행 잠금을 얻고 해제 함수를 반환합니다 (v1.5)
+// worktree 격리 없이 같은 체크아웃에서 작업이 동시에 실행되면 서로의 스냅샷 사이 변경이 섞이고,
+// 계획 단계의 복원이나 되돌리기가 다른 작업의 변경을 덮어쓸 수 있으므로 같은 저장소의 작업을 하나씩 실행합니다.
+func lockRepo(jobID string, dir string) func() {
+	key := dir
+	if repoDir, err := git.TopLevel(dir); err == nil {
+		key = repoDir
+	}
+	m, _ := repoLocks.LoadOrStore(key, &sync.Mutex{})
+	mu := m.(*sync.Mutex)
+	if !mu.TryLock() {
+		log.Printf("[%s] 같은 저장소에서 실행 중인 작업이 끝나길 기다립니다: %s", jobID, key)
+		mu.Lock()
+	}
+	return mu.Unlock
+}
@@ -0,0 +1,232 @@
+package worker
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestRepo는 커밋 하나가 있는 임시 git 저장소를 만듭니다.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	repo := filepath.Join(t.TempDir(), "repo")
+	if err := os.MkdirAll(filepath.Join(repo, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "sub", "README.md"), []byte("# test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v 실패: %v\n%s", args, err, out)
+		}
+	}
+	return repo
+}
+
+func TestParseWorktreeCleanup(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    WorktreeCleanup
+		wantErr bool
+	}{
+		{"", WorktreeCleanupIfClean, false},
+		{"if-clean", WorktreeCleanupIfClean, false},
+		{" Always ", WorktreeCleanupAlways, false},
+		{"KEEP", WorktreeCleanupKeep, false},
+		{"never", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWorktreeCleanup(tt.value)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseWorktreeCleanup(%q) = %q, %v; want %q, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPrepareWorktree(t *testing.T) {
+	repo := newTestRepo(t)
+	c := WorktreeConfig{Enabled: true, Dir: filepath.Join(t.TempDir(), "worktrees")}
+	const jobID = "12345678-aaaa-bbbb-cccc-000000000000"
+
+	wt, err := c.prepareWorktree(jobID, repo)
+	if err != nil {
+		t.Fatalf("prepareWorktree: %v", err)
+	}
+	if wt.Branch != "cursor/job-12345678" || wt.Path != filepath.Join(c.Dir, "repo-12345678") || wt.Dir() != wt.Path {
+		t.Errorf("worktree = %+v", wt)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "sub", "README.md")); err != nil {
+		t.Errorf("worktree에 커밋된 파일이 없습니다: %v", err)
+	}
+
+	// 재시도된 작업은 남은 worktree를 지우고 다시 만듦
+	os.WriteFile(filepath.Join(wt.Path, "stale.txt"), []byte("x"), 0644)
+	if _, err := c.prepareWorktree(jobID, repo); err != nil {
+		t.Fatalf("prepareWorktree (retry): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "stale.txt")); !os.IsNotExist(err) {
+		t.Errorf("이전 시도의 파일이 남아 있습니다: %v", err)
+	}
+
+	if _, err := c.prepareWorktree(jobID, t.TempDir()); err == nil {
+		t.Error("git 저장소가 아닌 경로: want error")
+	}
+}
+
+func TestPreparePlanWorktree(t *testing.T) {
+	repo := newTestRepo(t)
+	c := WorktreeConfig{Enabled: true, Dir: filepath.Join(t.TempDir(), "worktrees")}
+	const jobID = "12345678-aaaa-bbbb-cccc-000000000000"
+
+	plan, err := c.preparePlanWorktree(jobID, repo)
+	if err != nil {
+		t.Fatalf("preparePlanWorktree: %v", err)
+	}
+	if plan.Branch != "cursor/plan-12345678" || plan.Path != filepath.Join(c.Dir, "repo-12345678-plan") {
+		t.Errorf("plan worktree = %+v", plan)
+	}
+
+	// 승인 후 실행할 worktree는 계획 worktree와 겹치지 않음
+	wt, err := c.prepareWorktree(jobID, repo)
+	if err != nil {
+		t.Fatalf("prepareWorktree: %v", err)
+	}
+	if _, err := os.Stat(plan.Path); err != nil {
+		t.Errorf("계획 worktree가 삭제되었습니다: %v", err)
+	}
+
+	if !removeWorktree(jobID, plan) {
+		t.Fatal("removeWorktree = false")
+	}
+	if _, err := os.Stat(plan.Path); !os.IsNotExist(err) {
+		t.Errorf("계획 worktree가 남아 있습니다: %v", err)
+	}
+	if _, err := os.Stat(wt.Path); err != nil {
+		t.Errorf("작업 worktree가 삭제되었습니다: %v", err)
+	}
+}
+
+func TestPrepareWorktreeSubdirectory(t *testing.T) {
+	repo := newTestRepo(t)
+	c := WorktreeConfig{Enabled: true, Dir: filepath.Join(t.TempDir(), "worktrees")}
+
+	// 하위 디렉토리로 등록된 프로젝트는 worktree 안의 같은 디렉토리에서 실행
+	wt, err := c.prepareWorktree("12345678-0000", filepath.Join(repo, "sub"))
+	if err != nil {
+		t.Fatalf("prepareWorktree: %v", err)
+	}
+	if wt.Rel != "sub" || wt.Dir() != filepath.Join(wt.Path, "sub") {
+		t.Errorf("worktree = %+v, Dir() = %s", wt, wt.Dir())
+	}
+
+	// 커밋되지 않은 하위 디렉토리는 worktree에 없으므로 실패하고 브랜치를 남기지 않음
+	os.MkdirAll(filepath.Join(repo, "untracked"), 0755)
+	if _, err := c.prepareWorktree("87654321-0000", filepath.Join(repo, "untracked")); err == nil {
+		t.Fatal("커밋되지 않은 디렉토리: want error")
+	}
+	branch := exec.Command("git", "rev-parse", "--verify", "--quiet", "cursor/job-87654321")
+	branch.Dir = repo
+	if branch.Run() == nil {
+		t.Error("실패한 작업의 브랜치가 남아 있습니다")
+	}
+}
+
+func TestProjectRel(t *testing.T) {
+	repo := newTestRepo(t)
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(repo, link); err != nil {
+		t.Skipf("symlink: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		repoDir string
+		project string
+		want    string
+		wantErr bool
+	}{
+		{"top level", repo, repo, ".", false},
+		{"subdirectory", repo, filepath.Join(repo, "sub"), "sub", false},
+		{"symlinked project", repo, filepath.Join(link, "sub"), "sub", false},
+		{"outside repository", filepath.Join(repo, "sub"), repo, "", true},
+		{"missing path", repo, filepath.Join(repo, "missing"), "", true},
+	}
+	for _, tt := range tests {
+		got, err := projectRel(tt.repoDir, tt.project)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("%s: projectRel() = %q, %v; want %q, wantErr %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCleanupWorktree(t *testing.T) {
+	tests := []struct {
+		name        string
+		cleanup     WorktreeCleanup
+		dirty       bool
+		wantRemoved bool
+	}{
+		{"if-clean clean", WorktreeCleanupIfClean, false, true},
+		{"if-clean dirty", WorktreeCleanupIfClean, true, false},
+		{"always dirty", WorktreeCleanupAlways, true, true},
+		{"keep clean", WorktreeCleanupKeep, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepo(t)
+			c := WorktreeConfig{Enabled: true, Dir: filepath.Join(t.TempDir(), "worktrees"), Cleanup: tt.cleanup}
+			wt, err := c.prepareWorktree("12345678-0000", repo)
+			if err != nil {
+				t.Fatalf("prepareWorktree: %v", err)
+			}
+			if tt.dirty {
+				os.WriteFile(filepath.Join(wt.Path, "change.txt"), []byte("변경"), 0644)
+			}
+
+			if removed := c.cleanupWorktree("12345678-0000", wt); removed != tt.wantRemoved {
+				t.Errorf("cleanupWorktree() = %v, want %v", removed, tt.wantRemoved)
+			}
+			_, statErr := os.Stat(wt.Path)
+			if exists := statErr == nil; exists == tt.wantRemoved {
+				t.Errorf("worktree exists = %v after cleanup", exists)
+			}
+			branch := exec.Command("git", "rev-parse", "--verify", "--quiet", wt.Branch)
+			branch.Dir = repo
+			if hasBranch := branch.Run() == nil; hasBranch == tt.wantRemoved {
+				t.Errorf("branch exists = %v after cleanup", hasBranch)
+			}
+		})
+	}
+}
+
+func TestLockRepoSharesRepository(t *testing.T) {
+	repo := newTestRepo(t)
+	unlock := lockRepo("11111111", repo)
+
+	// 같은 저장소의 하위 디렉토리 프로젝트도 같은 잠금을 사용
+	acquired := make(chan struct{})
+	go func() {
+		defer lockRepo("22222222", filepath.Join(repo, "sub"))()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("같은 저장소의 작업이 동시에 잠금을 얻었습니다")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("잠금 해제 후에도 다음 작업이 잠금을 얻지 못했습니다")
+	}
+}